@@ -0,0 +1,195 @@
+package cuda
+
+//#include <cuda.h>
+import "C"
+import (
+	"fmt"
+
+	"github.com/negativeOne1/gocudnn/cuda/occupancy"
+)
+
+//FuncAttribute is a CUfunction_attribute.  Flags are set through methods.
+type FuncAttribute C.CUfunction_attribute
+
+func (f FuncAttribute) c() C.CUfunction_attribute { return C.CUfunction_attribute(f) }
+
+//MaxThreadsPerBlock is the most threads a block of the function can have.  Read only.
+func (f *FuncAttribute) MaxThreadsPerBlock() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_MAX_THREADS_PER_BLOCK)
+	return *f
+}
+
+//SharedSizeBytes is the statically allocated shared memory of the function.  Read only.
+func (f *FuncAttribute) SharedSizeBytes() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_SHARED_SIZE_BYTES)
+	return *f
+}
+
+//ConstSizeBytes is the user constant memory of the function.  Read only.
+func (f *FuncAttribute) ConstSizeBytes() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_CONST_SIZE_BYTES)
+	return *f
+}
+
+//LocalSizeBytes is the local memory per thread of the function.  Read only.
+func (f *FuncAttribute) LocalSizeBytes() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_LOCAL_SIZE_BYTES)
+	return *f
+}
+
+//NumRegs is the registers per thread of the function.  Read only.
+func (f *FuncAttribute) NumRegs() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_NUM_REGS)
+	return *f
+}
+
+//PTXVersion is the ptx version the function was compiled for as major*10+minor.  Read only.
+func (f *FuncAttribute) PTXVersion() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_PTX_VERSION)
+	return *f
+}
+
+//BinaryVersion is the architecture the function's binary was compiled for as major*10+minor.  Read only.
+func (f *FuncAttribute) BinaryVersion() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_BINARY_VERSION)
+	return *f
+}
+
+//MaxDynamicSharedSizeBytes is the most dynamic shared memory a launch of the function can ask for.  It can be set.
+func (f *FuncAttribute) MaxDynamicSharedSizeBytes() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_MAX_DYNAMIC_SHARED_SIZE_BYTES)
+	return *f
+}
+
+//PreferredSharedMemoryCarveout is the percent of the L1 cache and shared memory that the function would like as shared memory.  It can be set.
+func (f *FuncAttribute) PreferredSharedMemoryCarveout() FuncAttribute {
+	*f = FuncAttribute(C.CU_FUNC_ATTRIBUTE_PREFERRED_SHARED_MEMORY_CARVEOUT)
+	return *f
+}
+
+//FuncCache is a CUfunc_cache.  Flags are set through methods.
+type FuncCache C.CUfunc_cache
+
+func (f FuncCache) c() C.CUfunc_cache { return C.CUfunc_cache(f) }
+
+//PreferNone has no preference for shared memory or L1
+func (f *FuncCache) PreferNone() FuncCache {
+	*f = FuncCache(C.CU_FUNC_CACHE_PREFER_NONE)
+	return *f
+}
+
+//PreferShared prefers larger shared memory and smaller L1 cache
+func (f *FuncCache) PreferShared() FuncCache {
+	*f = FuncCache(C.CU_FUNC_CACHE_PREFER_SHARED)
+	return *f
+}
+
+//PreferL1 prefers larger L1 cache and smaller shared memory
+func (f *FuncCache) PreferL1() FuncCache {
+	*f = FuncCache(C.CU_FUNC_CACHE_PREFER_L1)
+	return *f
+}
+
+//PreferEqual prefers equal sized L1 cache and shared memory
+func (f *FuncCache) PreferEqual() FuncCache {
+	*f = FuncCache(C.CU_FUNC_CACHE_PREFER_EQUAL)
+	return *f
+}
+
+//Attribute returns the value of attr for the kernel.
+func (k *Kernel) Attribute(attr FuncAttribute) (int, error) {
+	var val C.int
+	err := newErrorDriver("cuFuncGetAttribute", C.cuFuncGetAttribute(&val, attr.c(), k.f))
+	return int(val), err
+}
+
+//SetAttribute sets attr for the kernel.  Only MaxDynamicSharedSizeBytes and PreferredSharedMemoryCarveout can be set.
+func (k *Kernel) SetAttribute(attr FuncAttribute, value int) error {
+	err := newErrorDriver("cuFuncSetAttribute", C.cuFuncSetAttribute(k.f, attr.c(), C.int(value)))
+	k.mux.Lock()
+	k.limits = nil
+	k.mux.Unlock()
+	return err
+}
+
+//NumRegs returns the registers per thread the kernel uses.
+func (k *Kernel) NumRegs() (int, error) {
+	var a FuncAttribute
+	return k.Attribute(a.NumRegs())
+}
+
+//StaticSharedBytes returns the bytes of shared memory that the kernel declares.
+func (k *Kernel) StaticSharedBytes() (int, error) {
+	var a FuncAttribute
+	return k.Attribute(a.SharedSizeBytes())
+}
+
+//MaxThreadsPerBlock returns the most threads a block of the kernel can have.  It can be less than the device's limit if the kernel uses a lot of registers.
+func (k *Kernel) MaxThreadsPerBlock() (int, error) {
+	var a FuncAttribute
+	return k.Attribute(a.MaxThreadsPerBlock())
+}
+
+//MaxDynamicSharedBytes returns the most dynamic shared memory a launch of the kernel can ask for.
+func (k *Kernel) MaxDynamicSharedBytes() (int, error) {
+	var a FuncAttribute
+	return k.Attribute(a.MaxDynamicSharedSizeBytes())
+}
+
+//SetMaxDynamicSharedBytes raises (or lowers) the dynamic shared memory a launch of the kernel can ask for.
+//Asking for more than 48KB needs this on devices that allow it.
+func (k *Kernel) SetMaxDynamicSharedBytes(bytes int) error {
+	var a FuncAttribute
+	return k.SetAttribute(a.MaxDynamicSharedSizeBytes(), bytes)
+}
+
+//SetSharedMemoryCarveout sets the percent of L1 and shared memory the kernel would like to be shared memory.  It is only a hint.
+func (k *Kernel) SetSharedMemoryCarveout(percent int) error {
+	if percent < 0 || percent > 100 {
+		return fmt.Errorf("(k *Kernel) SetSharedMemoryCarveout: %d isn't a percent", percent)
+	}
+	var a FuncAttribute
+	return k.SetAttribute(a.PreferredSharedMemoryCarveout(), percent)
+}
+
+//SetCacheConfig sets the preference of shared memory or L1 cache for the kernel.
+func (k *Kernel) SetCacheConfig(config FuncCache) error {
+	return newErrorDriver("cuFuncSetCacheConfig", C.cuFuncSetCacheConfig(k.f, config.c()))
+}
+
+//Limits returns the attributes of the kernel that limit how it can be launched and its occupancy.
+func (k *Kernel) Limits() (o occupancy.Kernel, err error) {
+	if o.Registers, err = k.NumRegs(); err != nil {
+		return o, err
+	}
+	if o.StaticShared, err = k.StaticSharedBytes(); err != nil {
+		return o, err
+	}
+	if o.MaxThreadsPerBlock, err = k.MaxThreadsPerBlock(); err != nil {
+		return o, err
+	}
+	o.MaxDynamicShared, err = k.MaxDynamicSharedBytes()
+	return o, err
+}
+
+//checklaunch checks the block and dynamic shared memory of a launch against the kernel's limits.  The limits are asked for once
+//and kept until an attribute is set.
+func (k *Kernel) checklaunch(bx, by, bz, shared uint32) error {
+	k.mux.Lock()
+	limits := k.limits
+	k.mux.Unlock()
+	if limits == nil {
+		l, err := k.Limits()
+		if err != nil {
+			return err
+		}
+		k.mux.Lock()
+		k.limits = &l
+		k.mux.Unlock()
+		limits = &l
+	}
+	if err := limits.CheckLaunch(int(bx), int(by), int(bz), int(shared)); err != nil {
+		return fmt.Errorf("(k *Kernel) Launch %s: %w", k.name, err)
+	}
+	return nil
+}
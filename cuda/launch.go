@@ -14,6 +14,7 @@ const CUjit_option * nullJitOptions = NULL;
 */
 import "C"
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -23,6 +24,8 @@ import (
 	"unsafe"
 
 	"github.com/dereklstinson/cutil"
+	"github.com/negativeOne1/gocudnn/cuda/occupancy"
+	"github.com/negativeOne1/gocudnn/cuda/ptx"
 	"github.com/negativeOne1/gocudnn/gocu"
 	"github.com/dereklstinson/half"
 )
@@ -34,13 +37,18 @@ type Kernel struct {
 	m    *Module
 	//notconcurentargs *launchargs
 	//concurrentargs   []*launchargs
-	mux sync.Mutex
+	mux      sync.Mutex
+	sig      *ptx.Entry
+	validate bool
+	checklim bool
+	limits   *occupancy.Kernel
 }
 
 //Module are used to hold kernel functions on the device that is in use
 type Module struct {
 	m      C.CUmodule
 	loaded bool
+	meta   *ptx.Module
 }
 type launchargs struct {
 	args []unsafe.Pointer
@@ -77,9 +85,30 @@ func NewModuleData(r io.Reader) (*Module, error) {
 
 		m:      mod,
 		loaded: true,
+		meta:   parsemeta(string(ptxbytes)),
 	}, newErrorDriver("NewModuleData", x)
 }
 
+//NewModuleImage loads a module from an image in memory.  The image can be ptx, a cubin or a fatbin.
+//Unlike NewModuleData it is safe for binary images that have zero bytes in them.
+func NewModuleImage(image []byte) (*Module, error) {
+	if len(image) == 0 {
+		return nil, errors.New("NewModuleImage: empty image")
+	}
+	cimage := C.CBytes(append(image[:len(image):len(image)], 0))
+	defer C.free(cimage)
+	var mod C.CUmodule
+	x := C.cuModuleLoadData(&mod, cimage)
+	m := &Module{
+		m:      mod,
+		loaded: true,
+	}
+	if bytes.IndexByte(image, 0) < 0 {
+		m.meta = parsemeta(string(image))
+	}
+	return m, newErrorDriver("NewModuleImage", x)
+}
+
 //UnLoad Loads a Module
 func (m *Module) UnLoad() error {
 	x := C.cuModuleUnload(m.m)
@@ -111,6 +140,7 @@ func (m *Module) LoadData(ptx string) error {
 	defer C.free((unsafe.Pointer)(cptx))
 	x := C.cuModuleLoadData(&m.m, cptx)
 	m.loaded = true
+	m.meta = parsemeta(ptx)
 	return newErrorDriver("LoadData", x)
 }
 
@@ -125,10 +155,24 @@ func (m *Module) LoadEx(ptx string) error {
 	defer C.free((unsafe.Pointer)(cptx))
 	x := C.cuModuleLoadDataEx(&m.m, cptx, 0, C.nullJitOptions, C.voiddptrnull)
 	m.loaded = true
-
+	m.meta = parsemeta(ptx)
 	return newErrorDriver("LoadEx", x)
 }
 
+//parsemeta returns the metadata of the ptx or nil if it can't be parsed. Modules loaded from cubin won't parse.
+func parsemeta(src string) *ptx.Module {
+	meta, err := ptx.Parse(src)
+	if err != nil {
+		return nil
+	}
+	return meta
+}
+
+//PTX returns the metadata of the ptx the module was loaded with.  It returns false if the module was loaded from a file, or the data couldn't be parsed.
+func (m *Module) PTX() (*ptx.Module, bool) {
+	return m.meta, m.meta != nil
+}
+
 //MakeKernel makes a kernel.  (basically a function in cuda) If module is unloaded, then the kernel returned won't work
 func MakeKernel(kname string, m *Module) (k *Kernel, err error) {
 	var kern C.CUfunction
@@ -147,6 +191,9 @@ func MakeKernel(kname string, m *Module) (k *Kernel, err error) {
 		m:    m,
 		f:    kern,
 	}
+	if m.meta != nil {
+		k.sig, _ = m.meta.Entry(kname)
+	}
 
 	runtime.SetFinalizer(k, destroycudakernel)
 
@@ -176,6 +223,16 @@ func (k *Kernel) Launch(gx, gy, gz, bx, by, bz, shared uint32, stream gocu.Strea
 		shold = stream.Ptr()
 	}
 
+	if k.checklim {
+		if err := k.checklaunch(bx, by, bz, shared); err != nil {
+			return err
+		}
+	}
+	if k.validate {
+		if err := k.sig.Check(argsizes(args)); err != nil {
+			return err
+		}
+	}
 	cargs := makelaunchargs(len(args))
 	err := k.ifacetounsafecomplete(args, cargs)
 	if err != nil {
@@ -251,6 +308,58 @@ func (k *KernelArguments) GetArguments() []interface{} {
 	return cargs
 }
 */
+//Signature returns the params of the kernel from the ptx of its module. It is nil if the module's ptx wasn't parsed.
+func (k *Kernel) Signature() *ptx.Entry {
+	return k.sig
+}
+
+//SetSignature sets the params of the kernel. Use it for kernels made from modules that were loaded from a file.
+func (k *Kernel) SetSignature(sig *ptx.Entry) {
+	k.sig = sig
+}
+
+//SetValidate turns on or off checking the args of Launch against the kernel's signature.
+//When it is on, Launch returns an error that wraps ptx.ErrSignature if the number of args or the width of an arg doesn't match,
+//instead of passing bad values to the kernel.  It can't be turned on if the kernel doesn't have a signature.
+func (k *Kernel) SetValidate(validate bool) error {
+	if validate && k.sig == nil {
+		return fmt.Errorf("(k *Kernel) SetValidate: %s doesn't have a signature", k.name)
+	}
+	k.validate = validate
+	return nil
+}
+
+//SetCheckLimits turns on or off checking the block size and dynamic shared memory of Launch against the kernel's limits.
+//When it is on, Launch returns an error that wraps occupancy.ErrBlockSize or occupancy.ErrShared instead of the driver's error.
+//The limits are asked for from the driver on the first launch after it is turned on, and again after an attribute is set.
+func (k *Kernel) SetCheckLimits(check bool) {
+	k.checklim = check
+}
+
+//argsizes returns the number of bytes each arg is passed to the kernel as. bool is counted as one byte
+//since that is how a bool param is in ptx. Types that can't be passed are -1.
+func argsizes(args []interface{}) []int {
+	sizes := make([]int, len(args))
+	for i := range args {
+		switch x := args[i].(type) {
+		case nil, cutil.Mem, []cutil.Mem:
+			sizes[i] = pointerSize
+		case bool:
+			sizes[i] = 1
+		case int, uint, []int32:
+			sizes[i] = 4
+		default:
+			scalar := cutil.CScalarConversion(x)
+			if scalar == nil {
+				sizes[i] = -1
+				continue
+			}
+			sizes[i] = int(scalar.SIB())
+		}
+	}
+	return sizes
+}
+
 func isconvertable(gotype interface{}) bool {
 	switch gotype.(type) {
 	case float64:
@@ -0,0 +1,259 @@
+package cuda
+
+/*
+#include <cuda.h>
+
+static CUresult memcpy2d(CUmemorytype dtype, void *dst, size_t dpitch,
+                         CUmemorytype stype, const void *src, size_t spitch,
+                         size_t width, size_t height, CUstream s, int async) {
+	CUDA_MEMCPY2D p = {0};
+	p.dstMemoryType = dtype;
+	if (dtype == CU_MEMORYTYPE_HOST) {
+		p.dstHost = dst;
+	} else {
+		p.dstDevice = (CUdeviceptr)dst;
+	}
+	p.dstPitch = dpitch;
+	p.srcMemoryType = stype;
+	if (stype == CU_MEMORYTYPE_HOST) {
+		p.srcHost = src;
+	} else {
+		p.srcDevice = (CUdeviceptr)src;
+	}
+	p.srcPitch = spitch;
+	p.WidthInBytes = width;
+	p.Height = height;
+	if (async) {
+		return cuMemcpy2DAsync(&p, s);
+	}
+	return cuMemcpy2D(&p);
+}
+*/
+import "C"
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/dereklstinson/cutil"
+	"github.com/negativeOne1/gocudnn/gocu"
+)
+
+//Mem is memory allocated with the driver api.  It implements cutil.Mem so it can be passed to kernels and to the other packages.
+//The memory is freed when Mem is garbage collected, or with Free.
+type Mem struct {
+	ptr  unsafe.Pointer
+	size uint
+	host bool
+}
+
+//Ptr returns the address of the memory.  For device and managed memory it is the CUdeviceptr.
+func (m *Mem) Ptr() unsafe.Pointer {
+	return m.ptr
+}
+
+//DPtr returns a pointer to the address of the memory.
+func (m *Mem) DPtr() *unsafe.Pointer {
+	return &m.ptr
+}
+
+//TotalBytes returns the size of the allocation in bytes
+func (m *Mem) TotalBytes() uint {
+	return m.size
+}
+
+//Free frees the memory.  Calling Free more than once is fine.
+func (m *Mem) Free() error {
+	runtime.SetFinalizer(m, nil)
+	return freemem(m)
+}
+
+func freemem(m *Mem) error {
+	if m.ptr == nil {
+		return nil
+	}
+	var err error
+	if m.host {
+		err = newErrorDriver("cuMemFreeHost", C.cuMemFreeHost(m.ptr))
+	} else {
+		err = newErrorDriver("cuMemFree", C.cuMemFree(deviceptr(m.ptr)))
+	}
+	if err != nil {
+		return err
+	}
+	m.ptr = nil
+	m.size = 0
+	return nil
+}
+
+//MemAlloc allocates size bytes of device memory on the current context.  Like cudart.Malloc the memory is set to zero.
+func MemAlloc(size uint) (*Mem, error) {
+	m := &Mem{size: size}
+	err := newErrorDriver("cuMemAlloc", C.cuMemAlloc(m.dptr(), C.size_t(size)))
+	if err != nil {
+		return nil, err
+	}
+	runtime.SetFinalizer(m, freemem)
+	err = MemsetD8(m, 0, size)
+	if err != nil {
+		m.Free()
+		return nil, err
+	}
+	return m, nil
+}
+
+//MemAllocHost allocates size bytes of page-locked host memory that the device can access directly.
+//Copies to and from it are faster than copies with pageable memory, and they can be asynchronous.
+func MemAllocHost(size uint) (*Mem, error) {
+	m := &Mem{size: size, host: true}
+	err := newErrorDriver("cuMemAllocHost", C.cuMemAllocHost(&m.ptr, C.size_t(size)))
+	if err != nil {
+		return nil, err
+	}
+	runtime.SetFinalizer(m, freemem)
+	return m, nil
+}
+
+//MemAllocManaged allocates size bytes of memory that is managed by the unified memory system.
+//The memory can be used on the host and on any device.
+func MemAllocManaged(size uint, flag AttachFlag) (*Mem, error) {
+	m := &Mem{size: size}
+	err := newErrorDriver("cuMemAllocManaged", C.cuMemAllocManaged(m.dptr(), C.size_t(size), C.uint(flag)))
+	if err != nil {
+		return nil, err
+	}
+	runtime.SetFinalizer(m, freemem)
+	return m, nil
+}
+
+func (m *Mem) dptr() *C.CUdeviceptr {
+	return (*C.CUdeviceptr)(unsafe.Pointer(&m.ptr))
+}
+
+//AttachFlag is used for flags in MemAllocManaged. Flags are passed through methods.
+type AttachFlag C.uint
+
+//Global - Memory can be accessed by any stream on any device
+func (a *AttachFlag) Global() AttachFlag {
+	*a = AttachFlag(C.CU_MEM_ATTACH_GLOBAL)
+	return *a
+}
+
+//Host - Memory cannot be accessed by any stream on any device until it is attached to a stream
+func (a *AttachFlag) Host() AttachFlag {
+	*a = AttachFlag(C.CU_MEM_ATTACH_HOST)
+	return *a
+}
+
+//MemoryType is the type of memory on each side of Memcpy2D. Flags are passed through methods.
+type MemoryType C.CUmemorytype
+
+//Host - host memory
+func (m *MemoryType) Host() MemoryType {
+	*m = MemoryType(C.CU_MEMORYTYPE_HOST)
+	return *m
+}
+
+//Device - device memory
+func (m *MemoryType) Device() MemoryType {
+	*m = MemoryType(C.CU_MEMORYTYPE_DEVICE)
+	return *m
+}
+
+//Unified - unified or managed memory.  The driver works out where it is.
+func (m *MemoryType) Unified() MemoryType {
+	*m = MemoryType(C.CU_MEMORYTYPE_UNIFIED)
+	return *m
+}
+
+func (m MemoryType) c() C.CUmemorytype { return C.CUmemorytype(m) }
+
+func deviceptr(p unsafe.Pointer) C.CUdeviceptr {
+	return C.CUdeviceptr(uintptr(p))
+}
+
+func cstream(s gocu.Streamer) C.CUstream {
+	if s == nil {
+		return nil
+	}
+	return (C.CUstream)(s.Ptr())
+}
+
+//MemcpyHtoD copies size bytes from host memory src to device memory dst
+func MemcpyHtoD(dst, src cutil.Pointer, size uint) error {
+	return newErrorDriver("cuMemcpyHtoD", C.cuMemcpyHtoD(deviceptr(dst.Ptr()), src.Ptr(), C.size_t(size)))
+}
+
+//MemcpyDtoH copies size bytes from device memory src to host memory dst
+func MemcpyDtoH(dst, src cutil.Pointer, size uint) error {
+	return newErrorDriver("cuMemcpyDtoH", C.cuMemcpyDtoH(dst.Ptr(), deviceptr(src.Ptr()), C.size_t(size)))
+}
+
+//MemcpyDtoD copies size bytes from device memory src to device memory dst
+func MemcpyDtoD(dst, src cutil.Pointer, size uint) error {
+	return newErrorDriver("cuMemcpyDtoD", C.cuMemcpyDtoD(deviceptr(dst.Ptr()), deviceptr(src.Ptr()), C.size_t(size)))
+}
+
+//Memcpy copies size bytes between any two kinds of memory.  The driver infers the kind of copy from the addresses, so it needs unified addressing.
+func Memcpy(dst, src cutil.Pointer, size uint) error {
+	return newErrorDriver("cuMemcpy", C.cuMemcpy(deviceptr(dst.Ptr()), deviceptr(src.Ptr()), C.size_t(size)))
+}
+
+//MemcpyHtoDAsync is like MemcpyHtoD but it is queued on s. src should be page-locked memory for the copy to be asynchronous.
+func MemcpyHtoDAsync(dst, src cutil.Pointer, size uint, s gocu.Streamer) error {
+	return newErrorDriver("cuMemcpyHtoDAsync", C.cuMemcpyHtoDAsync(deviceptr(dst.Ptr()), src.Ptr(), C.size_t(size), cstream(s)))
+}
+
+//MemcpyDtoHAsync is like MemcpyDtoH but it is queued on s. dst should be page-locked memory for the copy to be asynchronous.
+func MemcpyDtoHAsync(dst, src cutil.Pointer, size uint, s gocu.Streamer) error {
+	return newErrorDriver("cuMemcpyDtoHAsync", C.cuMemcpyDtoHAsync(dst.Ptr(), deviceptr(src.Ptr()), C.size_t(size), cstream(s)))
+}
+
+//MemcpyDtoDAsync is like MemcpyDtoD but it is queued on s.
+func MemcpyDtoDAsync(dst, src cutil.Pointer, size uint, s gocu.Streamer) error {
+	return newErrorDriver("cuMemcpyDtoDAsync", C.cuMemcpyDtoDAsync(deviceptr(dst.Ptr()), deviceptr(src.Ptr()), C.size_t(size), cstream(s)))
+}
+
+//MemcpyAsync is like Memcpy but it is queued on s.
+func MemcpyAsync(dst, src cutil.Pointer, size uint, s gocu.Streamer) error {
+	return newErrorDriver("cuMemcpyAsync", C.cuMemcpyAsync(deviceptr(dst.Ptr()), deviceptr(src.Ptr()), C.size_t(size), cstream(s)))
+}
+
+//Memcpy2D copies height rows of width bytes.  dpitch and spitch are the bytes between the start of each row in dst and src.
+//dtype and stype say what kind of memory dst and src are.
+func Memcpy2D(dst cutil.Pointer, dpitch uint, dtype MemoryType, src cutil.Pointer, spitch uint, stype MemoryType, width, height uint) error {
+	return newErrorDriver("cuMemcpy2D", C.memcpy2d(dtype.c(), dst.Ptr(), C.size_t(dpitch), stype.c(), src.Ptr(), C.size_t(spitch),
+		C.size_t(width), C.size_t(height), nil, 0))
+}
+
+//Memcpy2DAsync is like Memcpy2D but it is queued on s.
+func Memcpy2DAsync(dst cutil.Pointer, dpitch uint, dtype MemoryType, src cutil.Pointer, spitch uint, stype MemoryType, width, height uint, s gocu.Streamer) error {
+	return newErrorDriver("cuMemcpy2DAsync", C.memcpy2d(dtype.c(), dst.Ptr(), C.size_t(dpitch), stype.c(), src.Ptr(), C.size_t(spitch),
+		C.size_t(width), C.size_t(height), cstream(s), 1))
+}
+
+//MemsetD8 sets n bytes of device memory to value
+func MemsetD8(dst cutil.Pointer, value uint8, n uint) error {
+	return newErrorDriver("cuMemsetD8", C.cuMemsetD8(deviceptr(dst.Ptr()), C.uchar(value), C.size_t(n)))
+}
+
+//MemsetD32 sets n 32 bit values of device memory to value.  dst needs to be 4 byte aligned.
+func MemsetD32(dst cutil.Pointer, value uint32, n uint) error {
+	return newErrorDriver("cuMemsetD32", C.cuMemsetD32(deviceptr(dst.Ptr()), C.uint(value), C.size_t(n)))
+}
+
+//MemsetD8Async is like MemsetD8 but it is queued on s.
+func MemsetD8Async(dst cutil.Pointer, value uint8, n uint, s gocu.Streamer) error {
+	return newErrorDriver("cuMemsetD8Async", C.cuMemsetD8Async(deviceptr(dst.Ptr()), C.uchar(value), C.size_t(n), cstream(s)))
+}
+
+//MemsetD32Async is like MemsetD32 but it is queued on s.
+func MemsetD32Async(dst cutil.Pointer, value uint32, n uint, s gocu.Streamer) error {
+	return newErrorDriver("cuMemsetD32Async", C.cuMemsetD32Async(deviceptr(dst.Ptr()), C.uint(value), C.size_t(n), cstream(s)))
+}
+
+//MemGetInfo returns the free and total memory in bytes of the device of the current context.
+func MemGetInfo() (free, total uint, err error) {
+	var f, t C.size_t
+	err = newErrorDriver("cuMemGetInfo", C.cuMemGetInfo(&f, &t))
+	return uint(f), uint(t), err
+}
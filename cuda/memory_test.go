@@ -0,0 +1,155 @@
+package cuda
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/negativeOne1/gocudnn/gocu"
+)
+
+//testcontext locks the test to its thread and makes a context on the first device for it.
+func testcontext(t *testing.T) {
+	t.Helper()
+	runtime.LockOSThread()
+	devs, err := GetDeviceList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(devs) == 0 {
+		t.Skip("no cuda devices")
+	}
+	ctx, err := CtxCreate(-1, devs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		ctx.Destroy()
+		runtime.UnlockOSThread()
+	})
+}
+
+func gomem(t *testing.T, x interface{}) *gocu.Wrapper {
+	t.Helper()
+	m, err := gocu.MakeGoMem(x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMemcpy(t *testing.T) {
+	testcontext(t)
+	const n = 256
+	src := make([]float32, n)
+	for i := range src {
+		src[i] = float32(i) - 100.5
+	}
+	a, err := MemAlloc(n * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Free()
+	b, err := MemAlloc(n * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer b.Free()
+
+	zero := make([]float32, n)
+	if err = MemcpyDtoH(gomem(t, zero), b, n*4); err != nil {
+		t.Fatal(err)
+	}
+	for i := range zero {
+		if zero[i] != 0 {
+			t.Error("MemAlloc didn't zero the memory", i, zero[i])
+			break
+		}
+	}
+
+	if err = MemcpyHtoD(a, gomem(t, src), n*4); err != nil {
+		t.Fatal(err)
+	}
+	if err = MemcpyDtoD(b, a, n*4); err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]float32, n)
+	if err = MemcpyDtoH(gomem(t, dst), b, n*4); err != nil {
+		t.Fatal(err)
+	}
+	for i := range dst {
+		if dst[i] != src[i] {
+			t.Error("round trip", i, dst[i], src[i])
+			break
+		}
+	}
+}
+
+func TestMemsetD32(t *testing.T) {
+	testcontext(t)
+	const n = 100
+	a, err := MemAlloc(n * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Free()
+	if err = MemsetD32(a, math.Float32bits(1.5), n); err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]float32, n)
+	if err = MemcpyDtoH(gomem(t, dst), a, n*4); err != nil {
+		t.Fatal(err)
+	}
+	for i := range dst {
+		if dst[i] != 1.5 {
+			t.Error(i, dst[i])
+			break
+		}
+	}
+}
+
+//TestMemcpy2D copies a 3x4 block out of a 5x6 host matrix into packed device memory and back into a 3x4 host matrix.
+func TestMemcpy2D(t *testing.T) {
+	testcontext(t)
+	const rows, cols, pitch = 5, 6, 6 * 4
+	const h, w = 3, 4
+	src := make([]float32, rows*cols)
+	for i := range src {
+		src[i] = float32(i)
+	}
+	d, err := MemAlloc(h * w * 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Free()
+	var host, device MemoryType
+	host.Host()
+	device.Device()
+	//start the block at row 1 column 2
+	block := gomem(t, src[cols+2:])
+	if err = Memcpy2D(d, w*4, device, block, pitch, host, w*4, h); err != nil {
+		t.Fatal(err)
+	}
+	dst := make([]float32, h*w)
+	if err = Memcpy2D(gomem(t, dst), w*4, host, d, w*4, device, w*4, h); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < h; i++ {
+		for j := 0; j < w; j++ {
+			if want := src[(i+1)*cols+j+2]; dst[i*w+j] != want {
+				t.Error(i, j, dst[i*w+j], want)
+			}
+		}
+	}
+}
+
+func TestMemGetInfo(t *testing.T) {
+	testcontext(t)
+	free, total, err := MemGetInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total == 0 || free > total {
+		t.Error("free", free, "total", total)
+	}
+}
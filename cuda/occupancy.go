@@ -0,0 +1,51 @@
+package cuda
+
+//#include <cuda.h>
+import "C"
+import (
+	"github.com/negativeOne1/gocudnn/cuda/occupancy"
+)
+
+//MaxActiveBlocksPerMultiprocessor returns how many blocks of blocksize threads, using dynamicshared bytes of dynamic shared memory,
+//can be active on a multiprocessor at once.
+func (k *Kernel) MaxActiveBlocksPerMultiprocessor(blocksize int32, dynamicshared uint) (int32, error) {
+	var n C.int
+	err := newErrorDriver("cuOccupancyMaxActiveBlocksPerMultiprocessor",
+		C.cuOccupancyMaxActiveBlocksPerMultiprocessor(&n, k.f, C.int(blocksize), C.size_t(dynamicshared)))
+	return int32(n), err
+}
+
+//MaxPotentialBlockSize returns the block size that gets the most occupancy for the kernel, and the smallest grid that fills the device with it.
+//If blocksizelimit is 0 the kernel's max threads per block is the limit.
+func (k *Kernel) MaxPotentialBlockSize(dynamicshared uint, blocksizelimit int32) (mingridsize, blocksize int32, err error) {
+	var grid, block C.int
+	err = newErrorDriver("cuOccupancyMaxPotentialBlockSize",
+		C.cuOccupancyMaxPotentialBlockSize(&grid, &block, k.f, nil, C.size_t(dynamicshared), C.int(blocksizelimit)))
+	return int32(grid), int32(block), err
+}
+
+//OccupancyKernel returns the attributes of the kernel that the occupancy model needs.
+//
+//Deprecated: use Limits.
+func (k *Kernel) OccupancyKernel() (occupancy.Kernel, error) {
+	return k.Limits()
+}
+
+//Occupancy returns the occupancy model of the device.
+func (d Device) Occupancy() (occupancy.Device, error) {
+	major, err := d.Major()
+	if err != nil {
+		return occupancy.Device{}, err
+	}
+	minor, err := d.Minor()
+	if err != nil {
+		return occupancy.Device{}, err
+	}
+	o, err := occupancy.Lookup(major, minor)
+	if err != nil {
+		return o, err
+	}
+	sms, err := d.getattribute(C.CU_DEVICE_ATTRIBUTE_MULTIPROCESSOR_COUNT)
+	o.MultiProcessors = int(sms)
+	return o, err
+}
@@ -0,0 +1,301 @@
+//Package occupancy works out how many blocks of a kernel can be active on a multiprocessor at once, and picks block sizes that keep the most warps active.
+//
+//It is a go version of the occupancy calculator that comes with cuda.  The limits of each architecture are in a table,
+//so it can be used without a device.  The cuda package has the driver's cuOccupancy functions for when there is one.
+//
+//	d, _ := occupancy.Lookup(7, 5)
+//	d.MultiProcessors = 40
+//	r, _ := d.Occupancy(occupancy.Kernel{Registers: 64}, 256, 0)
+//	//r.ActiveBlocks == 4, r.Occupancy == 1
+package occupancy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+//ErrArch is returned by Lookup for a compute capability that isn't in the table.
+var ErrArch = errors.New("occupancy: unknown compute capability")
+
+//ErrLaunch is returned when the kernel can't be launched with the block size at all.
+var ErrLaunch = errors.New("occupancy: kernel can't be launched")
+
+//ErrBlockSize is returned by CheckLaunch when a block has more threads than the kernel can have.
+var ErrBlockSize = errors.New("block size is over the kernel's max threads per block")
+
+//ErrShared is returned by CheckLaunch when a launch asks for more dynamic shared memory than the kernel can have.
+var ErrShared = errors.New("dynamic shared memory is over the kernel's limit")
+
+//Device is the limits of a multiprocessor.  The shared memory numbers are in bytes.
+type Device struct {
+	Major, Minor           int
+	MultiProcessors        int //Not part of the table.  Only MaxPotentialBlockSize's grid size needs it.
+	WarpSize               int
+	MaxThreadsPerBlock     int
+	MaxThreadsPerSM        int
+	MaxBlocksPerSM         int
+	RegistersPerSM         int
+	MaxRegistersPerBlock   int
+	MaxRegistersPerThread  int
+	RegisterAllocUnit      int //Registers are given to warps in units of this many
+	SubPartitions          int //Registers are split between this many sub partitions, and a warp's registers are in one of them
+	SharedPerSM            int //With the biggest shared memory carveout
+	SharedPerBlock         int //Without opting in
+	SharedPerBlockOptin    int //The most a kernel can get by setting its max dynamic shared memory
+	SharedAllocUnit        int
+	ReservedSharedPerBlock int //Shared memory the driver keeps for every block
+}
+
+//MaxWarpsPerSM returns the number of warps that can be active on a multiprocessor.
+func (d Device) MaxWarpsPerSM() int { return d.MaxThreadsPerSM / d.WarpSize }
+
+func arch(major, minor, blocks, threads, regsperblock, regsperthread, sharedsm, sharedoptin int) Device {
+	d := Device{
+		Major:                 major,
+		Minor:                 minor,
+		WarpSize:              32,
+		MaxThreadsPerBlock:    1024,
+		MaxThreadsPerSM:       threads,
+		MaxBlocksPerSM:        blocks,
+		RegistersPerSM:        64 * 1024,
+		MaxRegistersPerBlock:  regsperblock,
+		MaxRegistersPerThread: regsperthread,
+		RegisterAllocUnit:     256,
+		SubPartitions:         4,
+		SharedPerSM:           sharedsm,
+		SharedPerBlock:        48 * 1024,
+		SharedPerBlockOptin:   sharedoptin,
+		SharedAllocUnit:       256,
+	}
+	if major >= 8 {
+		d.SharedAllocUnit = 128
+		d.ReservedSharedPerBlock = 1024
+	}
+	if major == 6 && minor == 0 {
+		d.SubPartitions = 2
+	}
+	return d
+}
+
+const kb = 1024
+
+//table is from the compute capability tables in the cuda programming guide.
+var table = []Device{
+	arch(3, 0, 16, 2048, 64*kb, 63, 48*kb, 48*kb),
+	arch(3, 5, 16, 2048, 64*kb, 255, 48*kb, 48*kb),
+	func() Device {
+		d := arch(3, 7, 16, 2048, 64*kb, 255, 112*kb, 48*kb)
+		d.RegistersPerSM = 128 * kb
+		return d
+	}(),
+	arch(5, 0, 32, 2048, 64*kb, 255, 64*kb, 48*kb),
+	arch(5, 2, 32, 2048, 64*kb, 255, 96*kb, 48*kb),
+	arch(5, 3, 32, 2048, 32*kb, 255, 64*kb, 48*kb),
+	arch(6, 0, 32, 2048, 64*kb, 255, 64*kb, 48*kb),
+	arch(6, 1, 32, 2048, 64*kb, 255, 96*kb, 48*kb),
+	arch(6, 2, 32, 2048, 32*kb, 255, 64*kb, 48*kb),
+	arch(7, 0, 32, 2048, 64*kb, 255, 96*kb, 96*kb),
+	arch(7, 2, 32, 2048, 64*kb, 255, 96*kb, 96*kb),
+	arch(7, 5, 16, 1024, 64*kb, 255, 64*kb, 64*kb),
+	arch(8, 0, 32, 2048, 64*kb, 255, 164*kb, 163*kb),
+	arch(8, 6, 16, 1536, 64*kb, 255, 100*kb, 99*kb),
+	arch(8, 7, 16, 1536, 64*kb, 255, 164*kb, 163*kb),
+	arch(8, 9, 24, 1536, 64*kb, 255, 100*kb, 99*kb),
+	arch(9, 0, 32, 2048, 64*kb, 255, 228*kb, 227*kb),
+}
+
+//Lookup returns the limits of compute capability major.minor.  MultiProcessors needs to be set by the caller.
+func Lookup(major, minor int) (Device, error) {
+	for _, d := range table {
+		if d.Major == major && d.Minor == minor {
+			return d, nil
+		}
+	}
+	return Device{}, fmt.Errorf("%w: %d.%d", ErrArch, major, minor)
+}
+
+//Archs returns the compute capabilities in the table as major*10+minor, sorted.
+func Archs() []int {
+	a := make([]int, len(table))
+	for i, d := range table {
+		a[i] = d.Major*10 + d.Minor
+	}
+	sort.Ints(a)
+	return a
+}
+
+//Kernel is what occupancy needs to know about a kernel.  They are the CU_FUNC_ATTRIBUTEs of the same names.
+type Kernel struct {
+	Registers          int //Registers per thread
+	StaticShared       int //Bytes of shared memory declared in the kernel
+	MaxThreadsPerBlock int //0 means the device's limit
+	MaxDynamicShared   int //Bytes of dynamic shared memory the kernel opted into.  0 means the device's default limit.
+}
+
+//Limiter is the thing that limits the number of active blocks.
+type Limiter int
+
+//Limiters
+const (
+	LimitBlocks    Limiter = iota //Blocks per multiprocessor
+	LimitWarps                    //Warps per multiprocessor
+	LimitRegisters                //Registers per multiprocessor
+	LimitShared                   //Shared memory per multiprocessor
+)
+
+func (l Limiter) String() string {
+	switch l {
+	case LimitBlocks:
+		return "blocks"
+	case LimitWarps:
+		return "warps"
+	case LimitRegisters:
+		return "registers"
+	case LimitShared:
+		return "shared memory"
+	}
+	return fmt.Sprintf("Limiter(%d)", int(l))
+}
+
+//Result is the occupancy of a kernel with a block size.
+type Result struct {
+	BlockSize     int
+	DynamicShared int
+	ActiveBlocks  int     //Active blocks per multiprocessor
+	ActiveWarps   int     //Active warps per multiprocessor
+	Occupancy     float64 //ActiveWarps over the most warps a multiprocessor can have
+	Limit         Limiter
+	//The number of blocks each resource allows on its own. A resource that isn't used has -1.
+	BlocksByWarps, BlocksByRegisters, BlocksByShared int
+}
+
+func divup(a, b int) int { return (a + b - 1) / b }
+
+func roundup(a, b int) int { return divup(a, b) * b }
+
+//Occupancy returns the occupancy of k with blocks of blocksize threads that use dynamicshared bytes of dynamic shared memory.
+//It returns ErrLaunch if a block can't fit on a multiprocessor.
+func (d Device) Occupancy(k Kernel, blocksize, dynamicshared int) (Result, error) {
+	r := Result{BlockSize: blocksize, DynamicShared: dynamicshared, BlocksByRegisters: -1, BlocksByShared: -1}
+	maxthreads := d.MaxThreadsPerBlock
+	if k.MaxThreadsPerBlock > 0 && k.MaxThreadsPerBlock < maxthreads {
+		maxthreads = k.MaxThreadsPerBlock
+	}
+	if blocksize < 1 || blocksize > maxthreads {
+		return r, fmt.Errorf("%w: block size %d isn't in 1 to %d", ErrLaunch, blocksize, maxthreads)
+	}
+	warps := divup(blocksize, d.WarpSize)
+	r.BlocksByWarps = d.MaxWarpsPerSM() / warps
+	blocks := d.MaxBlocksPerSM
+	r.Limit = LimitBlocks
+	if r.BlocksByWarps < blocks {
+		blocks, r.Limit = r.BlocksByWarps, LimitWarps
+	}
+
+	if k.Registers > 0 {
+		if k.Registers > d.MaxRegistersPerThread {
+			return r, fmt.Errorf("%w: %d registers per thread is more than %d", ErrLaunch, k.Registers, d.MaxRegistersPerThread)
+		}
+		regsperwarp := roundup(k.Registers*d.WarpSize, d.RegisterAllocUnit)
+		if regsperwarp*warps > d.MaxRegistersPerBlock {
+			return r, fmt.Errorf("%w: %d registers per block is more than %d", ErrLaunch, regsperwarp*warps, d.MaxRegistersPerBlock)
+		}
+		warpspersub := (d.RegistersPerSM / d.SubPartitions) / regsperwarp
+		r.BlocksByRegisters = warpspersub * d.SubPartitions / warps
+		if r.BlocksByRegisters < blocks {
+			blocks, r.Limit = r.BlocksByRegisters, LimitRegisters
+		}
+	}
+
+	shared := k.StaticShared + dynamicshared
+	if shared > 0 {
+		maxshared := d.SharedPerBlock
+		if k.MaxDynamicShared > 0 {
+			maxshared = min(k.StaticShared+k.MaxDynamicShared, d.SharedPerBlockOptin)
+		}
+		if shared > maxshared {
+			return r, fmt.Errorf("%w: %d bytes of shared memory is more than %d", ErrLaunch, shared, maxshared)
+		}
+		pershared := roundup(shared+d.ReservedSharedPerBlock, d.SharedAllocUnit)
+		r.BlocksByShared = d.SharedPerSM / pershared
+		if r.BlocksByShared < blocks {
+			blocks, r.Limit = r.BlocksByShared, LimitShared
+		}
+	}
+	if blocks == 0 {
+		return r, fmt.Errorf("%w: limited by %v", ErrLaunch, r.Limit)
+	}
+	r.ActiveBlocks = blocks
+	r.ActiveWarps = blocks * warps
+	r.Occupancy = float64(r.ActiveWarps) / float64(d.MaxWarpsPerSM())
+	return r, nil
+}
+
+//MaxPotentialBlockSize returns the block size with the most active threads per multiprocessor, and the smallest grid that fills the device with it.
+//dynamicshared gives the dynamic shared memory a block size needs, and can be nil if the kernel doesn't use any.
+//Block sizes are tried from the largest down in steps of the warp size, so the largest block size wins a tie.
+//blocksizelimit caps the block size if > 0.  Like cuOccupancyMaxPotentialBlockSize.
+func (d Device) MaxPotentialBlockSize(k Kernel, dynamicshared func(blocksize int) int, blocksizelimit int) (best Result, mingrid int, err error) {
+	limit := d.MaxThreadsPerBlock
+	if k.MaxThreadsPerBlock > 0 && k.MaxThreadsPerBlock < limit {
+		limit = k.MaxThreadsPerBlock
+	}
+	if blocksizelimit > 0 && blocksizelimit < limit {
+		limit = blocksizelimit
+	}
+	start := roundup(limit, d.WarpSize)
+	mostthreads := 0
+	for bs := start; bs > 0; bs -= d.WarpSize {
+		try := min(bs, limit)
+		smem := 0
+		if dynamicshared != nil {
+			smem = dynamicshared(try)
+		}
+		r, err := d.Occupancy(k, try, smem)
+		if err != nil {
+			continue
+		}
+		if threads := r.ActiveBlocks * try; threads > mostthreads {
+			mostthreads = threads
+			best = r
+		}
+		if mostthreads == d.MaxThreadsPerSM {
+			break
+		}
+	}
+	if mostthreads == 0 {
+		return best, 0, fmt.Errorf("%w: no block size fits", ErrLaunch)
+	}
+	return best, best.ActiveBlocks * d.MultiProcessors, nil
+}
+
+//Grid returns the number of blocks for elements elements with r.BlockSize threads per block.
+//It is no more than the device can have active at once, since kernels loop over the elements.
+func (d Device) Grid(r Result, elements int) int {
+	if r.BlockSize < 1 || elements < 1 {
+		return 0
+	}
+	blocks := divup(elements, r.BlockSize)
+	if full := r.ActiveBlocks * d.MultiProcessors; full > 0 && full < blocks {
+		return full
+	}
+	return blocks
+}
+
+//CheckLaunch checks a launch of a bx*by*bz block with shared bytes of dynamic shared memory against k.
+//k needs to have the kernel's attributes from the driver, since MaxDynamicShared is used as the limit even when it is 0.
+//The errors wrap ErrBlockSize and ErrShared.
+func (k Kernel) CheckLaunch(bx, by, bz, shared int) error {
+	threads := bx * by * bz
+	if threads < 1 {
+		return fmt.Errorf("%w: block %dx%dx%d has no threads", ErrBlockSize, bx, by, bz)
+	}
+	if k.MaxThreadsPerBlock > 0 && threads > k.MaxThreadsPerBlock {
+		return fmt.Errorf("%w: block %dx%dx%d is %d threads, the max is %d", ErrBlockSize, bx, by, bz, threads, k.MaxThreadsPerBlock)
+	}
+	if shared > k.MaxDynamicShared {
+		return fmt.Errorf("%w: %d bytes asked for, the max is %d.  It can be raised by setting the kernel's max dynamic shared size", ErrShared, shared, k.MaxDynamicShared)
+	}
+	return nil
+}
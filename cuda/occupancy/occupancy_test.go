@@ -0,0 +1,191 @@
+package occupancy
+
+import (
+	"errors"
+	"testing"
+)
+
+//The expected values are the ones the cuda occupancy calculator spreadsheet gives.
+func TestOccupancy(t *testing.T) {
+	tests := []struct {
+		major, minor int
+		k            Kernel
+		blocksize    int
+		dynamic      int
+		blocks       int
+		warps        int
+		limit        Limiter
+	}{
+		{6, 1, Kernel{Registers: 32}, 256, 0, 8, 64, LimitWarps},
+		{6, 1, Kernel{Registers: 40}, 64, 0, 24, 48, LimitRegisters},
+		{6, 0, Kernel{Registers: 40}, 64, 0, 25, 50, LimitRegisters},
+		{6, 1, Kernel{}, 32, 0, 32, 32, LimitBlocks},
+		{7, 5, Kernel{Registers: 64}, 256, 0, 4, 32, LimitWarps},
+		{7, 5, Kernel{}, 32, 0, 16, 16, LimitBlocks},
+		{7, 0, Kernel{Registers: 96}, 128, 0, 5, 20, LimitRegisters},
+		{7, 0, Kernel{Registers: 64}, 1024, 0, 1, 32, LimitRegisters},
+		{8, 0, Kernel{Registers: 32}, 256, 48 * 1024, 3, 24, LimitShared},
+		{8, 0, Kernel{Registers: 32, StaticShared: 16 * 1024}, 256, 0, 8, 64, LimitWarps},
+		{8, 6, Kernel{Registers: 32, StaticShared: 4096}, 128, 8192, 7, 28, LimitShared},
+		{7, 5, Kernel{MaxDynamicShared: 64 * 1024}, 128, 64 * 1024, 1, 4, LimitShared},
+		{5, 2, Kernel{Registers: 255}, 128, 0, 2, 8, LimitRegisters},
+	}
+	for _, tt := range tests {
+		d, err := Lookup(tt.major, tt.minor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := d.Occupancy(tt.k, tt.blocksize, tt.dynamic)
+		if err != nil {
+			t.Error(tt.major, tt.minor, tt.k, tt.blocksize, err)
+			continue
+		}
+		if r.ActiveBlocks != tt.blocks || r.ActiveWarps != tt.warps || r.Limit != tt.limit {
+			t.Errorf("%d.%d %+v %d %d: got %d blocks %d warps %v, want %d %d %v", tt.major, tt.minor, tt.k, tt.blocksize, tt.dynamic,
+				r.ActiveBlocks, r.ActiveWarps, r.Limit, tt.blocks, tt.warps, tt.limit)
+		}
+		if want := float64(tt.warps) / float64(d.MaxWarpsPerSM()); r.Occupancy != want {
+			t.Error(r.Occupancy, want)
+		}
+	}
+}
+
+func TestOccupancyErrors(t *testing.T) {
+	tests := []struct {
+		major, minor int
+		k            Kernel
+		blocksize    int
+		dynamic      int
+	}{
+		{7, 5, Kernel{Registers: 128}, 1024, 0},
+		{7, 5, Kernel{Registers: 256}, 32, 0},
+		{3, 0, Kernel{Registers: 64}, 32, 0},
+		{6, 1, Kernel{}, 128, 64 * 1024},
+		{6, 1, Kernel{MaxDynamicShared: 64 * 1024}, 128, 64 * 1024},
+		{7, 0, Kernel{StaticShared: 40 * 1024}, 128, 10 * 1024},
+		{7, 5, Kernel{}, 0, 0},
+		{7, 5, Kernel{}, 1025, 0},
+		{7, 5, Kernel{MaxThreadsPerBlock: 256}, 512, 0},
+	}
+	for _, tt := range tests {
+		d, _ := Lookup(tt.major, tt.minor)
+		if r, err := d.Occupancy(tt.k, tt.blocksize, tt.dynamic); !errors.Is(err, ErrLaunch) {
+			t.Error(tt, "want ErrLaunch got", r, err)
+		}
+	}
+	if _, err := Lookup(2, 0); !errors.Is(err, ErrArch) {
+		t.Error(err)
+	}
+}
+
+func TestMaxPotentialBlockSize(t *testing.T) {
+	tests := []struct {
+		major, minor int
+		k            Kernel
+		limit        int
+		blocksize    int
+		occupancy    float64
+	}{
+		{7, 5, Kernel{Registers: 32}, 0, 1024, 1},
+		{7, 0, Kernel{Registers: 64}, 0, 1024, 0.5},
+		{6, 1, Kernel{Registers: 32}, 0, 1024, 1},
+		{6, 1, Kernel{Registers: 32}, 200, 128, 1},
+		{6, 1, Kernel{Registers: 32}, 100, 64, 1},
+		{6, 1, Kernel{Registers: 32}, 48, 48, 1},
+		{8, 6, Kernel{Registers: 32, MaxThreadsPerBlock: 512}, 0, 512, 1},
+		{7, 0, Kernel{Registers: 96}, 0, 640, 0.3125},
+	}
+	for _, tt := range tests {
+		d, _ := Lookup(tt.major, tt.minor)
+		d.MultiProcessors = 10
+		r, grid, err := d.MaxPotentialBlockSize(tt.k, nil, tt.limit)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if r.BlockSize != tt.blocksize || r.Occupancy != tt.occupancy || grid != r.ActiveBlocks*10 {
+			t.Errorf("%d.%d %+v: got %d %v %d, want %d %v", tt.major, tt.minor, tt.k, r.BlockSize, r.Occupancy, grid, tt.blocksize, tt.occupancy)
+		}
+	}
+}
+
+//MaxPotentialBlockSize has to find the block size with the most active threads, so it is checked against trying every block size.
+func TestMaxPotentialBlockSizeSearch(t *testing.T) {
+	shared := func(bs int) int { return bs * 4 * 8 }
+	for _, cc := range Archs() {
+		d, _ := Lookup(cc/10, cc%10)
+		for _, regs := range []int{16, 40, 63, 100} {
+			if regs > d.MaxRegistersPerThread {
+				continue
+			}
+			k := Kernel{Registers: regs, StaticShared: 2048}
+			r, _, err := d.MaxPotentialBlockSize(k, shared, 0)
+			if err != nil {
+				t.Error(cc, regs, err)
+				continue
+			}
+			for bs := 1; bs <= d.MaxThreadsPerBlock; bs++ {
+				o, err := d.Occupancy(k, bs, shared(bs))
+				if err != nil {
+					continue
+				}
+				if o.ActiveBlocks*bs > r.ActiveBlocks*r.BlockSize && bs%d.WarpSize == 0 {
+					t.Error(cc, regs, "block size", bs, "has more threads than", r.BlockSize)
+				}
+			}
+		}
+	}
+	d, _ := Lookup(7, 5)
+	if _, _, err := d.MaxPotentialBlockSize(Kernel{Registers: 300}, nil, 0); !errors.Is(err, ErrLaunch) {
+		t.Error(err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	d, _ := Lookup(7, 5)
+	d.MultiProcessors = 40
+	r := Result{BlockSize: 256, ActiveBlocks: 4}
+	tests := map[int]int{0: 0, 1: 1, 256: 1, 257: 2, 160 * 256: 160, 1 << 30: 160}
+	for elements, want := range tests {
+		if got := d.Grid(r, elements); got != want {
+			t.Error(elements, got, want)
+		}
+	}
+}
+
+func TestTable(t *testing.T) {
+	for _, cc := range Archs() {
+		d, err := Lookup(cc/10, cc%10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.MaxWarpsPerSM()*d.WarpSize != d.MaxThreadsPerSM || d.SharedPerBlockOptin > d.SharedPerSM || d.SharedPerBlock > d.SharedPerBlockOptin {
+			t.Errorf("%d: %+v", cc, d)
+		}
+	}
+}
+
+func TestCheckLaunch(t *testing.T) {
+	k := Kernel{Registers: 32, StaticShared: 1024, MaxThreadsPerBlock: 512, MaxDynamicShared: 48*1024 - 1024}
+	tests := []struct {
+		bx, by, bz, shared int
+		err                error
+	}{
+		{512, 1, 1, 0, nil},
+		{16, 16, 2, 47 * 1024, nil},
+		{513, 1, 1, 0, ErrBlockSize},
+		{16, 16, 4, 0, ErrBlockSize},
+		{0, 1, 1, 0, ErrBlockSize},
+		{256, 1, 1, 47*1024 + 1, ErrShared},
+	}
+	for _, tt := range tests {
+		err := k.CheckLaunch(tt.bx, tt.by, tt.bz, tt.shared)
+		if tt.err == nil && err != nil || tt.err != nil && !errors.Is(err, tt.err) {
+			t.Error(tt, err)
+		}
+	}
+	k.MaxDynamicShared = 0
+	if err := k.CheckLaunch(32, 1, 1, 1); !errors.Is(err, ErrShared) {
+		t.Error(err)
+	}
+}
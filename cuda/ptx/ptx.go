@@ -0,0 +1,266 @@
+//Package ptx reads the metadata out of ptx text. It gets the .version, .target and .address_size of a module,
+//and the name and params of each .entry.
+//
+//It doesn't use cuda, so it can be tested without a gpu.  The cuda package uses it to check the args passed to Kernel.Launch.
+package ptx
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//ErrSignature is wrapped by the errors returned when args don't match an Entry.
+var ErrSignature = errors.New("ptx: args don't match kernel signature")
+
+//Module is the metadata of a ptx module.
+type Module struct {
+	Version     string   //like "6.4"
+	Target      []string //like ["sm_75"]
+	AddressSize int      //32 or 64. It is 0 if .address_size isn't in the ptx.
+	Entries     []Entry
+}
+
+//Entry is a kernel in a Module.
+type Entry struct {
+	Name   string
+	Params []Param
+}
+
+//Param is a param of an Entry.
+type Param struct {
+	Name    string
+	Type    string //The ptx type without the dot, like "u64" or "f32"
+	Size    int    //Size in bytes of the whole param.  An array param like .b8 x[2] has the size of the array.
+	Align   int    //0 if the param doesn't have .align
+	Count   int    //The length of the array. 0 if it isn't an array.
+	Pointer bool   //True if the param has .ptr
+}
+
+//Entry returns the entry called name.
+func (m *Module) Entry(name string) (*Entry, bool) {
+	for i := range m.Entries {
+		if m.Entries[i].Name == name {
+			return &m.Entries[i], true
+		}
+	}
+	return nil, false
+}
+
+//Check checks that there is an arg for every param, and that sizes[i] is the size of param i.
+func (e *Entry) Check(sizes []int) error {
+	if len(sizes) != len(e.Params) {
+		return fmt.Errorf("%w: %s takes %d args, got %d", ErrSignature, e.Name, len(e.Params), len(sizes))
+	}
+	for i, p := range e.Params {
+		if sizes[i] != p.Size {
+			return fmt.Errorf("%w: %s arg %d (%s .%s) is %d bytes, got %d", ErrSignature, e.Name, i, p.Name, p.Type, p.Size, sizes[i])
+		}
+	}
+	return nil
+}
+
+//Parse parses the metadata out of ptx.
+func Parse(ptx string) (*Module, error) {
+	src := stripcomments(ptx)
+	m := new(Module)
+	toks := tokenize(src)
+	for i := 0; i < len(toks); i++ {
+		switch toks[i] {
+		case ".version":
+			if i+1 >= len(toks) {
+				return nil, errors.New("ptx: .version without a number")
+			}
+			i++
+			m.Version = toks[i]
+		case ".target":
+			for i+1 < len(toks) && !strings.HasPrefix(toks[i+1], ".") {
+				i++
+				if toks[i] != "," {
+					m.Target = append(m.Target, toks[i])
+				}
+			}
+			if len(m.Target) == 0 {
+				return nil, errors.New("ptx: .target without a target")
+			}
+		case ".address_size":
+			if i+1 >= len(toks) {
+				return nil, errors.New("ptx: .address_size without a size")
+			}
+			i++
+			n, err := strconv.Atoi(toks[i])
+			if err != nil {
+				return nil, fmt.Errorf("ptx: bad .address_size %q", toks[i])
+			}
+			m.AddressSize = n
+		case ".entry":
+			e, next, err := parseentry(toks, i+1)
+			if err != nil {
+				return nil, err
+			}
+			m.Entries = append(m.Entries, e)
+			i = next - 1
+		}
+	}
+	if m.Version == "" {
+		return nil, errors.New("ptx: no .version")
+	}
+	return m, nil
+}
+
+//parseentry parses name( params ) starting at toks[i]. It returns the index after the ')'.
+func parseentry(toks []string, i int) (Entry, int, error) {
+	var e Entry
+	if i >= len(toks) {
+		return e, i, errors.New("ptx: .entry without a name")
+	}
+	e.Name = toks[i]
+	i++
+	if i >= len(toks) || toks[i] != "(" {
+		//a kernel without params can leave out the parens
+		return e, i, nil
+	}
+	i++
+	var param []string
+	for ; i < len(toks); i++ {
+		switch toks[i] {
+		case ",", ")":
+			if len(param) > 0 {
+				p, err := parseparam(param)
+				if err != nil {
+					return e, i, fmt.Errorf("ptx: %s: %v", e.Name, err)
+				}
+				e.Params = append(e.Params, p)
+				param = param[:0]
+			}
+			if toks[i] == ")" {
+				return e, i + 1, nil
+			}
+		default:
+			param = append(param, toks[i])
+		}
+	}
+	return e, i, fmt.Errorf("ptx: %s: params aren't closed", e.Name)
+}
+
+//parseparam parses a param like [.param .align 2 .b8 name [ 2 ]]
+func parseparam(toks []string) (Param, error) {
+	var p Param
+	if toks[0] != ".param" {
+		return p, fmt.Errorf("param starts with %q", toks[0])
+	}
+	var elemsize int
+	for i := 1; i < len(toks); i++ {
+		t := toks[i]
+		switch {
+		case t == ".align":
+			if i+1 >= len(toks) {
+				return p, errors.New(".align without a number")
+			}
+			i++
+			n, err := strconv.Atoi(toks[i])
+			if err != nil {
+				return p, fmt.Errorf("bad .align %q", toks[i])
+			}
+			p.Align = n
+		case t == ".ptr":
+			p.Pointer = true
+		case t == ".global", t == ".const", t == ".local", t == ".shared":
+		case strings.HasPrefix(t, "."):
+			n, ok := typesize(t[1:])
+			if !ok {
+				return p, fmt.Errorf("unknown param type %q", t)
+			}
+			p.Type, elemsize = t[1:], n
+		case t == "[":
+			if i+2 >= len(toks) || toks[i+2] != "]" {
+				return p, errors.New("bad array param")
+			}
+			n, err := strconv.Atoi(toks[i+1])
+			if err != nil || n < 1 {
+				return p, fmt.Errorf("bad array length %q", toks[i+1])
+			}
+			p.Count = n
+			i += 2
+		default:
+			p.Name = t
+		}
+	}
+	if p.Type == "" {
+		return p, fmt.Errorf("param %q doesn't have a type", p.Name)
+	}
+	p.Size = elemsize
+	if p.Count > 0 {
+		p.Size *= p.Count
+	}
+	return p, nil
+}
+
+func typesize(t string) (int, bool) {
+	switch t {
+	case "b8", "u8", "s8":
+		return 1, true
+	case "b16", "u16", "s16", "f16":
+		return 2, true
+	case "b32", "u32", "s32", "f32", "f16x2":
+		return 4, true
+	case "b64", "u64", "s64", "f64":
+		return 8, true
+	}
+	return 0, false
+}
+
+//stripcomments removes // and /* */ comments
+func stripcomments(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '/' && i+1 < len(s) {
+			switch s[i+1] {
+			case '/':
+				for i < len(s) && s[i] != '\n' {
+					i++
+				}
+				b.WriteByte('\n')
+				continue
+			case '*':
+				end := strings.Index(s[i+2:], "*/")
+				if end < 0 {
+					return b.String()
+				}
+				i += end + 3
+				b.WriteByte(' ')
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+//tokenize splits s on white space and ; and makes (),[]{} their own tokens
+func tokenize(s string) []string {
+	var toks []string
+	start := -1
+	flush := func(i int) {
+		if start >= 0 {
+			toks = append(toks, s[start:i])
+			start = -1
+		}
+	}
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\r', ';':
+			flush(i)
+		case '(', ')', ',', '[', ']', '{', '}':
+			flush(i)
+			toks = append(toks, string(c))
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	flush(len(s))
+	return toks
+}
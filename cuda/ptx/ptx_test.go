@@ -0,0 +1,125 @@
+package ptx
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+const testptx = `//
+//Generated by NVIDIA NVVM Compiler
+//
+
+.version 6.4
+.target sm_75, texmode_independent
+.address_size 64
+
+	//.globl	Scale
+
+.visible .entry Scale(
+	.param .u32 Scale_param_0,
+	.param .u64 .ptr .global .align 4 Scale_param_1, /* x */
+	.param .align 2 .b8 Scale_param_2[2],
+	.param .f32 Scale_param_3
+)
+{
+	.reg .b32 	%r<2>;
+	ld.param.u32 	%r1, [Scale_param_0];
+	ret;
+}
+
+.weak .entry NoParams
+{
+	ret;
+}
+`
+
+func TestParse(t *testing.T) {
+	m, err := Parse(testptx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != "6.4" || m.AddressSize != 64 || len(m.Target) != 2 || m.Target[0] != "sm_75" {
+		t.Error(m.Version, m.AddressSize, m.Target)
+	}
+	if len(m.Entries) != 2 {
+		t.Fatal(m.Entries)
+	}
+	e, ok := m.Entry("Scale")
+	if !ok {
+		t.Fatal("no Scale")
+	}
+	want := []Param{
+		{Name: "Scale_param_0", Type: "u32", Size: 4},
+		{Name: "Scale_param_1", Type: "u64", Size: 8, Align: 4, Pointer: true},
+		{Name: "Scale_param_2", Type: "b8", Size: 2, Align: 2, Count: 2},
+		{Name: "Scale_param_3", Type: "f32", Size: 4},
+	}
+	if len(e.Params) != len(want) {
+		t.Fatal(e.Params)
+	}
+	for i := range want {
+		if e.Params[i] != want[i] {
+			t.Error(i, e.Params[i], want[i])
+		}
+	}
+	if e, ok := m.Entry("NoParams"); !ok || len(e.Params) != 0 {
+		t.Error(e, ok)
+	}
+	if _, ok := m.Entry("Nope"); ok {
+		t.Error("found an entry that isn't there")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	m, _ := Parse(testptx)
+	e, _ := m.Entry("Scale")
+	if err := e.Check([]int{4, 8, 2, 4}); err != nil {
+		t.Error(err)
+	}
+	if err := e.Check([]int{4, 4, 2, 4}); !errors.Is(err, ErrSignature) {
+		t.Error("an int32 for a pointer should fail", err)
+	}
+	if err := e.Check([]int{4, 8, 2}); !errors.Is(err, ErrSignature) {
+		t.Error("missing arg should fail", err)
+	}
+}
+
+func TestBad(t *testing.T) {
+	for _, s := range []string{
+		``,
+		`.target sm_75`,
+		`.version 6.4 .address_size x`,
+		`.version 6.4 .entry K( .param .u128 a )`,
+		`.version 6.4 .entry K( .param .u32 a`,
+		`.version 6.4 .entry K( .param .b8 a[x] )`,
+	} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("%q should fail", s)
+		}
+	}
+}
+
+//the ptx that is shipped with the repo should all parse
+func TestRepoPTX(t *testing.T) {
+	for _, name := range []string{"gocudnnxtra61.ptx", "gocudnnxtra75.ptx", "gocudnnxtra75fp16.ptx", "gocudnnxtra75both.ptx"} {
+		b, err := os.ReadFile("../../kernels/" + name)
+		if err != nil {
+			t.Skip(err)
+		}
+		m, err := Parse(string(b))
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if len(m.Entries) == 0 || m.AddressSize != 64 {
+			t.Error(name, len(m.Entries), m.AddressSize)
+		}
+		for _, e := range m.Entries {
+			for _, p := range e.Params {
+				if p.Size == 0 {
+					t.Error(name, e.Name, p)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package cuda
+
+/*
+#include <cuda.h>
+
+static CUmemAllocationProp vmmprop(CUdevice dev) {
+	CUmemAllocationProp p = {0};
+	p.type = CU_MEM_ALLOCATION_TYPE_PINNED;
+	p.location.type = CU_MEM_LOCATION_TYPE_DEVICE;
+	p.location.id = dev;
+	return p;
+}
+
+static CUresult vmmmap(CUdeviceptr ptr, size_t size, CUdevice dev) {
+	CUmemAllocationProp p = vmmprop(dev);
+	CUmemGenericAllocationHandle h;
+	CUresult x = cuMemCreate(&h, size, &p, 0);
+	if (x != CUDA_SUCCESS) {
+		return x;
+	}
+	x = cuMemMap(ptr, size, 0, h, 0);
+	//The mapping keeps the physical memory alive, so the handle is released either way.
+	cuMemRelease(h);
+	if (x != CUDA_SUCCESS) {
+		return x;
+	}
+	CUmemAccessDesc a = {0};
+	a.location = p.location;
+	a.flags = CU_MEM_ACCESS_FLAGS_PROT_READWRITE;
+	x = cuMemSetAccess(ptr, size, &a, 1);
+	if (x != CUDA_SUCCESS) {
+		cuMemUnmap(ptr, size);
+	}
+	return x;
+}
+
+static CUresult vmmgranularity(size_t *g, CUdevice dev) {
+	CUmemAllocationProp p = vmmprop(dev);
+	return cuMemGetAllocationGranularity(g, &p, CU_MEM_ALLOC_GRANULARITY_RECOMMENDED);
+}
+*/
+import "C"
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/negativeOne1/gocudnn/cuda/vmm"
+)
+
+//GrowableMem is device memory that can be resized without its address changing.  It implements cutil.Mem.
+//
+//It reserves a range of virtual addresses once and maps physical memory into it a chunk at a time with
+//cuMemCreate and cuMemMap as it grows.  Shrinking unmaps the chunks that are no longer needed.
+//Nothing is copied when it is resized, so it suits buffers whose size changes every batch, like activations of variable length sequences.
+//Only the first Size bytes can be used.
+type GrowableMem struct {
+	ptr unsafe.Pointer
+	dev Device
+	buf *vmm.Buffer
+}
+
+//CreateGrowableMem reserves reserve bytes of virtual addresses for memory on dev.  chunk is how much physical memory is mapped at a time.
+//Both are rounded up to the allocation granularity of dev, and chunk can be zero to use the granularity.
+//Nothing is mapped until Resize or Grow is called.  The device needs to support virtual memory management.
+func CreateGrowableMem(dev Device, reserve, chunk uint) (*GrowableMem, error) {
+	var g C.size_t
+	err := newErrorDriver("cuMemGetAllocationGranularity", C.vmmgranularity(&g, dev.c()))
+	if err != nil {
+		return nil, err
+	}
+	m := &GrowableMem{dev: dev}
+	m.buf, err = vmm.New((*vmmmapper)(m), uint(g), reserve, chunk)
+	if err != nil {
+		return nil, err
+	}
+	err = newErrorDriver("cuMemAddressReserve",
+		C.cuMemAddressReserve((*C.CUdeviceptr)(unsafe.Pointer(&m.ptr)), C.size_t(m.buf.Reserved()), 0, 0, 0))
+	if err != nil {
+		return nil, err
+	}
+	runtime.SetFinalizer(m, freegrowablemem)
+	return m, nil
+}
+
+//Ptr returns the address of the memory.  It doesn't change when the memory is resized.
+func (m *GrowableMem) Ptr() unsafe.Pointer {
+	return m.ptr
+}
+
+//DPtr returns a pointer to the address of the memory.
+func (m *GrowableMem) DPtr() *unsafe.Pointer {
+	return &m.ptr
+}
+
+//Size returns the size the memory was last resized to.
+func (m *GrowableMem) Size() uint {
+	return m.buf.Size()
+}
+
+//Mapped returns the bytes that are backed by physical memory.
+func (m *GrowableMem) Mapped() uint {
+	return m.buf.Mapped()
+}
+
+//Reserved returns the most the memory can be resized to.
+func (m *GrowableMem) Reserved() uint {
+	return m.buf.Reserved()
+}
+
+//Resize maps or unmaps chunks so that size bytes can be used.  What was in the memory below size is kept.
+//Kernels that use the memory need to be done with it before it shrinks.
+func (m *GrowableMem) Resize(size uint) error {
+	return m.buf.Resize(size)
+}
+
+//Grow is like Resize but it never shrinks the memory.
+func (m *GrowableMem) Grow(size uint) error {
+	return m.buf.Grow(size)
+}
+
+//Free unmaps all the memory and frees the reserved range.
+func (m *GrowableMem) Free() error {
+	runtime.SetFinalizer(m, nil)
+	return freegrowablemem(m)
+}
+
+func freegrowablemem(m *GrowableMem) error {
+	if m.ptr == nil {
+		return nil
+	}
+	if err := m.buf.Release(); err != nil {
+		return err
+	}
+	err := newErrorDriver("cuMemAddressFree", C.cuMemAddressFree(deviceptr(m.ptr), C.size_t(m.buf.Reserved())))
+	if err != nil {
+		return err
+	}
+	m.ptr = nil
+	return nil
+}
+
+//vmmmapper does the driver calls for the vmm.Buffer of a GrowableMem.
+type vmmmapper GrowableMem
+
+func (v *vmmmapper) Map(offset, size uint) error {
+	return newErrorDriver("cuMemMap", C.vmmmap(deviceptr(v.ptr)+C.CUdeviceptr(offset), C.size_t(size), v.dev.c()))
+}
+
+func (v *vmmmapper) Unmap(offset, size uint) error {
+	return newErrorDriver("cuMemUnmap", C.cuMemUnmap(deviceptr(v.ptr)+C.CUdeviceptr(offset), C.size_t(size)))
+}
@@ -0,0 +1,123 @@
+//Package vmm keeps track of the physical memory that is mapped into a reserved range of virtual device memory.
+//
+//A Buffer lives in a large range that is reserved once, and maps the range a chunk at a time as it grows, so the address of the buffer never changes
+//and growing it never copies.  Shrinking unmaps the chunks past the new size.  The driver calls are made by a Mapper,
+//which is in the cuda package.  This package only does the bookkeeping, so it can be used without a device.
+//
+//	b, _ := vmm.New(mapper, granularity, 1<<30, 2<<20)
+//	b.Resize(5 << 20) //maps 3 chunks of 2MiB
+//	b.Resize(1 << 20) //unmaps 2 of them
+package vmm
+
+import (
+	"errors"
+	"fmt"
+)
+
+//ErrReserve is returned when a buffer is resized past the range it reserved.
+var ErrReserve = errors.New("vmm: size is over the reserved range")
+
+//Mapper maps and unmaps physical memory at an offset into the reserved range.  Offsets and sizes are always multiples of the chunk size.
+type Mapper interface {
+	//Map backs [offset, offset+size) with new physical memory that the device can read and write.
+	Map(offset, size uint) error
+	//Unmap unmaps [offset, offset+size) and frees its physical memory.
+	Unmap(offset, size uint) error
+}
+
+//Buffer is a growable buffer in a reserved virtual range.
+type Buffer struct {
+	m        Mapper
+	chunk    uint
+	reserved uint
+	mapped   uint
+	size     uint
+}
+
+//New makes a Buffer that maps memory through m.  chunk is rounded up to a multiple of granularity,
+//and reserve is rounded up to a multiple of chunk.  Nothing is mapped until the buffer is resized.
+//The range itself has to have been reserved with the size Reserved returns.
+func New(m Mapper, granularity, reserve, chunk uint) (*Buffer, error) {
+	if granularity == 0 {
+		return nil, errors.New("vmm: granularity is zero")
+	}
+	if chunk == 0 {
+		chunk = granularity
+	}
+	chunk = roundup(chunk, granularity)
+	reserve = roundup(reserve, chunk)
+	if reserve == 0 {
+		return nil, errors.New("vmm: reserve is zero")
+	}
+	return &Buffer{m: m, chunk: chunk, reserved: reserve}, nil
+}
+
+func roundup(size, granularity uint) uint {
+	return (size + granularity - 1) / granularity * granularity
+}
+
+//Size is the size the buffer was last resized to.
+func (b *Buffer) Size() uint { return b.size }
+
+//Mapped is the number of bytes that are backed by physical memory.  It is Size rounded up to the chunk size, unless an Unmap failed.
+func (b *Buffer) Mapped() uint { return b.mapped }
+
+//Reserved is the size of the virtual range.
+func (b *Buffer) Reserved() uint { return b.reserved }
+
+//Chunk is the size of each mapping.
+func (b *Buffer) Chunk() uint { return b.chunk }
+
+//Resize maps chunks until size bytes are backed, or unmaps the chunks that are past size.
+//If mapping fails the chunks this call mapped are unmapped again, so the buffer is left as it was.
+func (b *Buffer) Resize(size uint) error {
+	if size > b.reserved {
+		return fmt.Errorf("%w: %d > %d", ErrReserve, size, b.reserved)
+	}
+	want := roundup(size, b.chunk)
+	switch {
+	case want > b.mapped:
+		for off := b.mapped; off < want; off += b.chunk {
+			if err := b.m.Map(off, b.chunk); err != nil {
+				if uerr := b.unmap(b.mapped, off); uerr != nil {
+					return errors.Join(err, uerr)
+				}
+				return err
+			}
+		}
+	case want < b.mapped:
+		if err := b.unmap(want, b.mapped); err != nil {
+			return err
+		}
+	}
+	b.mapped = want
+	b.size = size
+	return nil
+}
+
+//Grow resizes the buffer to size if it is bigger than the buffer is now.  It never shrinks.
+func (b *Buffer) Grow(size uint) error {
+	if size <= b.size {
+		return nil
+	}
+	return b.Resize(size)
+}
+
+//Release unmaps everything.  The buffer can be resized again afterwards.
+func (b *Buffer) Release() error {
+	return b.Resize(0)
+}
+
+//unmap unmaps the chunks in [from, to) from the top down.  If one fails b.mapped is left at what is still mapped.
+func (b *Buffer) unmap(from, to uint) error {
+	for off := to; off > from; off -= b.chunk {
+		if err := b.m.Unmap(off-b.chunk, b.chunk); err != nil {
+			b.mapped = off
+			if b.size > off {
+				b.size = off
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package vmm
+
+import (
+	"errors"
+	"testing"
+)
+
+//fake keeps the mapped chunks by offset and fails the calls whose numbers are in fail.
+type fake struct {
+	t      *testing.T
+	chunk  uint
+	mapped map[uint]bool
+	calls  int
+	fail   map[int]bool
+}
+
+var errFake = errors.New("fake mapper error")
+
+func newfake(t *testing.T, chunk uint) *fake {
+	return &fake{t: t, chunk: chunk, mapped: map[uint]bool{}}
+}
+
+func (f *fake) call() error {
+	f.calls++
+	if f.fail[f.calls] {
+		return errFake
+	}
+	return nil
+}
+
+func (f *fake) Map(offset, size uint) error {
+	if offset%f.chunk != 0 || size != f.chunk {
+		f.t.Error("Map", offset, size)
+	}
+	if f.mapped[offset] {
+		f.t.Error("mapped twice", offset)
+	}
+	if err := f.call(); err != nil {
+		return err
+	}
+	f.mapped[offset] = true
+	return nil
+}
+
+func (f *fake) Unmap(offset, size uint) error {
+	if !f.mapped[offset] || size != f.chunk {
+		f.t.Error("Unmap", offset, size)
+	}
+	if err := f.call(); err != nil {
+		return err
+	}
+	delete(f.mapped, offset)
+	return nil
+}
+
+//check checks that exactly the chunks below b.Mapped are mapped.
+func (f *fake) check(b *Buffer) {
+	f.t.Helper()
+	if uint(len(f.mapped))*b.Chunk() != b.Mapped() {
+		f.t.Error("fake has", len(f.mapped), "chunks, buffer has", b.Mapped(), "bytes mapped")
+	}
+	for off := uint(0); off < b.Mapped(); off += b.Chunk() {
+		if !f.mapped[off] {
+			f.t.Error("chunk", off, "isn't mapped")
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		granularity, reserve, chunk uint
+		wantchunk, wantreserve      uint
+	}{
+		{2 << 20, 1 << 30, 2 << 20, 2 << 20, 1 << 30},
+		{2 << 20, 1 << 30, 3 << 20, 4 << 20, 1 << 30},
+		{2 << 20, 5 << 20, 0, 2 << 20, 6 << 20},
+		{64 << 10, 1 << 20, 100 << 10, 128 << 10, 1 << 20},
+		{4, 10, 6, 8, 16},
+	}
+	for _, tt := range tests {
+		b, err := New(newfake(t, 1), tt.granularity, tt.reserve, tt.chunk)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if b.Chunk() != tt.wantchunk || b.Reserved() != tt.wantreserve {
+			t.Error(tt, "got", b.Chunk(), b.Reserved())
+		}
+	}
+	if _, err := New(newfake(t, 1), 0, 10, 10); err == nil {
+		t.Error("no error for zero granularity")
+	}
+	if _, err := New(newfake(t, 1), 4, 0, 4); err == nil {
+		t.Error("no error for zero reserve")
+	}
+}
+
+func TestResize(t *testing.T) {
+	f := newfake(t, 4)
+	b, _ := New(f, 4, 64, 4)
+	for _, size := range []uint{1, 4, 5, 30, 30, 12, 0, 64, 3, 0} {
+		if err := b.Resize(size); err != nil {
+			t.Fatal(size, err)
+		}
+		if b.Size() != size || b.Mapped() != roundup(size, 4) {
+			t.Error(size, b.Size(), b.Mapped())
+		}
+		f.check(b)
+	}
+	if err := b.Resize(65); !errors.Is(err, ErrReserve) {
+		t.Error(err)
+	}
+	if b.Size() != 0 || len(f.mapped) != 0 {
+		t.Error("failed resize changed the buffer")
+	}
+}
+
+func TestGrow(t *testing.T) {
+	f := newfake(t, 4)
+	b, _ := New(f, 4, 64, 4)
+	for _, tt := range []struct{ size, want uint }{{10, 10}, {5, 10}, {20, 20}, {0, 20}} {
+		if err := b.Grow(tt.size); err != nil {
+			t.Fatal(err)
+		}
+		if b.Size() != tt.want {
+			t.Error(tt, b.Size())
+		}
+		f.check(b)
+	}
+	if err := b.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if b.Size() != 0 || b.Mapped() != 0 || len(f.mapped) != 0 {
+		t.Error("Release left", b.Size(), b.Mapped(), len(f.mapped))
+	}
+}
+
+//A failed Map unmaps the chunks the same call mapped and leaves the ones from before.
+func TestResizeMapError(t *testing.T) {
+	f := newfake(t, 4)
+	b, _ := New(f, 4, 64, 4)
+	b.Resize(8)
+	f.calls, f.fail = 0, map[int]bool{3: true}
+	if err := b.Resize(32); !errors.Is(err, errFake) {
+		t.Error(err)
+	}
+	if b.Size() != 8 || b.Mapped() != 8 {
+		t.Error(b.Size(), b.Mapped())
+	}
+	f.check(b)
+
+	//The unmap of the rollback fails too, so what is left mapped is kept.
+	f.calls, f.fail = 0, map[int]bool{3: true, 4: true}
+	if err := b.Resize(32); !errors.Is(err, errFake) {
+		t.Error(err)
+	}
+	if b.Size() != 8 || b.Mapped() != 16 {
+		t.Error(b.Size(), b.Mapped())
+	}
+	f.check(b)
+	f.fail = nil
+	if err := b.Resize(32); err != nil {
+		t.Fatal(err)
+	}
+	f.check(b)
+}
+
+func TestResizeUnmapError(t *testing.T) {
+	f := newfake(t, 4)
+	b, _ := New(f, 4, 64, 4)
+	b.Resize(32)
+	f.calls, f.fail = 0, map[int]bool{3: true}
+	if err := b.Resize(4); !errors.Is(err, errFake) {
+		t.Error(err)
+	}
+	if b.Size() != 24 || b.Mapped() != 24 {
+		t.Error(b.Size(), b.Mapped())
+	}
+	f.check(b)
+	f.fail = nil
+	if err := b.Resize(4); err != nil {
+		t.Fatal(err)
+	}
+	if b.Size() != 4 || b.Mapped() != 4 {
+		t.Error(b.Size(), b.Mapped())
+	}
+	f.check(b)
+}
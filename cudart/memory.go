@@ -471,6 +471,17 @@ func Memset(mem cutil.Mem, value int32, count uint) error {
 	return newErrorRuntime("cudaMemset", err)
 }
 
+//MemsetAsync is like Memset but it is queued on stream.  If stream is nil it is queued on the default stream.
+func MemsetAsync(mem cutil.Mem, value int32, count uint, stream gocu.Streamer) error {
+	var s C.cudaStream_t
+	if stream != nil {
+		s = C.cudaStream_t(stream.Ptr())
+	}
+	err := C.cudaMemsetAsync(mem.Ptr(), C.int(value), C.size_t(count), s)
+
+	return newErrorRuntime("cudaMemsetAsync", err)
+}
+
 //Atribs are a memories attributes on the device side
 type Atribs struct {
 	Type    MemType
@@ -140,16 +140,24 @@ func (a *ActivationD) Forward(
 	alpha float64,
 	xD *TensorD, x cutil.Mem,
 	beta float64,
-	yD *TensorD, y cutil.Mem) error {
+	yD *TensorD, y cutil.Mem) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(a *ActivationD) Forward", []fmt.Stringer{a, xD, yD},
+					nonfiniteoutput{name: "y", desc: yD, mem: y},
+				)
+			}
+		}()
+	}
 	a1 := cscalarbydatatype(yD.dtype, alpha)
 	b := cscalarbydatatype(yD.dtype, beta)
-	var err error
 	if handle.w != nil {
 		err = handle.w.Work(func() error {
-			return Status(C.cudnnActivationForward(handle.x, a.descriptor, a1.CPtr(), xD.descriptor, x.Ptr(), b.CPtr(), yD.descriptor, y.Ptr())).error(" (a *ActivationD) Forward")
+			return Status(C.cudnnActivationForward(handle.x, a.descriptor, a1.CPtr(), xD.descriptor, x.Ptr(), b.CPtr(), yD.descriptor, y.Ptr())).errorDesc(" (a *ActivationD) Forward", a, xD, yD)
 		})
 	} else {
-		err = Status(C.cudnnActivationForward(handle.x, a.descriptor, a1.CPtr(), xD.descriptor, x.Ptr(), b.CPtr(), yD.descriptor, y.Ptr())).error(" (a *ActivationD) Forward")
+		err = Status(C.cudnnActivationForward(handle.x, a.descriptor, a1.CPtr(), xD.descriptor, x.Ptr(), b.CPtr(), yD.descriptor, y.Ptr())).errorDesc(" (a *ActivationD) Forward", a, xD, yD)
 	}
 	return err
 }
@@ -274,16 +282,24 @@ func (a *ActivationD) Backward(
 	dyD *TensorD, dy cutil.Mem,
 	xD *TensorD, x cutil.Mem,
 	beta float64,
-	dxD *TensorD, dx cutil.Mem) error {
+	dxD *TensorD, dx cutil.Mem) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(a *ActivationD) Backward", []fmt.Stringer{a, yD, dyD, xD, dxD},
+					nonfiniteoutput{name: "dx", desc: dxD, mem: dx},
+				)
+			}
+		}()
+	}
 	a1 := cscalarbydatatype(yD.dtype, alpha)
 	b := cscalarbydatatype(yD.dtype, beta)
-	var err error
 	if handle.w != nil {
 		err = handle.w.Work(func() error {
-			return Status(C.cudnnActivationBackward(handle.x, a.descriptor, a1.CPtr(), yD.descriptor, y.Ptr(), dyD.descriptor, dy.Ptr(), xD.descriptor, x.Ptr(), b.CPtr(), dxD.descriptor, dx.Ptr())).error("(a *ActivationD) Backward")
+			return Status(C.cudnnActivationBackward(handle.x, a.descriptor, a1.CPtr(), yD.descriptor, y.Ptr(), dyD.descriptor, dy.Ptr(), xD.descriptor, x.Ptr(), b.CPtr(), dxD.descriptor, dx.Ptr())).errorDesc("(a *ActivationD) Backward", a, yD, dyD, xD, dxD)
 		})
 	} else {
-		err = Status(C.cudnnActivationBackward(handle.x, a.descriptor, a1.CPtr(), yD.descriptor, y.Ptr(), dyD.descriptor, dy.Ptr(), xD.descriptor, x.Ptr(), b.CPtr(), dxD.descriptor, dx.Ptr())).error("(a *ActivationD) Backward")
+		err = Status(C.cudnnActivationBackward(handle.x, a.descriptor, a1.CPtr(), yD.descriptor, y.Ptr(), dyD.descriptor, dy.Ptr(), xD.descriptor, x.Ptr(), b.CPtr(), dxD.descriptor, dx.Ptr())).errorDesc("(a *ActivationD) Backward", a, yD, dyD, xD, dxD)
 	}
 	return err
 }
@@ -137,7 +137,16 @@ func (b *BatchNormD) ForwardInference(
 	ScaleBiasMeanVarDesc *TensorD, scale, bias, estimatedMean, estimatedVariance cutil.Mem, //all share the ScaleBiasMeanVarDesc descriptor
 	epsilon float64,
 
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(b *BatchNormD) ForwardInference", []fmt.Stringer{b, xD, yD, ScaleBiasMeanVarDesc},
+					nonfiniteoutput{name: "y", desc: yD, mem: y},
+				)
+			}
+		}()
+	}
 	if !b.set {
 		return errors.New("(b *BatchNormD) ForwardInference: BatchNormD not set")
 	}
@@ -158,7 +167,7 @@ func (b *BatchNormD) ForwardInference(
 				ScaleBiasMeanVarDesc.descriptor,
 				scale.Ptr(), bias.Ptr(), estimatedMean.Ptr(), estimatedVariance.Ptr(),
 				C.double(epsilon),
-			)).error("(b *BatchNormD) ForwardInference")
+			)).errorDesc("(b *BatchNormD) ForwardInference", b, xD, yD, ScaleBiasMeanVarDesc)
 		})
 	}
 	return Status(C.cudnnBatchNormalizationForwardInference(
@@ -173,7 +182,7 @@ func (b *BatchNormD) ForwardInference(
 		ScaleBiasMeanVarDesc.descriptor,
 		scale.Ptr(), bias.Ptr(), estimatedMean.Ptr(), estimatedVariance.Ptr(),
 		C.double(epsilon),
-	)).error("(b *BatchNormD) ForwardInference")
+	)).errorDesc("(b *BatchNormD) ForwardInference", b, xD, yD, ScaleBiasMeanVarDesc)
 }
 
 //ForwardInferenceUS is like ForwardInference but uses unsafe.Pointers instead of cutil.Mems
@@ -238,7 +247,18 @@ func (b *BatchNormD) Backward(
 	epsilon float64, /* Same epsilon as forward pass */
 	/* Optionally cached intermediate results from forward pass */
 	savedMean, savedInvVariance cutil.Mem,
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(b *BatchNormD) Backward", []fmt.Stringer{b, xD, dyD, dxD, dBnScaleBiasDesc},
+					nonfiniteoutput{name: "dx", desc: dxD, mem: dx},
+					nonfiniteoutput{name: "dscale", desc: dBnScaleBiasDesc, mem: dscale},
+					nonfiniteoutput{name: "dbias", desc: dBnScaleBiasDesc, mem: dbias},
+				)
+			}
+		}()
+	}
 	if !b.set {
 		return errors.New("BatchNormD not set")
 	}
@@ -274,7 +294,7 @@ func (b *BatchNormD) Backward(
 				C.double(epsilon),
 				smptr,
 				sinvptr,
-			)).error("(b *BatchNormD) Backward")
+			)).errorDesc("(b *BatchNormD) Backward", b, xD, dyD, dxD, dBnScaleBiasDesc)
 		})
 	}
 	return Status(C.cudnnBatchNormalizationBackward(
@@ -291,7 +311,7 @@ func (b *BatchNormD) Backward(
 		C.double(epsilon),
 		smptr,
 		sinvptr,
-	)).error("(b *BatchNormD) Backward")
+	)).errorDesc("(b *BatchNormD) Backward", b, xD, dyD, dxD, dBnScaleBiasDesc)
 }
 
 //BackwardUS is like Backward but uses unsafe.Pointers instead of cutil.Mem
@@ -483,7 +503,20 @@ func (b *BatchNormD) ForwardTraining(
 	resultSaveMean cutil.Mem, //output /* Optionally save intermediate results from the forward pass here	- can be reused to speed up backward pass. NULL if unused */
 	resultSaveInvVariance cutil.Mem, //output /* Optionally save intermediate results from the forward pass here	- can be reused to speed up backward pass. NULL if unused */
 
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(b *BatchNormD) ForwardTraining", []fmt.Stringer{b, xD, yD, bnScaleBiasMeanVar},
+					nonfiniteoutput{name: "y", desc: yD, mem: y},
+					nonfiniteoutput{name: "resultrunningmean", desc: bnScaleBiasMeanVar, mem: resultrunningmean},
+					nonfiniteoutput{name: "resultRunningVariance", desc: bnScaleBiasMeanVar, mem: resultRunningVariance},
+					nonfiniteoutput{name: "resultSaveMean", desc: bnScaleBiasMeanVar, mem: resultSaveMean},
+					nonfiniteoutput{name: "resultSaveInvVariance", desc: bnScaleBiasMeanVar, mem: resultSaveInvVariance},
+				)
+			}
+		}()
+	}
 	if !b.set {
 		return errors.New("BatchNormD not set")
 	}
@@ -507,7 +540,7 @@ func (b *BatchNormD) ForwardTraining(
 					resultrunningmean.Ptr(), resultRunningVariance.Ptr(),
 					C.double(epsilon),
 					nil, nil,
-				)).error("(b *BatchNormD) ForwardTraining")
+				)).errorDesc("(b *BatchNormD) ForwardTraining", b, xD, yD, bnScaleBiasMeanVar)
 			}
 			return Status(C.cudnnBatchNormalizationForwardTraining(
 				handle.x,
@@ -525,7 +558,7 @@ func (b *BatchNormD) ForwardTraining(
 				resultrunningmean.Ptr(), resultRunningVariance.Ptr(),
 				C.double(epsilon),
 				resultSaveMean.Ptr(), resultSaveInvVariance.Ptr(),
-			)).error("(b *BatchNormD) ForwardTraining")
+			)).errorDesc("(b *BatchNormD) ForwardTraining", b, xD, yD, bnScaleBiasMeanVar)
 		})
 	}
 	if resultSaveInvVariance == nil || resultSaveMean == nil {
@@ -544,7 +577,7 @@ func (b *BatchNormD) ForwardTraining(
 			resultrunningmean.Ptr(), resultRunningVariance.Ptr(),
 			C.double(epsilon),
 			nil, nil,
-		)).error("(b *BatchNormD) ForwardTraining")
+		)).errorDesc("(b *BatchNormD) ForwardTraining", b, xD, yD, bnScaleBiasMeanVar)
 	}
 	return Status(C.cudnnBatchNormalizationForwardTraining(
 		handle.x,
@@ -562,7 +595,7 @@ func (b *BatchNormD) ForwardTraining(
 		resultrunningmean.Ptr(), resultRunningVariance.Ptr(),
 		C.double(epsilon),
 		resultSaveMean.Ptr(), resultSaveInvVariance.Ptr(),
-	)).error("(b *BatchNormD) ForwardTraining")
+	)).errorDesc("(b *BatchNormD) ForwardTraining", b, xD, yD, bnScaleBiasMeanVar)
 }
 
 //ForwardTrainingUS is just like ForwardTraining but uses unsafe.Pointers.
@@ -167,7 +167,24 @@ func (b *BatchNormDEx) ForwardTraining(
 	wspacesib uint,
 	rspace cutil.Mem,
 	rspacesib uint,
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				descs := []fmt.Stringer{b, xD, yD, bnScaleBiasMeanVarDesc}
+				if zD != nil {
+					descs = append(descs, zD)
+				}
+				err = h.checknonfinite("(b *BatchNormDEx) ForwardTraining", descs,
+					nonfiniteoutput{name: "y", desc: yD, mem: y},
+					nonfiniteoutput{name: "resultRunningMean", desc: bnScaleBiasMeanVarDesc, mem: resultRunningMean},
+					nonfiniteoutput{name: "resultRunningVariance", desc: bnScaleBiasMeanVarDesc, mem: resultRunningVariance},
+					nonfiniteoutput{name: "resultSaveMean", desc: bnScaleBiasMeanVarDesc, mem: resultSaveMean},
+					nonfiniteoutput{name: "resultSaveInvVariance", desc: bnScaleBiasMeanVarDesc, mem: reslutSaveInVariance},
+				)
+			}
+		}()
+	}
 	if !b.set {
 		return errors.New("BatchNormD not set")
 	}
@@ -392,7 +409,23 @@ func (b *BatchNormDEx) Backward(
 	wspacesib uint,
 	rspace cutil.Mem,
 	rspacesib uint,
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				descs := []fmt.Stringer{b, xD, yD, dyD, dxD, dbnScaleBiasMeanVarDesc}
+				if dzD != nil {
+					descs = append(descs, dzD)
+				}
+				err = h.checknonfinite("(b *BatchNormDEx) Backward", descs,
+					nonfiniteoutput{name: "dx", desc: dxD, mem: dx},
+					nonfiniteoutput{name: "dz", desc: dzD, mem: dz},
+					nonfiniteoutput{name: "dscale", desc: dbnScaleBiasMeanVarDesc, mem: dscale},
+					nonfiniteoutput{name: "dbias", desc: dbnScaleBiasMeanVarDesc, mem: dbias},
+				)
+			}
+		}()
+	}
 	if !b.set {
 		return errors.New("BatchNormDEx not set")
 	}
@@ -681,7 +714,16 @@ func (b *BatchNormDEx) ForwardInference(
 	scale, bias, estimatedMean, estimatedVariance cutil.Mem, //all share the ScaleBiasMeanVarDesc descriptor
 	epsilon float64,
 
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(b *BatchNormDEx) ForwardInference", []fmt.Stringer{b, xD, yD, ScaleBiasMeanVarDesc},
+					nonfiniteoutput{name: "y", desc: yD, mem: y},
+				)
+			}
+		}()
+	}
 	if !b.set {
 		return errors.New("BatchNormDEx not set")
 	}
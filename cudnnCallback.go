@@ -3,6 +3,7 @@ package gocudnn
 /*
 #include "cudnnCallback.h"
 #include <cudnn.h>
+#include <stdlib.h>
 extern void go_call_back(cudnnSeverity_t sev, void *udata, cudnnDebug_t *dbg, char *msg);
 //void go_call_back_cgo(cudnnSeverity_t sev, void *udata, cudnnDebug_t *dbg, char *msg);
 */
@@ -12,7 +13,10 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"time"
 	"unsafe"
+
+	"github.com/negativeOne1/gocudnn/cudnnlog"
 )
 
 //typedef struct {
@@ -30,25 +34,19 @@ import (
 //} cudnnDebug_t;
 //export go_call_back
 func go_call_back(sev C.cudnnSeverity_t, udata unsafe.Pointer, dbg *C.cudnnDebug_t, msg *C.char) {
-	var err error
 	var userdata string
-	if udata == nil {
-		userdata = "None"
-	} else {
+	if udata != nil {
 		userdata = C.GoString((*C.char)(udata))
 	}
-	fmt.Println("going into callback")
-	gomsg := C.GoString(msg)
-	gdbg := (*Debug)(dbg)
-	s := fmt.Sprintf("UserData: %v\n: %v\n,Message: %v\n",
-		userdata, gdbg, gomsg)
-	//	gs := C.GoString(msg)
+	r := (*Debug)(dbg).record(cudnnlog.Severity(sev), C.GoString(msg), userdata)
 	mu.Lock()
-
-	_, err = callbackwriter.Write([]byte(s))
+	sink, ring := logsink, logring
 	mu.Unlock()
-	if err != nil {
-		panic(err)
+	if ring != nil {
+		ring.Log(r)
+	}
+	if sink != nil {
+		sink.Log(r)
 	}
 }
 
@@ -76,31 +74,105 @@ func (d *Debug) String() string {
 		"\tDeviceID: %v\n}", v, ts, tus, td, handle, stream, pid, tid, did)
 }
 
+func (d *Debug) record(sev cudnnlog.Severity, msg, udata string) cudnnlog.Record {
+	return cudnnlog.Record{
+		Severity: sev,
+		Version:  int(d.cudnn_version),
+		Status:   int(d.cudnnStatus),
+		Time:     time.Unix(int64(d.time_sec), int64(d.time_usec)*int64(time.Microsecond)),
+		Delta:    time.Duration(d.time_delta) * time.Second,
+		Received: time.Now(),
+		Handle:   uintptr(unsafe.Pointer(d.handle)),
+		Stream:   uintptr(unsafe.Pointer(d.stream)),
+		PID:      uint64(d.pid),
+		TID:      uint64(d.tid),
+		Device:   int(d.cudaDeviceId),
+		Message:  msg,
+		UserData: udata,
+	}
+}
+
 var mu sync.Mutex
-var callbackwriter io.Writer
+var setmu sync.Mutex
+var logsink cudnnlog.Sink
+var logring *cudnnlog.Ring
+var logudata *C.char
 
-//SetCallBack sets the debug callback function.  Callback data will be writer to the writer.
-//udata is custom user data that will write to the call back.  udata can be nil
-//Callback is not functional.
-func SetCallBack(udata fmt.Stringer, w io.Writer) error {
+//SetLogSink turns on the cudnn callback and sends records that are at least as severe as min to s.
+//udata is custom user data that is put in each record. udata can be nil.
+//If s is nil the callback is turned off, unless a ring was set with SetLogRing.  Then it stays on so the ring still gets records.
+func SetLogSink(min cudnnlog.Severity, s cudnnlog.Sink, udata fmt.Stringer) error {
+	var cudata *C.char
+	if udata != nil {
+		cudata = C.CString(udata.String())
+	}
+	//cudnn can call the callback from inside cudnnSetCallback, so mu can't be held here.
+	setmu.Lock()
+	defer setmu.Unlock()
 	mu.Lock()
+	ring := logring
+	mu.Unlock()
+	var err error
+	if s == nil && ring == nil {
+		err = Status(C.cudnnSetCallback(0, nil, nil)).error("SetLogSink")
+	} else {
+		err = Status(C.cudnnSetCallback(severitymask(min), (unsafe.Pointer)(cudata), createcallback())).error("SetLogSink")
+	}
+	if err != nil {
+		C.free(unsafe.Pointer(cudata))
+		return err
+	}
+	mu.Lock()
+	logsink = s
+	mu.Unlock()
+	if logudata != nil {
+		C.free(unsafe.Pointer(logudata))
+	}
+	logudata = cudata
+	return nil
+}
 
-	callbackwriter = w
+//SetLogRing sets a ring that gets every record that the callback gets.
+//While it is set, errors returned by gocudnn are a *cudnnlog.RecordsError holding the records in the ring.
+//errors.Is and errors.As still see the *cuerr.Error in them.  r can be nil to stop attaching records.
+//
+//The ring only gets records while the callback is on. See SetLogSink.
+func SetLogRing(r *cudnnlog.Ring) {
+	mu.Lock()
+	logring = r
 	mu.Unlock()
-	if udata == nil {
-		return Status(C.cudnnSetCallback(C.CUDNN_SEV_ERROR_EN, nil, createcallback())).error("SetCallBack")
+}
 
+func attachlog(err error) error {
+	if err == nil {
+		return nil
 	}
-	udatabytes := C.CString(udata.String())
-	return Status(C.cudnnSetCallback(C.CUDNN_SEV_ERROR_EN, (unsafe.Pointer)(udatabytes), createcallback())).error("SetCallBack")
+	mu.Lock()
+	ring := logring
+	mu.Unlock()
+	return ring.Attach(err)
+}
+
+func severitymask(min cudnnlog.Severity) C.uint {
+	var mask C.uint
+	for s := cudnnlog.Fatal; s <= min; s++ {
+		mask |= 1 << C.uint(s)
+	}
+	return mask
+}
 
+//SetCallBack sets the debug callback function.  Callback data will be writer to the writer.
+//udata is custom user data that will write to the call back.  udata can be nil
+//
+//SetCallBack only sends errors.  Use SetLogSink for other severities, slog, or rate limiting.
+func SetCallBack(udata fmt.Stringer, w io.Writer) error {
+	return SetLogSink(cudnnlog.Error, cudnnlog.NewWriterSink(w), udata)
 }
 func createcallback() C.cudnnCallback_t {
 	var cb C.cudnnCallback_t
 	cb = (C.cudnnCallback_t)(unsafe.Pointer(C.CudaCallBack))
 	return cb
 }
-
 //#define CUDNN_SEV_ERROR_EN (1U << CUDNN_SEV_ERROR)
 //#define CUDNN_SEV_WARNING_EN (1U << CUDNN_SEV_WARNING)
 //#define CUDNN_SEV_INFO_EN (1U << CUDNN_SEV_INFO)
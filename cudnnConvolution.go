@@ -329,7 +329,16 @@ func (c *ConvolutionD) BackwardData(
 	wspace cutil.Mem, wspaceSIB uint,
 	beta float64,
 	dxD *TensorD, dx cutil.Mem,
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(c *ConvolutionD) BackwardData", []fmt.Stringer{c, wD, dyD, dxD},
+					nonfiniteoutput{name: "dx", desc: dxD, mem: dx},
+				)
+			}
+		}()
+	}
 	a := cscalarbydatatype(dyD.dtype, alpha)
 	b := cscalarbydatatype(dyD.dtype, beta)
 	if handle.w != nil {
@@ -350,7 +359,7 @@ func (c *ConvolutionD) BackwardData(
 					b.CPtr(),
 					dxD.descriptor,
 					dx.Ptr(),
-				)).error("(c *ConvolutionD) BackwardData")
+				)).errorDesc("(c *ConvolutionD) BackwardData", c, wD, dyD, dxD)
 			}
 
 			return Status(C.cudnnConvolutionBackwardData(
@@ -367,7 +376,7 @@ func (c *ConvolutionD) BackwardData(
 				b.CPtr(),
 				dxD.descriptor,
 				dx.Ptr(),
-			)).error("(c *ConvolutionD) BackwardData")
+			)).errorDesc("(c *ConvolutionD) BackwardData", c, wD, dyD, dxD)
 		})
 	}
 	if wspace == nil {
@@ -386,7 +395,7 @@ func (c *ConvolutionD) BackwardData(
 			b.CPtr(),
 			dxD.descriptor,
 			dx.Ptr(),
-		)).error("(c *ConvolutionD) BackwardData")
+		)).errorDesc("(c *ConvolutionD) BackwardData", c, wD, dyD, dxD)
 	}
 
 	return Status(C.cudnnConvolutionBackwardData(
@@ -403,7 +412,7 @@ func (c *ConvolutionD) BackwardData(
 		b.CPtr(),
 		dxD.descriptor,
 		dx.Ptr(),
-	)).error("(c *ConvolutionD) BackwardData")
+	)).errorDesc("(c *ConvolutionD) BackwardData", c, wD, dyD, dxD)
 }
 
 //BackwardDataUS is like BackwardData but uses unsafe.Pointer instead of cutil.Mem
@@ -512,15 +521,24 @@ func (c *ConvolutionD) BackwardBias(
 	dy cutil.Mem,
 	beta float64,
 	dbD *TensorD,
-	db cutil.Mem) error {
+	db cutil.Mem) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(c *ConvolutionD) BackwardBias", []fmt.Stringer{c, dyD, dbD},
+					nonfiniteoutput{name: "db", desc: dbD, mem: db},
+				)
+			}
+		}()
+	}
 	a := cscalarbydatatype(dyD.dtype, alpha)
 	b := cscalarbydatatype(dyD.dtype, beta)
 	if handle.w != nil {
 		return handle.w.Work(func() error {
-			return Status(C.cudnnConvolutionBackwardBias(handle.x, a.CPtr(), dyD.descriptor, dy.Ptr(), b.CPtr(), dbD.descriptor, db.Ptr())).error("(c *ConvolutionD) BackwardBias")
+			return Status(C.cudnnConvolutionBackwardBias(handle.x, a.CPtr(), dyD.descriptor, dy.Ptr(), b.CPtr(), dbD.descriptor, db.Ptr())).errorDesc("(c *ConvolutionD) BackwardBias", c, dyD, dbD)
 		})
 	}
-	return Status(C.cudnnConvolutionBackwardBias(handle.x, a.CPtr(), dyD.descriptor, dy.Ptr(), b.CPtr(), dbD.descriptor, db.Ptr())).error("(c *ConvolutionD) BackwardBias")
+	return Status(C.cudnnConvolutionBackwardBias(handle.x, a.CPtr(), dyD.descriptor, dy.Ptr(), b.CPtr(), dbD.descriptor, db.Ptr())).errorDesc("(c *ConvolutionD) BackwardBias", c, dyD, dbD)
 }
 
 //BackwardBiasUS is like BackwardBias but using unsafe.Pointer instead of cutil.Mem
@@ -584,10 +602,18 @@ func (c *ConvolutionD) BackwardFilter(
 	wspace cutil.Mem, wspacesize uint,
 	beta float64,
 	dwD *FilterD, dw cutil.Mem,
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(c *ConvolutionD) BackwardFilter", []fmt.Stringer{c, xD, dyD, dwD},
+					nonfiniteoutput{name: "dw", desc: dwD, mem: dw},
+				)
+			}
+		}()
+	}
 	a := cscalarbydatatype(dyD.dtype, alpha)
 	b := cscalarbydatatype(dyD.dtype, beta)
-	var err error
 	if handle.w != nil {
 		err = handle.w.Work(func() error {
 			if wspace == nil {
@@ -608,7 +634,7 @@ func (c *ConvolutionD) BackwardFilter(
 					b.CPtr(),
 					dwD.descriptor,
 					dw.Ptr(),
-				)).error("(c *ConvolutionD) BackwardFilter")
+				)).errorDesc("(c *ConvolutionD) BackwardFilter", c, xD, dyD, dwD)
 
 			}
 			if cudnndebugmode {
@@ -628,7 +654,7 @@ func (c *ConvolutionD) BackwardFilter(
 				b.CPtr(),
 				dwD.descriptor,
 				dw.Ptr(),
-			)).error("(c *ConvolutionD) BackwardFilter")
+			)).errorDesc("(c *ConvolutionD) BackwardFilter", c, xD, dyD, dwD)
 
 		})
 	} else {
@@ -650,7 +676,7 @@ func (c *ConvolutionD) BackwardFilter(
 				b.CPtr(),
 				dwD.descriptor,
 				dw.Ptr(),
-			)).error("(c *ConvolutionD) BackwardFilter")
+			)).errorDesc("(c *ConvolutionD) BackwardFilter", c, xD, dyD, dwD)
 
 		} else {
 			if cudnndebugmode {
@@ -670,7 +696,7 @@ func (c *ConvolutionD) BackwardFilter(
 				b.CPtr(),
 				dwD.descriptor,
 				dw.Ptr(),
-			)).error("(c *ConvolutionD) BackwardFilter")
+			)).errorDesc("(c *ConvolutionD) BackwardFilter", c, xD, dyD, dwD)
 		}
 
 	}
@@ -776,30 +802,38 @@ func (c *ConvolutionD) Forward(
 	algo ConvFwdAlgo,
 	wspace cutil.Mem, wspacesize uint,
 	beta float64,
-	yD *TensorD, y cutil.Mem) error {
+	yD *TensorD, y cutil.Mem) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(c *ConvolutionD) Forward", []fmt.Stringer{c, xD, wD, yD},
+					nonfiniteoutput{name: "y", desc: yD, mem: y},
+				)
+			}
+		}()
+	}
 	a := cscalarbydatatype(yD.dtype, alpha)
 	b := cscalarbydatatype(yD.dtype, beta)
-	var err error
 	if handle.w != nil {
 		err = handle.w.Work(func() error {
 			if wspace == nil {
 
 				return Status(C.cudnnConvolutionForward(handle.x, a.CPtr(), xD.descriptor, x.Ptr(), wD.descriptor, w.Ptr(),
-					c.descriptor, algo.c(), nil, C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).error("(c *ConvolutionD) Forward")
+					c.descriptor, algo.c(), nil, C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).errorDesc("(c *ConvolutionD) Forward", c, xD, wD, yD)
 			}
 
 			return Status(C.cudnnConvolutionForward(handle.x, a.CPtr(), xD.descriptor, x.Ptr(), wD.descriptor, w.Ptr(),
-				c.descriptor, algo.c(), wspace.Ptr(), C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).error("(c *ConvolutionD) Forward")
+				c.descriptor, algo.c(), wspace.Ptr(), C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).errorDesc("(c *ConvolutionD) Forward", c, xD, wD, yD)
 		})
 	} else {
 		if wspace == nil {
 
 			return Status(C.cudnnConvolutionForward(handle.x, a.CPtr(), xD.descriptor, x.Ptr(), wD.descriptor, w.Ptr(),
-				c.descriptor, algo.c(), nil, C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).error("(c *ConvolutionD) Forward")
+				c.descriptor, algo.c(), nil, C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).errorDesc("(c *ConvolutionD) Forward", c, xD, wD, yD)
 		}
 
 		return Status(C.cudnnConvolutionForward(handle.x, a.CPtr(), xD.descriptor, x.Ptr(), wD.descriptor, w.Ptr(),
-			c.descriptor, algo.c(), wspace.Ptr(), C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).error("(c *ConvolutionD) Forward")
+			c.descriptor, algo.c(), wspace.Ptr(), C.size_t(wspacesize), b.CPtr(), yD.descriptor, y.Ptr())).errorDesc("(c *ConvolutionD) Forward", c, xD, wD, yD)
 	}
 
 	if cudnndebugmode {
@@ -860,7 +894,16 @@ func (c *ConvolutionD) BiasActivationForward(
 	biasD *TensorD, bias cutil.Mem,
 	aD *ActivationD,
 	yD *TensorD, y cutil.Mem,
-) error {
+) (err error) {
+	if NonFiniteCheck() {
+		defer func() {
+			if err == nil {
+				err = handle.checknonfinite("(c *ConvolutionD) BiasActivationForward", []fmt.Stringer{c, xD, wD, zD, biasD, aD, yD},
+					nonfiniteoutput{name: "y", desc: yD, mem: y},
+				)
+			}
+		}()
+	}
 	a1 := cscalarbydatatype(yD.dtype, alpha1)
 	a2 := cscalarbydatatype(yD.dtype, alpha2)
 	if handle.w != nil {
@@ -887,7 +930,7 @@ func (c *ConvolutionD) BiasActivationForward(
 						aD.descriptor,
 						yD.descriptor,
 						y.Ptr(),
-					)).error("(c *ConvolutionD) BiasActivationForward")
+					)).errorDesc("(c *ConvolutionD) BiasActivationForward", c, xD, wD, zD, biasD, aD, yD)
 			}
 
 			return Status(
@@ -910,7 +953,7 @@ func (c *ConvolutionD) BiasActivationForward(
 					aD.descriptor,
 					yD.descriptor,
 					y.Ptr(),
-				)).error("(c *ConvolutionD) BiasActivationForward")
+				)).errorDesc("(c *ConvolutionD) BiasActivationForward", c, xD, wD, zD, biasD, aD, yD)
 		})
 	}
 	if wspace == nil {
@@ -935,7 +978,7 @@ func (c *ConvolutionD) BiasActivationForward(
 				aD.descriptor,
 				yD.descriptor,
 				y.Ptr(),
-			)).error("(c *ConvolutionD) BiasActivationForward")
+			)).errorDesc("(c *ConvolutionD) BiasActivationForward", c, xD, wD, zD, biasD, aD, yD)
 	}
 
 	return Status(
@@ -958,7 +1001,7 @@ func (c *ConvolutionD) BiasActivationForward(
 			aD.descriptor,
 			yD.descriptor,
 			y.Ptr(),
-		)).error("(c *ConvolutionD) BiasActivationForward")
+		)).errorDesc("(c *ConvolutionD) BiasActivationForward", c, xD, wD, zD, biasD, aD, yD)
 }
 
 //BiasActivationForwardUS is like BiasActivationForward but using unsafe.Pointer instead of cutil.Mem
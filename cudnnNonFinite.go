@@ -0,0 +1,118 @@
+package gocudnn
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dereklstinson/cutil"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+//nonfinitemaxcoords is the max number of coords of bad values kept in a NonFiniteError
+const nonfinitemaxcoords = 10
+
+var nonfinitecheck int32
+
+//SetNonFiniteCheck turns the NaN/Inf check mode on or off.  It is off by default.
+//
+//When it is on, convolution, activation and batch norm methods (and the xtra trainer and loss) will sync the stream,
+//copy their outputs to the host and scan them for NaN and Inf after they run.
+//The first op that writes a bad value returns a *NonFiniteError.  This is really slow. Only use it for debugging.
+func SetNonFiniteCheck(on bool) {
+	if on {
+		atomic.StoreInt32(&nonfinitecheck, 1)
+		return
+	}
+	atomic.StoreInt32(&nonfinitecheck, 0)
+}
+
+//NonFiniteCheck returns true if the NaN/Inf check mode is on.
+func NonFiniteCheck() bool {
+	return atomic.LoadInt32(&nonfinitecheck) == 1
+}
+
+//NonFiniteError is returned by ops when the NaN/Inf check mode is on and an output holds NaN or Inf values.
+type NonFiniteError struct {
+	Op        string   //Method that wrote the output
+	Output    string   //Name of the output
+	Descs     []string //Descriptors that were passed to the op
+	NonFinite tensorutil.NonFinite
+}
+
+func (e *NonFiniteError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: output %s: %v", e.Op, e.Output, e.NonFinite)
+	for i := range e.Descs {
+		b.WriteString("\n")
+		b.WriteString(e.Descs[i])
+	}
+	return b.String()
+}
+
+//nonfiniteoutput is an output of an op that is scanned by the check mode.
+type nonfiniteoutput struct {
+	name string
+	desc hosttensor
+	mem  cutil.Pointer
+}
+
+//checknonfinite scans the outputs of op using the handle's stream.  It should be called after the op returns without error.
+func (handle *Handle) checknonfinite(op string, descs []fmt.Stringer, outputs ...nonfiniteoutput) error {
+	s, err := handle.GetStream()
+	if err != nil {
+		return err
+	}
+	if handle.w != nil {
+		return handle.w.Work(func() error {
+			return checknonfinite(s, op, descs, outputs...)
+		})
+	}
+	return checknonfinite(s, op, descs, outputs...)
+}
+
+//CheckNonFinite syncs s, copies t to the host and scans it for NaN and Inf. s can be nil.
+//It returns a *NonFiniteError if any are found.
+//
+//This is what the check mode uses after each op.  It is exported so that packages like xtra can check their own outputs.
+func CheckNonFinite(s gocu.Streamer, op, output string, tD *TensorD, t cutil.Pointer, descs ...fmt.Stringer) error {
+	if tD == nil {
+		return fmt.Errorf("%s: checking output %s: nil TensorD", op, output)
+	}
+	return checknonfinite(s, op, descs, nonfiniteoutput{name: output, desc: tD, mem: t})
+}
+
+func checknonfinite(s gocu.Streamer, op string, descs []fmt.Stringer, outputs ...nonfiniteoutput) error {
+	if s != nil {
+		if err := s.Sync(); err != nil {
+			return err
+		}
+	}
+	var kind cudart.MemcpyKind
+	for _, out := range outputs {
+		if out.mem == nil {
+			continue
+		}
+		d, data, err := copytensortohost(out.desc, out.mem, kind.Default())
+		if err != nil {
+			return fmt.Errorf("%s: checking output %s: %v", op, out.name, err)
+		}
+		r, err := tensorutil.ScanNonFinite(d, data, nonfinitemaxcoords)
+		if err != nil {
+			return fmt.Errorf("%s: checking output %s: %v", op, out.name, err)
+		}
+		if r.OK() {
+			continue
+		}
+		nerr := &NonFiniteError{Op: op, Output: out.name, NonFinite: r}
+		for i := range descs {
+			if descs[i] != nil {
+				nerr.Descs = append(nerr.Descs, descs[i].String())
+			}
+		}
+		return nerr
+	}
+	return nil
+}
@@ -6,7 +6,10 @@ package gocudnn
 import "C"
 import (
 	"errors"
+	"fmt"
 	"strings"
+
+	"github.com/negativeOne1/gocudnn/cuerr"
 )
 
 //Status is the status of the cuda dnn
@@ -22,13 +25,20 @@ func (status Status) String() string {
 	return "Cudnn Status: " + C.GoString(response)
 }
 
-//Error will return the error string if there was an error. If not it will return nil
+//Error will return a *cuerr.Error if there was an error. If not it will return nil
+//If a log ring was set with SetLogRing the records in it are attached to the error.
 func (status Status) error(comment string) error {
+	return status.errorDesc(comment)
+}
+
+//errorDesc is like error but adds the descriptors that were passed to the op to the error.
+//The descriptors are only formatted if there was an error.
+func (status Status) errorDesc(comment string, descs ...fmt.Stringer) error {
 	if C.cudnnStatus_t(status) == C.CUDNN_STATUS_SUCCESS {
 		return nil
 	}
-	x := comment + ":"
-	return errors.New(x + status.String())
+	err := cuerr.NewMessage(cuerr.Cudnn, int(status), comment, C.GoString(C.cudnnGetErrorString(C.cudnnStatus_t(status))))
+	return attachlog(cuerr.WithDesc(err, descs...))
 }
 
 func (status Status) c() C.cudnnStatus_t {
@@ -40,11 +50,16 @@ func (status Status) Error(comment string) error {
 
 //WrapErrorWithStatus  if the error string contains a cudnnStatus_t string then it will return the Status and nil,
 // if it doens't the Status will be the flag for   CUDNN_STATUS_RUNTIME_FP_OVERFLOW but the error will not return a nil
+//
+//Errors returned by gocudnn are *cuerr.Error so errors.As or StatusOf can be used instead.
 func WrapErrorWithStatus(e error) (Status, error) {
 	if e == nil {
 		return Status(C.CUDNN_STATUS_SUCCESS), nil
 
 	}
+	if s, ok := StatusOf(e); ok {
+		return s, nil
+	}
 	x := e.Error()
 	switch {
 	case strings.Contains(x, "CUDNN_STATUS_NOT_INITIALIZED"):
@@ -76,3 +91,12 @@ func WrapErrorWithStatus(e error) (Status, error) {
 	}
 
 }
+
+//StatusOf returns the Status held by err if err is or wraps a cudnn *cuerr.Error.
+func StatusOf(err error) (Status, bool) {
+	lib, code, ok := cuerr.CodeOf(err)
+	if !ok || lib != cuerr.Cudnn {
+		return StatusSuccess, false
+	}
+	return Status(code), true
+}
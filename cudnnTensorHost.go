@@ -0,0 +1,108 @@
+package gocudnn
+
+import (
+	"errors"
+
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+//HostDesc returns a tensorutil.Desc that describes the tensor once it has been copied to the host.
+func (t *TensorD) HostDesc() (tensorutil.Desc, error) {
+	frmt, dtype, shape, stride, err := t.Get()
+	if err != nil {
+		return tensorutil.Desc{}, err
+	}
+	var d tensorutil.Desc
+	d.Dims = shape
+	fflg := frmt
+	dflg := dtype
+	switch frmt {
+	case fflg.NCHW():
+		d.Layout = tensorutil.NCHW
+	case fflg.NHWC():
+		d.Layout = tensorutil.NHWC
+	case fflg.NCHWvectC():
+		d.Layout = tensorutil.NCHWVectC
+		switch dtype {
+		case dflg.Int8x32():
+			d.Vect = 32
+		default:
+			d.Vect = 4
+		}
+	default:
+		d.Layout = tensorutil.Strided
+		d.Strides = stride
+	}
+	return d, d.Validate()
+}
+
+//HostDesc returns a tensorutil.Desc that describes the filter once it has been copied to the host.
+//The dims are in the same order that Get returns them.
+func (f *FilterD) HostDesc() (tensorutil.Desc, error) {
+	dtype, frmt, shape, err := f.Get()
+	if err != nil {
+		return tensorutil.Desc{}, err
+	}
+	d := tensorutil.Desc{Dims: shape}
+	fflg := frmt
+	dflg := dtype
+	switch frmt {
+	case fflg.NHWC():
+		d.Layout = tensorutil.NHWC
+	case fflg.NCHWvectC():
+		d.Layout = tensorutil.NCHWVectC
+		switch dtype {
+		case dflg.Int8x32():
+			d.Vect = 32
+		default:
+			d.Vect = 4
+		}
+	default:
+		d.Layout = tensorutil.NCHW
+	}
+	return d, d.Validate()
+}
+
+//HostDataType returns the tensorutil.DataType for the elements of the tensor once it has been copied to the host.
+//Vectorized types return the type of a single element.
+func (t *TensorD) HostDataType() (tensorutil.DataType, error) {
+	return hostdatatype(t.dtype)
+}
+
+//HostDataType returns the tensorutil.DataType for the elements of the filter once it has been copied to the host.
+func (f *FilterD) HostDataType() (tensorutil.DataType, error) {
+	dtype, _, _, err := f.Get()
+	if err != nil {
+		return 0, err
+	}
+	return hostdatatype(dtype)
+}
+
+func hostdatatype(dtype DataType) (tensorutil.DataType, error) {
+	dflg := dtype
+	switch dtype {
+	case dflg.Float():
+		return tensorutil.Float, nil
+	case dflg.Double():
+		return tensorutil.Double, nil
+	case dflg.Half():
+		return tensorutil.Half, nil
+	case dflg.Int8(), dflg.Int8x4(), dflg.Int8x32():
+		return tensorutil.Int8, nil
+	case dflg.Int32():
+		return tensorutil.Int32, nil
+	case dflg.UInt8x4():
+		return tensorutil.UInt8, nil
+	}
+	return 0, errors.New("HostDataType: Unsupported DataType")
+}
+
+//CompareHost compares two host buffers that hold the tensor described by tD.
+//got and want need to be the go slice that matches the DataType of tD ([]float32 for Float, []half.Float16 for Half, etc.).
+func CompareHost(tD *TensorD, got, want interface{}, tol tensorutil.Tolerance) (*tensorutil.Report, error) {
+	d, err := tD.HostDesc()
+	if err != nil {
+		return nil, err
+	}
+	return tensorutil.Compare(d, got, want, tol)
+}
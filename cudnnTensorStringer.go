@@ -1,151 +1,96 @@
 package gocudnn
 
 import (
-	"errors"
 	"fmt"
 
+	"github.com/dereklstinson/cutil"
 	"github.com/negativeOne1/gocudnn/cudart"
 	"github.com/negativeOne1/gocudnn/gocu"
-	"github.com/dereklstinson/half"
-
-	"github.com/dereklstinson/cutil"
+	"github.com/negativeOne1/gocudnn/tensorutil"
 )
 
 type memstringer struct {
 	td   *TensorD
 	t    cutil.Pointer
 	kind cudart.MemcpyKind
+	opts tensorutil.PrintOptions
 }
 
 func (m *memstringer) String() string {
-	frmt, dtype, dims, stride, err := m.td.Get()
+	d, data, err := copytensortohost(m.td, m.t, m.kind)
 	if err != nil {
-		return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Err in getting hidden Tensor Descriptor")
+		return fmt.Sprintf("Tensor Data: {\n%v\n}\n", err.Error())
 	}
-	sib, err := m.td.GetSizeInBytes()
+	s, err := tensorutil.Format(d, data, m.opts)
 	if err != nil {
-		return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Err in getting sib for hidden Tensor Descriptor")
+		return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Err in formatting ::"+err.Error())
 	}
-	length := findvolume(dims)
-	fflg := frmt
-	dflg := dtype
-	switch dtype {
-	case dflg.Float():
-		data := make([]float32, length)
-		hptr, err := gocu.MakeGoMem(data)
-
-		if err != nil {
-			return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Err in wrapping data::"+err.Error())
-		}
-		err = cudart.Memcpy(hptr, m.t, sib, m.kind)
-		if err != nil {
-			return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Err in copy to host ::"+err.Error())
-		}
-		if frmt == fflg.NCHW() {
-			return fmt.Sprintf("Tensor Data: {\n%v\n}\n", nchwtensorstringformated(dims, stride, data))
-		}
-		return fmt.Sprintf("Tensor Data: {\n%v\n}\n", nhwctensorstringformated(dims, stride, data))
-
-	case dflg.Half():
-		data := make([]half.Float16, length)
-		hptr, err := gocu.MakeGoMem(data)
-
-		if err != nil {
-			return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Err in wrapping data::"+err.Error())
-		}
-		err = cudart.Memcpy(hptr, m.t, sib, m.kind)
-		if err != nil {
-			return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Err in copy to host ::"+err.Error())
-		}
-		if frmt == fflg.NCHW() {
-			return fmt.Sprintf("Tensor Data: {\n%v\n}\n", nchwtensorstringformated(dims, stride, half.ToFloat32(data)))
-		}
-		return fmt.Sprintf("Tensor Data: {\n%v\n}\n", nhwctensorstringformated(dims, stride, half.ToFloat32(data)))
+	return fmt.Sprintf("Tensor Data: {\n%v\n}\n", s)
+}
 
-	default:
-		return fmt.Sprintf("Tensor Data: {\n%v\n}\n", "Unsupported DataType")
+//hosttensor is satisfied by *TensorD and *FilterD
+type hosttensor interface {
+	HostDesc() (tensorutil.Desc, error)
+	HostDataType() (tensorutil.DataType, error)
+	GetSizeInBytes() (uint, error)
+}
 
+//copytensortohost copies the memory that tD describes into a go slice that matches the DataType of tD.
+func copytensortohost(tD hosttensor, t cutil.Pointer, kind cudart.MemcpyKind) (tensorutil.Desc, interface{}, error) {
+	d, err := tD.HostDesc()
+	if err != nil {
+		return d, nil, fmt.Errorf("Err in getting hidden Tensor Descriptor ::%v", err)
+	}
+	dtype, err := tD.HostDataType()
+	if err != nil {
+		return d, nil, err
+	}
+	sib, err := tD.GetSizeInBytes()
+	if err != nil {
+		return d, nil, fmt.Errorf("Err in getting sib for hidden Tensor Descriptor ::%v", err)
+	}
+	length := int(sib) / dtype.SizeOf()
+	if span := d.Span(); length < span {
+		length = span
+		sib = uint(span * dtype.SizeOf())
+	}
+	data, err := tensorutil.MakeSlice(dtype, length)
+	if err != nil {
+		return d, nil, err
+	}
+	hptr, err := gocu.MakeGoMem(data)
+	if err != nil {
+		return d, nil, fmt.Errorf("Err in wrapping data ::%v", err)
+	}
+	err = cudart.Memcpy(hptr, t, sib, kind)
+	if err != nil {
+		return d, nil, fmt.Errorf("Err in copy to host ::%v", err)
 	}
+	return d, data, nil
 }
 
-//GetStringer returns a stringer that will pring cuda allocated memory formated in NHWC or NCHW.
-//Only works for 4d tensors with float or half datatype. It will only print the data.
+//GetStringer returns a stringer that will print cuda allocated memory.
+//It supports NCHW, NHWC, NCHWvectC and strided tensors of any number of dims,
+//with float, double, half, int8, int32 and uint8 datatypes.  It will only print the data.
+//Large tensors are summarized. Use GetStringerWithOptions to change that.
 func GetStringer(tD *TensorD, t cutil.Pointer) (fmt.Stringer, error) {
+	return GetStringerWithOptions(tD, t, tensorutil.PrintOptions{})
+}
 
-	frmt, dtype, _, _, err := tD.Get()
-	if err != nil {
-		return nil, err
+//GetStringerWithOptions is like GetStringer, but the precision, summarization and per channel statistics can be set with opts.
+func GetStringerWithOptions(tD *TensorD, t cutil.Pointer, opts tensorutil.PrintOptions) (fmt.Stringer, error) {
+	if _, err := tD.HostDesc(); err != nil {
+		return nil, fmt.Errorf(" GetStringer(tD *TensorD, t cutil.Pointer): %v", err)
 	}
-	fflg := frmt
-	if !(frmt == fflg.NCHW() || frmt == fflg.NHWC()) {
-		return nil, errors.New(" GetStringer(tD *TensorD, t cutil.Pointer): Unsuported Format")
-	}
-	dflg := dtype
-	if !(dtype == dflg.Float() || dtype == dflg.Half()) {
-		return nil, errors.New(" GetStringer(tD *TensorD, t cutil.Pointer): Unsupported Type")
+	if _, err := tD.HostDataType(); err != nil {
+		return nil, fmt.Errorf(" GetStringer(tD *TensorD, t cutil.Pointer): %v", err)
 	}
 	var kind cudart.MemcpyKind
 	return &memstringer{
 		td:   tD,
 		t:    t,
 		kind: kind.Default(),
+		opts: opts,
 	}, nil
 
 }
-func nhwctensorstringformated(dims, strides []int32, data []float32) string {
-
-	var s string
-	s = "\n"
-	for i := int32(0); i < dims[0]; i++ {
-		s = s + fmt.Sprintf("Batch[%v]{\n", i)
-		for j := int32(0); j < dims[1]; j++ {
-
-			for k := int32(0); k < dims[2]; k++ {
-				s = s + fmt.Sprintf("(%v,%v)[ ", j, k)
-				for l := int32(0); l < dims[3]; l++ {
-					val := data[i*strides[0]+j*strides[1]+k*strides[2]+l*strides[3]]
-					if val >= 0 {
-						s = s + fmt.Sprintf(" %.5f ", val)
-					} else {
-						s = s + fmt.Sprintf("%.5f ", val)
-					}
-
-				}
-				s = s + "], "
-
-			}
-			s = s + "\n"
-		}
-		s = s + "}\n"
-	}
-	return s
-}
-func nchwtensorstringformated(dims, strides []int32, data []float32) string {
-	//flg := t.Format()
-
-	var s string
-	s = "\n"
-	for i := int32(0); i < dims[0]; i++ {
-		s = s + fmt.Sprintf("Batch[%v]{\n", i)
-		for j := int32(0); j < dims[1]; j++ {
-			s = s + fmt.Sprintf("\tChannel[%v]{\n", j)
-			for k := int32(0); k < dims[2]; k++ {
-				s = s + "\t\t"
-				for l := int32(0); l < dims[3]; l++ {
-					val := data[i*strides[0]+j*strides[1]+k*strides[2]+l*strides[3]]
-					if val >= 0 {
-						s = s + fmt.Sprintf(" %.4f ", val)
-					} else {
-						s = s + fmt.Sprintf("%.4f ", val)
-					}
-
-				}
-				s = s + "\n"
-
-			}
-			s = s + "\t}\n"
-		}
-		s = s + "}\n"
-	}
-	return s
-}
@@ -0,0 +1,217 @@
+//Package cudnnlog holds the records that the cudnn callback sends and the sinks they can be sent to.
+//
+//gocudnn.SetLogSink turns the cudnn callback on and sends each message as a Record to a Sink.
+//Sinks can be chained.
+//
+//	ring := cudnnlog.NewRing(64)
+//	sink := cudnnlog.Filter(cudnnlog.Warning, cudnnlog.Tee(ring, cudnnlog.RateLimit(cudnnlog.Slog(slog.Default()), 10, time.Second)))
+//
+//It doesn't use cgo so the sinks can be tested without a gpu.
+package cudnnlog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Severity is the severity of a Record.  It has the same values as cudnnSeverity_t.
+//Lower values are more severe.
+type Severity int
+
+//Severities
+const (
+	Fatal Severity = iota
+	Error
+	Warning
+	Info
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Fatal:
+		return "FATAL"
+	case Error:
+		return "ERROR"
+	case Warning:
+		return "WARNING"
+	case Info:
+		return "INFO"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+//Level returns the slog.Level that matches s.  Fatal is one step above slog.LevelError.
+func (s Severity) Level() slog.Level {
+	switch s {
+	case Fatal:
+		return slog.LevelError + 4
+	case Error:
+		return slog.LevelError
+	case Warning:
+		return slog.LevelWarn
+	}
+	return slog.LevelInfo
+}
+
+//Record is a single message from the cudnn callback.
+type Record struct {
+	Severity Severity
+	Version  int           //cudnn version
+	Status   int           //cudnnStatus_t
+	Time     time.Time     //When cudnn made the message
+	Delta    time.Duration //Time since cudnn started
+	Received time.Time     //When the callback got the message
+	Handle   uintptr
+	Stream   uintptr
+	PID      uint64
+	TID      uint64
+	Device   int
+	Message  string
+	UserData string //String of the udata passed to SetLogSink
+}
+
+func (r Record) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s cudnn %d status %d device %d stream %#x pid %d tid %d: %s",
+		r.Time.Format(time.RFC3339Nano), r.Severity, r.Version, r.Status, r.Device, r.Stream, r.PID, r.TID, strings.TrimSpace(r.Message))
+	if r.UserData != "" {
+		fmt.Fprintf(&b, " (%s)", r.UserData)
+	}
+	return b.String()
+}
+
+//Sink gets records.  Log can be called from any thread that calls cudnn, so it needs to be safe for concurrent use.
+type Sink interface {
+	Log(r Record)
+}
+
+//SinkFunc is a func that is a Sink
+type SinkFunc func(r Record)
+
+//Log satisfies Sink
+func (f SinkFunc) Log(r Record) { f(r) }
+
+//Filter returns a Sink that only passes records that are at least as severe as min.
+func Filter(min Severity, s Sink) Sink {
+	return SinkFunc(func(r Record) {
+		if r.Severity <= min {
+			s.Log(r)
+		}
+	})
+}
+
+//Tee returns a Sink that sends each record to every sink.
+func Tee(sinks ...Sink) Sink {
+	return SinkFunc(func(r Record) {
+		for _, s := range sinks {
+			s.Log(r)
+		}
+	})
+}
+
+//WriterSink writes one line per record to an io.Writer.
+type WriterSink struct {
+	mu     sync.Mutex
+	w      io.Writer
+	failed int
+}
+
+//NewWriterSink returns a WriterSink that writes to w.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+//Log satisfies Sink.  Write errors are counted instead of returned since the callback has nowhere to return them to.
+func (w *WriterSink) Log(r Record) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := io.WriteString(w.w, r.String()+"\n"); err != nil {
+		w.failed++
+	}
+}
+
+//Failed returns the number of writes that failed.
+func (w *WriterSink) Failed() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.failed
+}
+
+//Slog returns a Sink that logs records to l at the level of their severity.
+func Slog(l *slog.Logger) Sink {
+	return SinkFunc(func(r Record) {
+		attrs := []slog.Attr{
+			slog.String("severity", r.Severity.String()),
+			slog.Int("cudnn_version", r.Version),
+			slog.Int("status", r.Status),
+			slog.Time("cudnn_time", r.Time),
+			slog.Duration("delta", r.Delta),
+			slog.Uint64("pid", r.PID),
+			slog.Uint64("tid", r.TID),
+			slog.Int("device", r.Device),
+			slog.String("stream", fmt.Sprintf("%#x", r.Stream)),
+		}
+		if r.UserData != "" {
+			attrs = append(attrs, slog.String("udata", r.UserData))
+		}
+		l.LogAttrs(context.Background(), r.Severity.Level(), strings.TrimSpace(r.Message), attrs...)
+	})
+}
+
+//Limiter is a Sink that passes at most burst records to its sink, and gets one more every interval.
+type Limiter struct {
+	mu       sync.Mutex
+	s        Sink
+	burst    int
+	every    time.Duration
+	tokens   int
+	last     time.Time
+	dropped  int
+	timefunc func() time.Time
+}
+
+//RateLimit returns a Limiter that sends records to s.
+func RateLimit(s Sink, burst int, every time.Duration) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{s: s, burst: burst, every: every, tokens: burst, timefunc: time.Now}
+}
+
+//Log satisfies Sink
+func (l *Limiter) Log(r Record) {
+	l.mu.Lock()
+	now := l.timefunc()
+	if l.last.IsZero() {
+		l.last = now
+	}
+	if l.every > 0 {
+		if n := int(now.Sub(l.last) / l.every); n > 0 {
+			l.tokens += n
+			if l.tokens > l.burst {
+				l.tokens = l.burst
+			}
+			l.last = l.last.Add(time.Duration(n) * l.every)
+		}
+	}
+	if l.tokens == 0 {
+		l.dropped++
+		l.mu.Unlock()
+		return
+	}
+	l.tokens--
+	l.mu.Unlock()
+	l.s.Log(r)
+}
+
+//Dropped returns the number of records that were dropped.
+func (l *Limiter) Dropped() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.dropped
+}
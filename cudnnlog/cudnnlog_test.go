@@ -0,0 +1,120 @@
+package cudnnlog
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func rec(sev Severity, msg string) Record {
+	return Record{Severity: sev, Version: 7605, Message: msg, Time: time.Unix(100, 0).UTC()}
+}
+
+func TestFilterTee(t *testing.T) {
+	a, b := NewRing(10), NewRing(10)
+	s := Tee(a, Filter(Warning, b))
+	for _, sev := range []Severity{Fatal, Error, Warning, Info} {
+		s.Log(rec(sev, sev.String()))
+	}
+	if len(a.Records()) != 4 {
+		t.Error(a.Records())
+	}
+	got := b.Records()
+	if len(got) != 3 || got[2].Severity != Warning {
+		t.Error(got)
+	}
+}
+
+func TestRing(t *testing.T) {
+	r := NewRing(3)
+	if r.Attach(nil) != nil {
+		t.Error("nil error")
+	}
+	plain := errors.New("bad param")
+	if r.Attach(plain) != plain {
+		t.Error("empty ring changed the error")
+	}
+	for _, m := range []string{"a", "b", "c", "d"} {
+		r.Log(rec(Error, m))
+	}
+	got := r.Records()
+	if len(got) != 3 || got[0].Message != "b" || got[2].Message != "d" {
+		t.Error(got)
+	}
+	err := r.Attach(plain)
+	if !errors.Is(err, plain) {
+		t.Error("errors.Is lost the error")
+	}
+	var rerr *RecordsError
+	if !errors.As(err, &rerr) || len(rerr.Records) != 3 || !strings.Contains(err.Error(), ": d") {
+		t.Error(err)
+	}
+	r.Reset()
+	if len(r.Records()) != 0 {
+		t.Error("Reset")
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	now := time.Unix(0, 0)
+	ring := NewRing(10)
+	l := RateLimit(ring, 2, time.Second)
+	l.timefunc = func() time.Time { return now }
+	for i := 0; i < 5; i++ {
+		l.Log(rec(Error, "x"))
+	}
+	if len(ring.Records()) != 2 || l.Dropped() != 3 {
+		t.Error(len(ring.Records()), l.Dropped())
+	}
+	now = now.Add(1500 * time.Millisecond)
+	l.Log(rec(Error, "y"))
+	l.Log(rec(Error, "z"))
+	if len(ring.Records()) != 3 || l.Dropped() != 4 {
+		t.Error(len(ring.Records()), l.Dropped())
+	}
+	//the half second left over counts toward the next token
+	now = now.Add(500 * time.Millisecond)
+	l.Log(rec(Error, "w"))
+	if len(ring.Records()) != 4 {
+		t.Error(len(ring.Records()))
+	}
+}
+
+type failwriter struct{}
+
+func (failwriter) Write(p []byte) (int, error) { return 0, errors.New("closed") }
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriterSink(&buf)
+	r := rec(Warning, "algo not supported\n")
+	r.UserData = "layer1"
+	w.Log(r)
+	want := "1970-01-01T00:01:40Z WARNING cudnn 7605 status 0 device 0 stream 0x0 pid 0 tid 0: algo not supported (layer1)\n"
+	if buf.String() != want {
+		t.Errorf("got %q want %q", buf.String(), want)
+	}
+	f := NewWriterSink(failwriter{})
+	f.Log(r)
+	if f.Failed() != 1 {
+		t.Error(f.Failed())
+	}
+}
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	l := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+	s := Slog(l)
+	s.Log(rec(Info, "dropped by the handler"))
+	s.Log(rec(Error, "bad param"))
+	out := buf.String()
+	if strings.Contains(out, "dropped") {
+		t.Error(out)
+	}
+	if !strings.Contains(out, "level=ERROR") || !strings.Contains(out, `msg="bad param"`) || !strings.Contains(out, "cudnn_version=7605") {
+		t.Error(out)
+	}
+}
@@ -0,0 +1,91 @@
+package cudnnlog
+
+import (
+	"strings"
+	"sync"
+)
+
+//Ring is a Sink that keeps the most recent records.
+type Ring struct {
+	mu    sync.Mutex
+	recs  []Record
+	next  int
+	count int
+}
+
+//NewRing returns a Ring that holds up to n records.
+func NewRing(n int) *Ring {
+	if n < 1 {
+		n = 1
+	}
+	return &Ring{recs: make([]Record, n)}
+}
+
+//Log satisfies Sink
+func (r *Ring) Log(rec Record) {
+	r.mu.Lock()
+	r.recs[r.next] = rec
+	r.next = (r.next + 1) % len(r.recs)
+	if r.count < len(r.recs) {
+		r.count++
+	}
+	r.mu.Unlock()
+}
+
+//Records returns a copy of the records in the ring from oldest to newest.
+func (r *Ring) Records() []Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	recs := make([]Record, 0, r.count)
+	start := r.next - r.count
+	if start < 0 {
+		start += len(r.recs)
+	}
+	for i := 0; i < r.count; i++ {
+		recs = append(recs, r.recs[(start+i)%len(r.recs)])
+	}
+	return recs
+}
+
+//Reset empties the ring.
+func (r *Ring) Reset() {
+	r.mu.Lock()
+	r.next, r.count = 0, 0
+	for i := range r.recs {
+		r.recs[i] = Record{}
+	}
+	r.mu.Unlock()
+}
+
+//Attach returns err with the records that are in the ring.  It returns err if err is nil or the ring is empty.
+func (r *Ring) Attach(err error) error {
+	if err == nil || r == nil {
+		return err
+	}
+	recs := r.Records()
+	if len(recs) == 0 {
+		return err
+	}
+	return &RecordsError{Err: err, Records: recs}
+}
+
+//RecordsError is an error with the records that were logged before it was returned.
+//errors.Is and errors.As see through it to Err.
+type RecordsError struct {
+	Err     error
+	Records []Record
+}
+
+func (e *RecordsError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Err.Error())
+	b.WriteString("\ncudnn log:")
+	for i := range e.Records {
+		b.WriteString("\n\t")
+		b.WriteString(e.Records[i].String())
+	}
+	return b.String()
+}
+
+//Unwrap returns Err
+func (e *RecordsError) Unwrap() error { return e.Err }
@@ -0,0 +1,24 @@
+//Package ccodes has the status codes of each library the way the installed C headers define them.
+//
+//It uses cgo, so it can't be part of cuerr.  Its tests check the tables of cuerr against the headers:
+//every name in the tables has to be a constant in the header with the same value, and every status in the header has to be in the tables.
+package ccodes
+
+/*
+#cgo CFLAGS: -I/opt/cuda/include -I/opt/cuda/targets/x86_64-linux/include
+*/
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+type header struct {
+	file string //header the type is defined in, or one that includes it
+	typ  string //name of the enum type
+}
+
+var codes = map[cuerr.Library]map[string]int{}
+var headers = map[cuerr.Library]header{}
+
+//Codes returns the value of each name that cuerr has for lib, as the C constant of that name.
+func Codes(lib cuerr.Library) map[string]int {
+	return codes[lib]
+}
@@ -0,0 +1,112 @@
+package ccodes
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/negativeOne1/gocudnn/cuerr"
+)
+
+var libraries = []cuerr.Library{cuerr.Cudnn, cuerr.Cudart, cuerr.Cuda, cuerr.Nvrtc, cuerr.Nvjpeg, cuerr.Curand, cuerr.Npp}
+
+//The constants are compiled from the headers, so a name that isn't in a header doesn't build, and a wrong value is found here.
+func TestTablesMatchHeaders(t *testing.T) {
+	for _, lib := range libraries {
+		c := Codes(lib)
+		if c == nil {
+			t.Errorf("%v: no codes", lib)
+			continue
+		}
+		table := cuerr.Codes(lib)
+		if len(table) != len(c) {
+			t.Errorf("%v: %d codes in cuerr, %d names", lib, len(table), len(c))
+		}
+		for _, code := range table {
+			name := cuerr.Name(lib, code)
+			v, ok := c[name]
+			if !ok {
+				t.Errorf("%v: %s is not checked against the header", lib, name)
+				continue
+			}
+			if v != code {
+				t.Errorf("%v: cuerr has %s = %d, the header has %d", lib, name, code, v)
+			}
+		}
+	}
+}
+
+//The values of a C enum can't be listed with cgo, so the enum is read from the header text to find statuses cuerr doesn't have.
+func TestHeadersHaveNoNewCodes(t *testing.T) {
+	for _, lib := range libraries {
+		h := headers[lib]
+		names, err := enumnames(h)
+		if err != nil {
+			t.Logf("%v: %v", lib, err)
+			continue
+		}
+		var missing []string
+		for _, n := range names {
+			if _, ok := codes[lib][n]; !ok {
+				missing = append(missing, n)
+			}
+		}
+		if len(missing) > 0 {
+			t.Errorf("%v: %s has statuses that aren't in cuerr: %v", lib, h.typ, missing)
+		}
+	}
+}
+
+var (
+	comments = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*`)
+	member   = regexp.MustCompile(`^\s*([A-Za-z_]\w*)\s*(?:=\s*(.+?))?\s*$`)
+	ident    = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+func includedirs() []string {
+	dirs := []string{"/opt/cuda/include", "/opt/cuda/targets/x86_64-linux/include", "/usr/local/cuda/include", "/usr/include"}
+	if p := os.Getenv("CUDA_PATH"); p != "" {
+		dirs = append([]string{filepath.Join(p, "include")}, dirs...)
+	}
+	return dirs
+}
+
+//enumnames finds the enum h.typ in any header of the include dir that has h.file, and returns the names of its members.
+func enumnames(h header) ([]string, error) {
+	//enum NAME { ... } for cudaError, and typedef enum ... { ... } NAME; for the rest.
+	named := regexp.MustCompile(`enum\s+(?:\w+\s+)*` + h.typ + `\s*\{([^}]*)\}`)
+	typedef := regexp.MustCompile(`enum\s*(?:\w+\s*)?\{([^}]*)\}\s*` + h.typ + `\s*;`)
+	for _, dir := range includedirs() {
+		if _, err := os.Stat(filepath.Join(dir, h.file)); err != nil {
+			continue
+		}
+		files, _ := filepath.Glob(filepath.Join(dir, "*.h"))
+		for _, f := range files {
+			b, err := os.ReadFile(f)
+			if err != nil {
+				continue
+			}
+			src := comments.ReplaceAllString(string(b), "")
+			m := typedef.FindStringSubmatch(src)
+			if m == nil {
+				m = named.FindStringSubmatch(src)
+			}
+			if m == nil {
+				continue
+			}
+			var names []string
+			for _, part := range strings.Split(m[1], ",") {
+				//Members set to another member, like NPP_SUCCESS = NPP_NO_ERROR, are other names for a status that is already there.
+				if mm := member.FindStringSubmatch(part); mm != nil && !ident.MatchString(mm[2]) {
+					names = append(names, mm[1])
+				}
+			}
+			sort.Strings(names)
+			return names, nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
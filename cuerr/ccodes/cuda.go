@@ -0,0 +1,92 @@
+package ccodes
+
+//#include <cuda.h>
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+//CUresult from cuda.h
+func init() {
+	codes[cuerr.Cuda] = map[string]int{
+		"CUDA_SUCCESS":                              int(C.CUDA_SUCCESS),
+		"CUDA_ERROR_INVALID_VALUE":                  int(C.CUDA_ERROR_INVALID_VALUE),
+		"CUDA_ERROR_OUT_OF_MEMORY":                  int(C.CUDA_ERROR_OUT_OF_MEMORY),
+		"CUDA_ERROR_NOT_INITIALIZED":                int(C.CUDA_ERROR_NOT_INITIALIZED),
+		"CUDA_ERROR_DEINITIALIZED":                  int(C.CUDA_ERROR_DEINITIALIZED),
+		"CUDA_ERROR_PROFILER_DISABLED":              int(C.CUDA_ERROR_PROFILER_DISABLED),
+		"CUDA_ERROR_PROFILER_NOT_INITIALIZED":       int(C.CUDA_ERROR_PROFILER_NOT_INITIALIZED),
+		"CUDA_ERROR_PROFILER_ALREADY_STARTED":       int(C.CUDA_ERROR_PROFILER_ALREADY_STARTED),
+		"CUDA_ERROR_PROFILER_ALREADY_STOPPED":       int(C.CUDA_ERROR_PROFILER_ALREADY_STOPPED),
+		"CUDA_ERROR_STUB_LIBRARY":                   int(C.CUDA_ERROR_STUB_LIBRARY),
+		"CUDA_ERROR_NO_DEVICE":                      int(C.CUDA_ERROR_NO_DEVICE),
+		"CUDA_ERROR_INVALID_DEVICE":                 int(C.CUDA_ERROR_INVALID_DEVICE),
+		"CUDA_ERROR_DEVICE_NOT_LICENSED":            int(C.CUDA_ERROR_DEVICE_NOT_LICENSED),
+		"CUDA_ERROR_INVALID_IMAGE":                  int(C.CUDA_ERROR_INVALID_IMAGE),
+		"CUDA_ERROR_INVALID_CONTEXT":                int(C.CUDA_ERROR_INVALID_CONTEXT),
+		"CUDA_ERROR_CONTEXT_ALREADY_CURRENT":        int(C.CUDA_ERROR_CONTEXT_ALREADY_CURRENT),
+		"CUDA_ERROR_MAP_FAILED":                     int(C.CUDA_ERROR_MAP_FAILED),
+		"CUDA_ERROR_UNMAP_FAILED":                   int(C.CUDA_ERROR_UNMAP_FAILED),
+		"CUDA_ERROR_ARRAY_IS_MAPPED":                int(C.CUDA_ERROR_ARRAY_IS_MAPPED),
+		"CUDA_ERROR_ALREADY_MAPPED":                 int(C.CUDA_ERROR_ALREADY_MAPPED),
+		"CUDA_ERROR_NO_BINARY_FOR_GPU":              int(C.CUDA_ERROR_NO_BINARY_FOR_GPU),
+		"CUDA_ERROR_ALREADY_ACQUIRED":               int(C.CUDA_ERROR_ALREADY_ACQUIRED),
+		"CUDA_ERROR_NOT_MAPPED":                     int(C.CUDA_ERROR_NOT_MAPPED),
+		"CUDA_ERROR_NOT_MAPPED_AS_ARRAY":            int(C.CUDA_ERROR_NOT_MAPPED_AS_ARRAY),
+		"CUDA_ERROR_NOT_MAPPED_AS_POINTER":          int(C.CUDA_ERROR_NOT_MAPPED_AS_POINTER),
+		"CUDA_ERROR_ECC_UNCORRECTABLE":              int(C.CUDA_ERROR_ECC_UNCORRECTABLE),
+		"CUDA_ERROR_UNSUPPORTED_LIMIT":              int(C.CUDA_ERROR_UNSUPPORTED_LIMIT),
+		"CUDA_ERROR_CONTEXT_ALREADY_IN_USE":         int(C.CUDA_ERROR_CONTEXT_ALREADY_IN_USE),
+		"CUDA_ERROR_PEER_ACCESS_UNSUPPORTED":        int(C.CUDA_ERROR_PEER_ACCESS_UNSUPPORTED),
+		"CUDA_ERROR_INVALID_PTX":                    int(C.CUDA_ERROR_INVALID_PTX),
+		"CUDA_ERROR_INVALID_GRAPHICS_CONTEXT":       int(C.CUDA_ERROR_INVALID_GRAPHICS_CONTEXT),
+		"CUDA_ERROR_NVLINK_UNCORRECTABLE":           int(C.CUDA_ERROR_NVLINK_UNCORRECTABLE),
+		"CUDA_ERROR_JIT_COMPILER_NOT_FOUND":         int(C.CUDA_ERROR_JIT_COMPILER_NOT_FOUND),
+		"CUDA_ERROR_UNSUPPORTED_PTX_VERSION":        int(C.CUDA_ERROR_UNSUPPORTED_PTX_VERSION),
+		"CUDA_ERROR_JIT_COMPILATION_DISABLED":       int(C.CUDA_ERROR_JIT_COMPILATION_DISABLED),
+		"CUDA_ERROR_INVALID_SOURCE":                 int(C.CUDA_ERROR_INVALID_SOURCE),
+		"CUDA_ERROR_FILE_NOT_FOUND":                 int(C.CUDA_ERROR_FILE_NOT_FOUND),
+		"CUDA_ERROR_SHARED_OBJECT_SYMBOL_NOT_FOUND": int(C.CUDA_ERROR_SHARED_OBJECT_SYMBOL_NOT_FOUND),
+		"CUDA_ERROR_SHARED_OBJECT_INIT_FAILED":      int(C.CUDA_ERROR_SHARED_OBJECT_INIT_FAILED),
+		"CUDA_ERROR_OPERATING_SYSTEM":               int(C.CUDA_ERROR_OPERATING_SYSTEM),
+		"CUDA_ERROR_INVALID_HANDLE":                 int(C.CUDA_ERROR_INVALID_HANDLE),
+		"CUDA_ERROR_ILLEGAL_STATE":                  int(C.CUDA_ERROR_ILLEGAL_STATE),
+		"CUDA_ERROR_NOT_FOUND":                      int(C.CUDA_ERROR_NOT_FOUND),
+		"CUDA_ERROR_NOT_READY":                      int(C.CUDA_ERROR_NOT_READY),
+		"CUDA_ERROR_ILLEGAL_ADDRESS":                int(C.CUDA_ERROR_ILLEGAL_ADDRESS),
+		"CUDA_ERROR_LAUNCH_OUT_OF_RESOURCES":        int(C.CUDA_ERROR_LAUNCH_OUT_OF_RESOURCES),
+		"CUDA_ERROR_LAUNCH_TIMEOUT":                 int(C.CUDA_ERROR_LAUNCH_TIMEOUT),
+		"CUDA_ERROR_LAUNCH_INCOMPATIBLE_TEXTURING":  int(C.CUDA_ERROR_LAUNCH_INCOMPATIBLE_TEXTURING),
+		"CUDA_ERROR_PEER_ACCESS_ALREADY_ENABLED":    int(C.CUDA_ERROR_PEER_ACCESS_ALREADY_ENABLED),
+		"CUDA_ERROR_PEER_ACCESS_NOT_ENABLED":        int(C.CUDA_ERROR_PEER_ACCESS_NOT_ENABLED),
+		"CUDA_ERROR_PRIMARY_CONTEXT_ACTIVE":         int(C.CUDA_ERROR_PRIMARY_CONTEXT_ACTIVE),
+		"CUDA_ERROR_CONTEXT_IS_DESTROYED":           int(C.CUDA_ERROR_CONTEXT_IS_DESTROYED),
+		"CUDA_ERROR_ASSERT":                         int(C.CUDA_ERROR_ASSERT),
+		"CUDA_ERROR_TOO_MANY_PEERS":                 int(C.CUDA_ERROR_TOO_MANY_PEERS),
+		"CUDA_ERROR_HOST_MEMORY_ALREADY_REGISTERED": int(C.CUDA_ERROR_HOST_MEMORY_ALREADY_REGISTERED),
+		"CUDA_ERROR_HOST_MEMORY_NOT_REGISTERED":     int(C.CUDA_ERROR_HOST_MEMORY_NOT_REGISTERED),
+		"CUDA_ERROR_HARDWARE_STACK_ERROR":           int(C.CUDA_ERROR_HARDWARE_STACK_ERROR),
+		"CUDA_ERROR_ILLEGAL_INSTRUCTION":            int(C.CUDA_ERROR_ILLEGAL_INSTRUCTION),
+		"CUDA_ERROR_MISALIGNED_ADDRESS":             int(C.CUDA_ERROR_MISALIGNED_ADDRESS),
+		"CUDA_ERROR_INVALID_ADDRESS_SPACE":          int(C.CUDA_ERROR_INVALID_ADDRESS_SPACE),
+		"CUDA_ERROR_INVALID_PC":                     int(C.CUDA_ERROR_INVALID_PC),
+		"CUDA_ERROR_LAUNCH_FAILED":                  int(C.CUDA_ERROR_LAUNCH_FAILED),
+		"CUDA_ERROR_COOPERATIVE_LAUNCH_TOO_LARGE":   int(C.CUDA_ERROR_COOPERATIVE_LAUNCH_TOO_LARGE),
+		"CUDA_ERROR_NOT_PERMITTED":                  int(C.CUDA_ERROR_NOT_PERMITTED),
+		"CUDA_ERROR_NOT_SUPPORTED":                  int(C.CUDA_ERROR_NOT_SUPPORTED),
+		"CUDA_ERROR_SYSTEM_NOT_READY":               int(C.CUDA_ERROR_SYSTEM_NOT_READY),
+		"CUDA_ERROR_SYSTEM_DRIVER_MISMATCH":         int(C.CUDA_ERROR_SYSTEM_DRIVER_MISMATCH),
+		"CUDA_ERROR_COMPAT_NOT_SUPPORTED_ON_DEVICE": int(C.CUDA_ERROR_COMPAT_NOT_SUPPORTED_ON_DEVICE),
+		"CUDA_ERROR_STREAM_CAPTURE_UNSUPPORTED":     int(C.CUDA_ERROR_STREAM_CAPTURE_UNSUPPORTED),
+		"CUDA_ERROR_STREAM_CAPTURE_INVALIDATED":     int(C.CUDA_ERROR_STREAM_CAPTURE_INVALIDATED),
+		"CUDA_ERROR_STREAM_CAPTURE_MERGE":           int(C.CUDA_ERROR_STREAM_CAPTURE_MERGE),
+		"CUDA_ERROR_STREAM_CAPTURE_UNMATCHED":       int(C.CUDA_ERROR_STREAM_CAPTURE_UNMATCHED),
+		"CUDA_ERROR_STREAM_CAPTURE_UNJOINED":        int(C.CUDA_ERROR_STREAM_CAPTURE_UNJOINED),
+		"CUDA_ERROR_STREAM_CAPTURE_ISOLATION":       int(C.CUDA_ERROR_STREAM_CAPTURE_ISOLATION),
+		"CUDA_ERROR_STREAM_CAPTURE_IMPLICIT":        int(C.CUDA_ERROR_STREAM_CAPTURE_IMPLICIT),
+		"CUDA_ERROR_CAPTURED_EVENT":                 int(C.CUDA_ERROR_CAPTURED_EVENT),
+		"CUDA_ERROR_STREAM_CAPTURE_WRONG_THREAD":    int(C.CUDA_ERROR_STREAM_CAPTURE_WRONG_THREAD),
+		"CUDA_ERROR_TIMEOUT":                        int(C.CUDA_ERROR_TIMEOUT),
+		"CUDA_ERROR_GRAPH_EXEC_UPDATE_FAILURE":      int(C.CUDA_ERROR_GRAPH_EXEC_UPDATE_FAILURE),
+		"CUDA_ERROR_UNKNOWN":                        int(C.CUDA_ERROR_UNKNOWN),
+	}
+	headers[cuerr.Cuda] = header{"cuda.h", "CUresult"}
+}
@@ -0,0 +1,128 @@
+package ccodes
+
+//#include <cuda_runtime_api.h>
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+//cudaError_t from driver_types.h, which cuda_runtime_api.h includes
+func init() {
+	codes[cuerr.Cudart] = map[string]int{
+		"cudaSuccess":                             int(C.cudaSuccess),
+		"cudaErrorInvalidValue":                   int(C.cudaErrorInvalidValue),
+		"cudaErrorMemoryAllocation":               int(C.cudaErrorMemoryAllocation),
+		"cudaErrorInitializationError":            int(C.cudaErrorInitializationError),
+		"cudaErrorCudartUnloading":                int(C.cudaErrorCudartUnloading),
+		"cudaErrorProfilerDisabled":               int(C.cudaErrorProfilerDisabled),
+		"cudaErrorProfilerNotInitialized":         int(C.cudaErrorProfilerNotInitialized),
+		"cudaErrorProfilerAlreadyStarted":         int(C.cudaErrorProfilerAlreadyStarted),
+		"cudaErrorProfilerAlreadyStopped":         int(C.cudaErrorProfilerAlreadyStopped),
+		"cudaErrorInvalidConfiguration":           int(C.cudaErrorInvalidConfiguration),
+		"cudaErrorInvalidPitchValue":              int(C.cudaErrorInvalidPitchValue),
+		"cudaErrorInvalidSymbol":                  int(C.cudaErrorInvalidSymbol),
+		"cudaErrorInvalidHostPointer":             int(C.cudaErrorInvalidHostPointer),
+		"cudaErrorInvalidDevicePointer":           int(C.cudaErrorInvalidDevicePointer),
+		"cudaErrorInvalidTexture":                 int(C.cudaErrorInvalidTexture),
+		"cudaErrorInvalidTextureBinding":          int(C.cudaErrorInvalidTextureBinding),
+		"cudaErrorInvalidChannelDescriptor":       int(C.cudaErrorInvalidChannelDescriptor),
+		"cudaErrorInvalidMemcpyDirection":         int(C.cudaErrorInvalidMemcpyDirection),
+		"cudaErrorAddressOfConstant":              int(C.cudaErrorAddressOfConstant),
+		"cudaErrorTextureFetchFailed":             int(C.cudaErrorTextureFetchFailed),
+		"cudaErrorTextureNotBound":                int(C.cudaErrorTextureNotBound),
+		"cudaErrorSynchronizationError":           int(C.cudaErrorSynchronizationError),
+		"cudaErrorInvalidFilterSetting":           int(C.cudaErrorInvalidFilterSetting),
+		"cudaErrorInvalidNormSetting":             int(C.cudaErrorInvalidNormSetting),
+		"cudaErrorMixedDeviceExecution":           int(C.cudaErrorMixedDeviceExecution),
+		"cudaErrorNotYetImplemented":              int(C.cudaErrorNotYetImplemented),
+		"cudaErrorMemoryValueTooLarge":            int(C.cudaErrorMemoryValueTooLarge),
+		"cudaErrorStubLibrary":                    int(C.cudaErrorStubLibrary),
+		"cudaErrorInsufficientDriver":             int(C.cudaErrorInsufficientDriver),
+		"cudaErrorCallRequiresNewerDriver":        int(C.cudaErrorCallRequiresNewerDriver),
+		"cudaErrorInvalidSurface":                 int(C.cudaErrorInvalidSurface),
+		"cudaErrorDuplicateVariableName":          int(C.cudaErrorDuplicateVariableName),
+		"cudaErrorDuplicateTextureName":           int(C.cudaErrorDuplicateTextureName),
+		"cudaErrorDuplicateSurfaceName":           int(C.cudaErrorDuplicateSurfaceName),
+		"cudaErrorDevicesUnavailable":             int(C.cudaErrorDevicesUnavailable),
+		"cudaErrorIncompatibleDriverContext":      int(C.cudaErrorIncompatibleDriverContext),
+		"cudaErrorMissingConfiguration":           int(C.cudaErrorMissingConfiguration),
+		"cudaErrorPriorLaunchFailure":             int(C.cudaErrorPriorLaunchFailure),
+		"cudaErrorLaunchMaxDepthExceeded":         int(C.cudaErrorLaunchMaxDepthExceeded),
+		"cudaErrorLaunchFileScopedTex":            int(C.cudaErrorLaunchFileScopedTex),
+		"cudaErrorLaunchFileScopedSurf":           int(C.cudaErrorLaunchFileScopedSurf),
+		"cudaErrorSyncDepthExceeded":              int(C.cudaErrorSyncDepthExceeded),
+		"cudaErrorLaunchPendingCountExceeded":     int(C.cudaErrorLaunchPendingCountExceeded),
+		"cudaErrorInvalidDeviceFunction":          int(C.cudaErrorInvalidDeviceFunction),
+		"cudaErrorNoDevice":                       int(C.cudaErrorNoDevice),
+		"cudaErrorInvalidDevice":                  int(C.cudaErrorInvalidDevice),
+		"cudaErrorDeviceNotLicensed":              int(C.cudaErrorDeviceNotLicensed),
+		"cudaErrorSoftwareValidityNotEstablished": int(C.cudaErrorSoftwareValidityNotEstablished),
+		"cudaErrorStartupFailure":                 int(C.cudaErrorStartupFailure),
+		"cudaErrorInvalidKernelImage":             int(C.cudaErrorInvalidKernelImage),
+		"cudaErrorDeviceUninitialized":            int(C.cudaErrorDeviceUninitialized),
+		"cudaErrorMapBufferObjectFailed":          int(C.cudaErrorMapBufferObjectFailed),
+		"cudaErrorUnmapBufferObjectFailed":        int(C.cudaErrorUnmapBufferObjectFailed),
+		"cudaErrorArrayIsMapped":                  int(C.cudaErrorArrayIsMapped),
+		"cudaErrorAlreadyMapped":                  int(C.cudaErrorAlreadyMapped),
+		"cudaErrorNoKernelImageForDevice":         int(C.cudaErrorNoKernelImageForDevice),
+		"cudaErrorAlreadyAcquired":                int(C.cudaErrorAlreadyAcquired),
+		"cudaErrorNotMapped":                      int(C.cudaErrorNotMapped),
+		"cudaErrorNotMappedAsArray":               int(C.cudaErrorNotMappedAsArray),
+		"cudaErrorNotMappedAsPointer":             int(C.cudaErrorNotMappedAsPointer),
+		"cudaErrorECCUncorrectable":               int(C.cudaErrorECCUncorrectable),
+		"cudaErrorUnsupportedLimit":               int(C.cudaErrorUnsupportedLimit),
+		"cudaErrorDeviceAlreadyInUse":             int(C.cudaErrorDeviceAlreadyInUse),
+		"cudaErrorPeerAccessUnsupported":          int(C.cudaErrorPeerAccessUnsupported),
+		"cudaErrorInvalidPtx":                     int(C.cudaErrorInvalidPtx),
+		"cudaErrorInvalidGraphicsContext":         int(C.cudaErrorInvalidGraphicsContext),
+		"cudaErrorNvlinkUncorrectable":            int(C.cudaErrorNvlinkUncorrectable),
+		"cudaErrorJitCompilerNotFound":            int(C.cudaErrorJitCompilerNotFound),
+		"cudaErrorUnsupportedPtxVersion":          int(C.cudaErrorUnsupportedPtxVersion),
+		"cudaErrorJitCompilationDisabled":         int(C.cudaErrorJitCompilationDisabled),
+		"cudaErrorInvalidSource":                  int(C.cudaErrorInvalidSource),
+		"cudaErrorFileNotFound":                   int(C.cudaErrorFileNotFound),
+		"cudaErrorSharedObjectSymbolNotFound":     int(C.cudaErrorSharedObjectSymbolNotFound),
+		"cudaErrorSharedObjectInitFailed":         int(C.cudaErrorSharedObjectInitFailed),
+		"cudaErrorOperatingSystem":                int(C.cudaErrorOperatingSystem),
+		"cudaErrorInvalidResourceHandle":          int(C.cudaErrorInvalidResourceHandle),
+		"cudaErrorIllegalState":                   int(C.cudaErrorIllegalState),
+		"cudaErrorSymbolNotFound":                 int(C.cudaErrorSymbolNotFound),
+		"cudaErrorNotReady":                       int(C.cudaErrorNotReady),
+		"cudaErrorIllegalAddress":                 int(C.cudaErrorIllegalAddress),
+		"cudaErrorLaunchOutOfResources":           int(C.cudaErrorLaunchOutOfResources),
+		"cudaErrorLaunchTimeout":                  int(C.cudaErrorLaunchTimeout),
+		"cudaErrorLaunchIncompatibleTexturing":    int(C.cudaErrorLaunchIncompatibleTexturing),
+		"cudaErrorPeerAccessAlreadyEnabled":       int(C.cudaErrorPeerAccessAlreadyEnabled),
+		"cudaErrorPeerAccessNotEnabled":           int(C.cudaErrorPeerAccessNotEnabled),
+		"cudaErrorSetOnActiveProcess":             int(C.cudaErrorSetOnActiveProcess),
+		"cudaErrorContextIsDestroyed":             int(C.cudaErrorContextIsDestroyed),
+		"cudaErrorAssert":                         int(C.cudaErrorAssert),
+		"cudaErrorTooManyPeers":                   int(C.cudaErrorTooManyPeers),
+		"cudaErrorHostMemoryAlreadyRegistered":    int(C.cudaErrorHostMemoryAlreadyRegistered),
+		"cudaErrorHostMemoryNotRegistered":        int(C.cudaErrorHostMemoryNotRegistered),
+		"cudaErrorHardwareStackError":             int(C.cudaErrorHardwareStackError),
+		"cudaErrorIllegalInstruction":             int(C.cudaErrorIllegalInstruction),
+		"cudaErrorMisalignedAddress":              int(C.cudaErrorMisalignedAddress),
+		"cudaErrorInvalidAddressSpace":            int(C.cudaErrorInvalidAddressSpace),
+		"cudaErrorInvalidPc":                      int(C.cudaErrorInvalidPc),
+		"cudaErrorLaunchFailure":                  int(C.cudaErrorLaunchFailure),
+		"cudaErrorCooperativeLaunchTooLarge":      int(C.cudaErrorCooperativeLaunchTooLarge),
+		"cudaErrorNotPermitted":                   int(C.cudaErrorNotPermitted),
+		"cudaErrorNotSupported":                   int(C.cudaErrorNotSupported),
+		"cudaErrorSystemNotReady":                 int(C.cudaErrorSystemNotReady),
+		"cudaErrorSystemDriverMismatch":           int(C.cudaErrorSystemDriverMismatch),
+		"cudaErrorCompatNotSupportedOnDevice":     int(C.cudaErrorCompatNotSupportedOnDevice),
+		"cudaErrorStreamCaptureUnsupported":       int(C.cudaErrorStreamCaptureUnsupported),
+		"cudaErrorStreamCaptureInvalidated":       int(C.cudaErrorStreamCaptureInvalidated),
+		"cudaErrorStreamCaptureMerge":             int(C.cudaErrorStreamCaptureMerge),
+		"cudaErrorStreamCaptureUnmatched":         int(C.cudaErrorStreamCaptureUnmatched),
+		"cudaErrorStreamCaptureUnjoined":          int(C.cudaErrorStreamCaptureUnjoined),
+		"cudaErrorStreamCaptureIsolation":         int(C.cudaErrorStreamCaptureIsolation),
+		"cudaErrorStreamCaptureImplicit":          int(C.cudaErrorStreamCaptureImplicit),
+		"cudaErrorCapturedEvent":                  int(C.cudaErrorCapturedEvent),
+		"cudaErrorStreamCaptureWrongThread":       int(C.cudaErrorStreamCaptureWrongThread),
+		"cudaErrorTimeout":                        int(C.cudaErrorTimeout),
+		"cudaErrorGraphExecUpdateFailure":         int(C.cudaErrorGraphExecUpdateFailure),
+		"cudaErrorUnknown":                        int(C.cudaErrorUnknown),
+		"cudaErrorApiFailureBase":                 int(C.cudaErrorApiFailureBase),
+	}
+	headers[cuerr.Cudart] = header{"cuda_runtime_api.h", "cudaError"}
+}
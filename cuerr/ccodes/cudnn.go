@@ -0,0 +1,27 @@
+package ccodes
+
+//#include <cudnn.h>
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+//cudnnStatus_t from cudnn.h
+func init() {
+	codes[cuerr.Cudnn] = map[string]int{
+		"CUDNN_STATUS_SUCCESS":                      int(C.CUDNN_STATUS_SUCCESS),
+		"CUDNN_STATUS_NOT_INITIALIZED":              int(C.CUDNN_STATUS_NOT_INITIALIZED),
+		"CUDNN_STATUS_ALLOC_FAILED":                 int(C.CUDNN_STATUS_ALLOC_FAILED),
+		"CUDNN_STATUS_BAD_PARAM":                    int(C.CUDNN_STATUS_BAD_PARAM),
+		"CUDNN_STATUS_INTERNAL_ERROR":               int(C.CUDNN_STATUS_INTERNAL_ERROR),
+		"CUDNN_STATUS_INVALID_VALUE":                int(C.CUDNN_STATUS_INVALID_VALUE),
+		"CUDNN_STATUS_ARCH_MISMATCH":                int(C.CUDNN_STATUS_ARCH_MISMATCH),
+		"CUDNN_STATUS_MAPPING_ERROR":                int(C.CUDNN_STATUS_MAPPING_ERROR),
+		"CUDNN_STATUS_EXECUTION_FAILED":             int(C.CUDNN_STATUS_EXECUTION_FAILED),
+		"CUDNN_STATUS_NOT_SUPPORTED":                int(C.CUDNN_STATUS_NOT_SUPPORTED),
+		"CUDNN_STATUS_LICENSE_ERROR":                int(C.CUDNN_STATUS_LICENSE_ERROR),
+		"CUDNN_STATUS_RUNTIME_PREREQUISITE_MISSING": int(C.CUDNN_STATUS_RUNTIME_PREREQUISITE_MISSING),
+		"CUDNN_STATUS_RUNTIME_IN_PROGRESS":          int(C.CUDNN_STATUS_RUNTIME_IN_PROGRESS),
+		"CUDNN_STATUS_RUNTIME_FP_OVERFLOW":          int(C.CUDNN_STATUS_RUNTIME_FP_OVERFLOW),
+		"CUDNN_STATUS_VERSION_MISMATCH":             int(C.CUDNN_STATUS_VERSION_MISMATCH),
+	}
+	headers[cuerr.Cudnn] = header{"cudnn.h", "cudnnStatus_t"}
+}
@@ -0,0 +1,25 @@
+package ccodes
+
+//#include <curand.h>
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+//curandStatus_t from curand.h
+func init() {
+	codes[cuerr.Curand] = map[string]int{
+		"CURAND_STATUS_SUCCESS":                   int(C.CURAND_STATUS_SUCCESS),
+		"CURAND_STATUS_VERSION_MISMATCH":          int(C.CURAND_STATUS_VERSION_MISMATCH),
+		"CURAND_STATUS_NOT_INITIALIZED":           int(C.CURAND_STATUS_NOT_INITIALIZED),
+		"CURAND_STATUS_ALLOCATION_FAILED":         int(C.CURAND_STATUS_ALLOCATION_FAILED),
+		"CURAND_STATUS_TYPE_ERROR":                int(C.CURAND_STATUS_TYPE_ERROR),
+		"CURAND_STATUS_OUT_OF_RANGE":              int(C.CURAND_STATUS_OUT_OF_RANGE),
+		"CURAND_STATUS_LENGTH_NOT_MULTIPLE":       int(C.CURAND_STATUS_LENGTH_NOT_MULTIPLE),
+		"CURAND_STATUS_DOUBLE_PRECISION_REQUIRED": int(C.CURAND_STATUS_DOUBLE_PRECISION_REQUIRED),
+		"CURAND_STATUS_LAUNCH_FAILURE":            int(C.CURAND_STATUS_LAUNCH_FAILURE),
+		"CURAND_STATUS_PREEXISTING_FAILURE":       int(C.CURAND_STATUS_PREEXISTING_FAILURE),
+		"CURAND_STATUS_INITIALIZATION_FAILED":     int(C.CURAND_STATUS_INITIALIZATION_FAILED),
+		"CURAND_STATUS_ARCH_MISMATCH":             int(C.CURAND_STATUS_ARCH_MISMATCH),
+		"CURAND_STATUS_INTERNAL_ERROR":            int(C.CURAND_STATUS_INTERNAL_ERROR),
+	}
+	headers[cuerr.Curand] = header{"curand.h", "curandStatus"}
+}
@@ -0,0 +1,77 @@
+package ccodes
+
+//#include <nppdefs.h>
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+//NppStatus from nppdefs.h
+func init() {
+	codes[cuerr.Npp] = map[string]int{
+		"NPP_NOT_SUPPORTED_MODE_ERROR":          int(C.NPP_NOT_SUPPORTED_MODE_ERROR),
+		"NPP_INVALID_HOST_POINTER_ERROR":        int(C.NPP_INVALID_HOST_POINTER_ERROR),
+		"NPP_INVALID_DEVICE_POINTER_ERROR":      int(C.NPP_INVALID_DEVICE_POINTER_ERROR),
+		"NPP_LUT_PALETTE_BITSIZE_ERROR":         int(C.NPP_LUT_PALETTE_BITSIZE_ERROR),
+		"NPP_ZC_MODE_NOT_SUPPORTED_ERROR":       int(C.NPP_ZC_MODE_NOT_SUPPORTED_ERROR),
+		"NPP_NOT_SUFFICIENT_COMPUTE_CAPABILITY": int(C.NPP_NOT_SUFFICIENT_COMPUTE_CAPABILITY),
+		"NPP_TEXTURE_BIND_ERROR":                int(C.NPP_TEXTURE_BIND_ERROR),
+		"NPP_WRONG_INTERSECTION_ROI_ERROR":      int(C.NPP_WRONG_INTERSECTION_ROI_ERROR),
+		"NPP_HAAR_CLASSIFIER_PIXEL_MATCH_ERROR": int(C.NPP_HAAR_CLASSIFIER_PIXEL_MATCH_ERROR),
+		"NPP_MEMFREE_ERROR":                     int(C.NPP_MEMFREE_ERROR),
+		"NPP_MEMSET_ERROR":                      int(C.NPP_MEMSET_ERROR),
+		"NPP_MEMCPY_ERROR":                      int(C.NPP_MEMCPY_ERROR),
+		"NPP_ALIGNMENT_ERROR":                   int(C.NPP_ALIGNMENT_ERROR),
+		"NPP_CUDA_KERNEL_EXECUTION_ERROR":       int(C.NPP_CUDA_KERNEL_EXECUTION_ERROR),
+		"NPP_ROUND_MODE_NOT_SUPPORTED_ERROR":    int(C.NPP_ROUND_MODE_NOT_SUPPORTED_ERROR),
+		"NPP_QUALITY_INDEX_ERROR":               int(C.NPP_QUALITY_INDEX_ERROR),
+		"NPP_RESIZE_NO_OPERATION_ERROR":         int(C.NPP_RESIZE_NO_OPERATION_ERROR),
+		"NPP_OVERFLOW_ERROR":                    int(C.NPP_OVERFLOW_ERROR),
+		"NPP_NOT_EVEN_STEP_ERROR":               int(C.NPP_NOT_EVEN_STEP_ERROR),
+		"NPP_HISTOGRAM_NUMBER_OF_LEVELS_ERROR":  int(C.NPP_HISTOGRAM_NUMBER_OF_LEVELS_ERROR),
+		"NPP_LUT_NUMBER_OF_LEVELS_ERROR":        int(C.NPP_LUT_NUMBER_OF_LEVELS_ERROR),
+		"NPP_CORRUPTED_DATA_ERROR":              int(C.NPP_CORRUPTED_DATA_ERROR),
+		"NPP_CHANNEL_ORDER_ERROR":               int(C.NPP_CHANNEL_ORDER_ERROR),
+		"NPP_ZERO_MASK_VALUE_ERROR":             int(C.NPP_ZERO_MASK_VALUE_ERROR),
+		"NPP_QUADRANGLE_ERROR":                  int(C.NPP_QUADRANGLE_ERROR),
+		"NPP_RECTANGLE_ERROR":                   int(C.NPP_RECTANGLE_ERROR),
+		"NPP_COEFFICIENT_ERROR":                 int(C.NPP_COEFFICIENT_ERROR),
+		"NPP_NUMBER_OF_CHANNELS_ERROR":          int(C.NPP_NUMBER_OF_CHANNELS_ERROR),
+		"NPP_COI_ERROR":                         int(C.NPP_COI_ERROR),
+		"NPP_DIVISOR_ERROR":                     int(C.NPP_DIVISOR_ERROR),
+		"NPP_CHANNEL_ERROR":                     int(C.NPP_CHANNEL_ERROR),
+		"NPP_STRIDE_ERROR":                      int(C.NPP_STRIDE_ERROR),
+		"NPP_ANCHOR_ERROR":                      int(C.NPP_ANCHOR_ERROR),
+		"NPP_MASK_SIZE_ERROR":                   int(C.NPP_MASK_SIZE_ERROR),
+		"NPP_RESIZE_FACTOR_ERROR":               int(C.NPP_RESIZE_FACTOR_ERROR),
+		"NPP_INTERPOLATION_ERROR":               int(C.NPP_INTERPOLATION_ERROR),
+		"NPP_MIRROR_FLIP_ERROR":                 int(C.NPP_MIRROR_FLIP_ERROR),
+		"NPP_MOMENT_00_ZERO_ERROR":              int(C.NPP_MOMENT_00_ZERO_ERROR),
+		"NPP_THRESHOLD_NEGATIVE_LEVEL_ERROR":    int(C.NPP_THRESHOLD_NEGATIVE_LEVEL_ERROR),
+		"NPP_THRESHOLD_ERROR":                   int(C.NPP_THRESHOLD_ERROR),
+		"NPP_CONTEXT_MATCH_ERROR":               int(C.NPP_CONTEXT_MATCH_ERROR),
+		"NPP_FFT_FLAG_ERROR":                    int(C.NPP_FFT_FLAG_ERROR),
+		"NPP_FFT_ORDER_ERROR":                   int(C.NPP_FFT_ORDER_ERROR),
+		"NPP_STEP_ERROR":                        int(C.NPP_STEP_ERROR),
+		"NPP_SCALE_RANGE_ERROR":                 int(C.NPP_SCALE_RANGE_ERROR),
+		"NPP_DATA_TYPE_ERROR":                   int(C.NPP_DATA_TYPE_ERROR),
+		"NPP_OUT_OFF_RANGE_ERROR":               int(C.NPP_OUT_OFF_RANGE_ERROR),
+		"NPP_DIVIDE_BY_ZERO_ERROR":              int(C.NPP_DIVIDE_BY_ZERO_ERROR),
+		"NPP_MEMORY_ALLOCATION_ERR":             int(C.NPP_MEMORY_ALLOCATION_ERR),
+		"NPP_NULL_POINTER_ERROR":                int(C.NPP_NULL_POINTER_ERROR),
+		"NPP_RANGE_ERROR":                       int(C.NPP_RANGE_ERROR),
+		"NPP_SIZE_ERROR":                        int(C.NPP_SIZE_ERROR),
+		"NPP_BAD_ARGUMENT_ERROR":                int(C.NPP_BAD_ARGUMENT_ERROR),
+		"NPP_NO_MEMORY_ERROR":                   int(C.NPP_NO_MEMORY_ERROR),
+		"NPP_NOT_IMPLEMENTED_ERROR":             int(C.NPP_NOT_IMPLEMENTED_ERROR),
+		"NPP_ERROR":                             int(C.NPP_ERROR),
+		"NPP_ERROR_RESERVED":                    int(C.NPP_ERROR_RESERVED),
+		"NPP_NO_ERROR":                          int(C.NPP_NO_ERROR),
+		"NPP_NO_OPERATION_WARNING":              int(C.NPP_NO_OPERATION_WARNING),
+		"NPP_DIVIDE_BY_ZERO_WARNING":            int(C.NPP_DIVIDE_BY_ZERO_WARNING),
+		"NPP_AFFINE_QUAD_INCORRECT_WARNING":     int(C.NPP_AFFINE_QUAD_INCORRECT_WARNING),
+		"NPP_WRONG_INTERSECTION_ROI_WARNING":    int(C.NPP_WRONG_INTERSECTION_ROI_WARNING),
+		"NPP_WRONG_INTERSECTION_QUAD_WARNING":   int(C.NPP_WRONG_INTERSECTION_QUAD_WARNING),
+		"NPP_DOUBLE_SIZE_WARNING":               int(C.NPP_DOUBLE_SIZE_WARNING),
+		"NPP_MISALIGNED_DST_ROI_WARNING":        int(C.NPP_MISALIGNED_DST_ROI_WARNING),
+	}
+	headers[cuerr.Npp] = header{"nppdefs.h", "NppStatus"}
+}
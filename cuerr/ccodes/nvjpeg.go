@@ -0,0 +1,22 @@
+package ccodes
+
+//#include <nvjpeg.h>
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+//nvjpegStatus_t from nvjpeg.h
+func init() {
+	codes[cuerr.Nvjpeg] = map[string]int{
+		"NVJPEG_STATUS_SUCCESS":                      int(C.NVJPEG_STATUS_SUCCESS),
+		"NVJPEG_STATUS_NOT_INITIALIZED":              int(C.NVJPEG_STATUS_NOT_INITIALIZED),
+		"NVJPEG_STATUS_INVALID_PARAMETER":            int(C.NVJPEG_STATUS_INVALID_PARAMETER),
+		"NVJPEG_STATUS_BAD_JPEG":                     int(C.NVJPEG_STATUS_BAD_JPEG),
+		"NVJPEG_STATUS_JPEG_NOT_SUPPORTED":           int(C.NVJPEG_STATUS_JPEG_NOT_SUPPORTED),
+		"NVJPEG_STATUS_ALLOCATOR_FAILURE":            int(C.NVJPEG_STATUS_ALLOCATOR_FAILURE),
+		"NVJPEG_STATUS_EXECUTION_FAILED":             int(C.NVJPEG_STATUS_EXECUTION_FAILED),
+		"NVJPEG_STATUS_ARCH_MISMATCH":                int(C.NVJPEG_STATUS_ARCH_MISMATCH),
+		"NVJPEG_STATUS_INTERNAL_ERROR":               int(C.NVJPEG_STATUS_INTERNAL_ERROR),
+		"NVJPEG_STATUS_IMPLEMENTATION_NOT_SUPPORTED": int(C.NVJPEG_STATUS_IMPLEMENTATION_NOT_SUPPORTED),
+	}
+	headers[cuerr.Nvjpeg] = header{"nvjpeg.h", "nvjpegStatus_t"}
+}
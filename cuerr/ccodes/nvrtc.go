@@ -0,0 +1,24 @@
+package ccodes
+
+//#include <nvrtc.h>
+import "C"
+import "github.com/negativeOne1/gocudnn/cuerr"
+
+//nvrtcResult from nvrtc.h
+func init() {
+	codes[cuerr.Nvrtc] = map[string]int{
+		"NVRTC_SUCCESS":                                     int(C.NVRTC_SUCCESS),
+		"NVRTC_ERROR_OUT_OF_MEMORY":                         int(C.NVRTC_ERROR_OUT_OF_MEMORY),
+		"NVRTC_ERROR_PROGRAM_CREATION_FAILURE":              int(C.NVRTC_ERROR_PROGRAM_CREATION_FAILURE),
+		"NVRTC_ERROR_INVALID_INPUT":                         int(C.NVRTC_ERROR_INVALID_INPUT),
+		"NVRTC_ERROR_INVALID_PROGRAM":                       int(C.NVRTC_ERROR_INVALID_PROGRAM),
+		"NVRTC_ERROR_INVALID_OPTION":                        int(C.NVRTC_ERROR_INVALID_OPTION),
+		"NVRTC_ERROR_COMPILATION":                           int(C.NVRTC_ERROR_COMPILATION),
+		"NVRTC_ERROR_BUILTIN_OPERATION_FAILURE":             int(C.NVRTC_ERROR_BUILTIN_OPERATION_FAILURE),
+		"NVRTC_ERROR_NO_NAME_EXPRESSIONS_AFTER_COMPILATION": int(C.NVRTC_ERROR_NO_NAME_EXPRESSIONS_AFTER_COMPILATION),
+		"NVRTC_ERROR_NO_LOWERED_NAMES_BEFORE_COMPILATION":   int(C.NVRTC_ERROR_NO_LOWERED_NAMES_BEFORE_COMPILATION),
+		"NVRTC_ERROR_NAME_EXPRESSION_NOT_VALID":             int(C.NVRTC_ERROR_NAME_EXPRESSION_NOT_VALID),
+		"NVRTC_ERROR_INTERNAL_ERROR":                        int(C.NVRTC_ERROR_INTERNAL_ERROR),
+	}
+	headers[cuerr.Nvrtc] = header{"nvrtc.h", "nvrtcResult"}
+}
@@ -0,0 +1,344 @@
+package cuerr
+
+type entry struct {
+	code     int
+	name     string
+	sentinel error
+}
+
+//tables hold every code of each library and the sentinel it maps to.
+//The values match the C headers and never change between versions, new codes only get added.  The tests of cuerr/ccodes check them against the installed headers.
+var tables = map[Library][]entry{
+	//cudnnStatus_t from cudnn.h
+	Cudnn: {
+		{0, "CUDNN_STATUS_SUCCESS", nil},
+		{1, "CUDNN_STATUS_NOT_INITIALIZED", ErrNotInitialized},
+		{2, "CUDNN_STATUS_ALLOC_FAILED", ErrAllocFailed},
+		{3, "CUDNN_STATUS_BAD_PARAM", ErrBadParam},
+		{4, "CUDNN_STATUS_INTERNAL_ERROR", ErrInternal},
+		{5, "CUDNN_STATUS_INVALID_VALUE", ErrBadParam},
+		{6, "CUDNN_STATUS_ARCH_MISMATCH", ErrArchMismatch},
+		{7, "CUDNN_STATUS_MAPPING_ERROR", ErrMapping},
+		{8, "CUDNN_STATUS_EXECUTION_FAILED", ErrExecutionFailed},
+		{9, "CUDNN_STATUS_NOT_SUPPORTED", ErrNotSupported},
+		{10, "CUDNN_STATUS_LICENSE_ERROR", ErrLicense},
+		{11, "CUDNN_STATUS_RUNTIME_PREREQUISITE_MISSING", ErrNotInitialized},
+		{12, "CUDNN_STATUS_RUNTIME_IN_PROGRESS", ErrNotReady},
+		{13, "CUDNN_STATUS_RUNTIME_FP_OVERFLOW", ErrBadData},
+		{14, "CUDNN_STATUS_VERSION_MISMATCH", ErrVersionMismatch},
+	},
+	//cudaError_t from driver_types.h
+	Cudart: {
+		{0, "cudaSuccess", nil},
+		{1, "cudaErrorInvalidValue", ErrBadParam},
+		{2, "cudaErrorMemoryAllocation", ErrAllocFailed},
+		{3, "cudaErrorInitializationError", ErrNotInitialized},
+		{4, "cudaErrorCudartUnloading", ErrNotInitialized},
+		{5, "cudaErrorProfilerDisabled", ErrNotPermitted},
+		{6, "cudaErrorProfilerNotInitialized", ErrNotInitialized},
+		{7, "cudaErrorProfilerAlreadyStarted", ErrNotPermitted},
+		{8, "cudaErrorProfilerAlreadyStopped", ErrNotPermitted},
+		{9, "cudaErrorInvalidConfiguration", ErrBadParam},
+		{12, "cudaErrorInvalidPitchValue", ErrBadParam},
+		{13, "cudaErrorInvalidSymbol", ErrBadParam},
+		{16, "cudaErrorInvalidHostPointer", ErrBadParam},
+		{17, "cudaErrorInvalidDevicePointer", ErrBadParam},
+		{18, "cudaErrorInvalidTexture", ErrBadParam},
+		{19, "cudaErrorInvalidTextureBinding", ErrBadParam},
+		{20, "cudaErrorInvalidChannelDescriptor", ErrBadParam},
+		{21, "cudaErrorInvalidMemcpyDirection", ErrBadParam},
+		{22, "cudaErrorAddressOfConstant", ErrNotSupported},
+		{23, "cudaErrorTextureFetchFailed", ErrExecutionFailed},
+		{24, "cudaErrorTextureNotBound", ErrExecutionFailed},
+		{25, "cudaErrorSynchronizationError", ErrExecutionFailed},
+		{26, "cudaErrorInvalidFilterSetting", ErrBadParam},
+		{27, "cudaErrorInvalidNormSetting", ErrBadParam},
+		{28, "cudaErrorMixedDeviceExecution", ErrNotSupported},
+		{31, "cudaErrorNotYetImplemented", ErrNotSupported},
+		{32, "cudaErrorMemoryValueTooLarge", ErrOutOfRange},
+		{34, "cudaErrorStubLibrary", ErrNotInitialized},
+		{35, "cudaErrorInsufficientDriver", ErrVersionMismatch},
+		{36, "cudaErrorCallRequiresNewerDriver", ErrVersionMismatch},
+		{37, "cudaErrorInvalidSurface", ErrBadParam},
+		{43, "cudaErrorDuplicateVariableName", ErrBadParam},
+		{44, "cudaErrorDuplicateTextureName", ErrBadParam},
+		{45, "cudaErrorDuplicateSurfaceName", ErrBadParam},
+		{46, "cudaErrorDevicesUnavailable", ErrInvalidDevice},
+		{49, "cudaErrorIncompatibleDriverContext", ErrVersionMismatch},
+		{52, "cudaErrorMissingConfiguration", ErrBadParam},
+		{53, "cudaErrorPriorLaunchFailure", ErrLaunchFailed},
+		{65, "cudaErrorLaunchMaxDepthExceeded", ErrLaunchFailed},
+		{66, "cudaErrorLaunchFileScopedTex", ErrNotSupported},
+		{67, "cudaErrorLaunchFileScopedSurf", ErrNotSupported},
+		{68, "cudaErrorSyncDepthExceeded", ErrLaunchFailed},
+		{69, "cudaErrorLaunchPendingCountExceeded", ErrLaunchFailed},
+		{98, "cudaErrorInvalidDeviceFunction", ErrInvalidImage},
+		{100, "cudaErrorNoDevice", ErrInvalidDevice},
+		{101, "cudaErrorInvalidDevice", ErrInvalidDevice},
+		{102, "cudaErrorDeviceNotLicensed", ErrLicense},
+		{103, "cudaErrorSoftwareValidityNotEstablished", ErrInternal},
+		{127, "cudaErrorStartupFailure", ErrNotInitialized},
+		{200, "cudaErrorInvalidKernelImage", ErrInvalidImage},
+		{201, "cudaErrorDeviceUninitialized", ErrInvalidHandle},
+		{205, "cudaErrorMapBufferObjectFailed", ErrMapping},
+		{206, "cudaErrorUnmapBufferObjectFailed", ErrMapping},
+		{207, "cudaErrorArrayIsMapped", ErrMapping},
+		{208, "cudaErrorAlreadyMapped", ErrMapping},
+		{209, "cudaErrorNoKernelImageForDevice", ErrInvalidImage},
+		{210, "cudaErrorAlreadyAcquired", ErrMapping},
+		{211, "cudaErrorNotMapped", ErrMapping},
+		{212, "cudaErrorNotMappedAsArray", ErrMapping},
+		{213, "cudaErrorNotMappedAsPointer", ErrMapping},
+		{214, "cudaErrorECCUncorrectable", ErrExecutionFailed},
+		{215, "cudaErrorUnsupportedLimit", ErrNotSupported},
+		{216, "cudaErrorDeviceAlreadyInUse", ErrNotPermitted},
+		{217, "cudaErrorPeerAccessUnsupported", ErrNotSupported},
+		{218, "cudaErrorInvalidPtx", ErrInvalidImage},
+		{219, "cudaErrorInvalidGraphicsContext", ErrInvalidHandle},
+		{220, "cudaErrorNvlinkUncorrectable", ErrExecutionFailed},
+		{221, "cudaErrorJitCompilerNotFound", ErrCompilation},
+		{222, "cudaErrorUnsupportedPtxVersion", ErrInvalidImage},
+		{223, "cudaErrorJitCompilationDisabled", ErrCompilation},
+		{300, "cudaErrorInvalidSource", ErrInvalidImage},
+		{301, "cudaErrorFileNotFound", ErrNotFound},
+		{302, "cudaErrorSharedObjectSymbolNotFound", ErrNotFound},
+		{303, "cudaErrorSharedObjectInitFailed", ErrNotInitialized},
+		{304, "cudaErrorOperatingSystem", ErrInternal},
+		{400, "cudaErrorInvalidResourceHandle", ErrInvalidHandle},
+		{401, "cudaErrorIllegalState", ErrNotPermitted},
+		{500, "cudaErrorSymbolNotFound", ErrNotFound},
+		{600, "cudaErrorNotReady", ErrNotReady},
+		{700, "cudaErrorIllegalAddress", ErrIllegalAddress},
+		{701, "cudaErrorLaunchOutOfResources", ErrLaunchFailed},
+		{702, "cudaErrorLaunchTimeout", ErrTimeout},
+		{703, "cudaErrorLaunchIncompatibleTexturing", ErrLaunchFailed},
+		{704, "cudaErrorPeerAccessAlreadyEnabled", ErrNotPermitted},
+		{705, "cudaErrorPeerAccessNotEnabled", ErrNotPermitted},
+		{708, "cudaErrorSetOnActiveProcess", ErrNotPermitted},
+		{709, "cudaErrorContextIsDestroyed", ErrInvalidHandle},
+		{710, "cudaErrorAssert", ErrExecutionFailed},
+		{711, "cudaErrorTooManyPeers", ErrNotSupported},
+		{712, "cudaErrorHostMemoryAlreadyRegistered", ErrNotPermitted},
+		{713, "cudaErrorHostMemoryNotRegistered", ErrNotPermitted},
+		{714, "cudaErrorHardwareStackError", ErrExecutionFailed},
+		{715, "cudaErrorIllegalInstruction", ErrExecutionFailed},
+		{716, "cudaErrorMisalignedAddress", ErrIllegalAddress},
+		{717, "cudaErrorInvalidAddressSpace", ErrIllegalAddress},
+		{718, "cudaErrorInvalidPc", ErrExecutionFailed},
+		{719, "cudaErrorLaunchFailure", ErrLaunchFailed},
+		{720, "cudaErrorCooperativeLaunchTooLarge", ErrLaunchFailed},
+		{800, "cudaErrorNotPermitted", ErrNotPermitted},
+		{801, "cudaErrorNotSupported", ErrNotSupported},
+		{802, "cudaErrorSystemNotReady", ErrNotReady},
+		{803, "cudaErrorSystemDriverMismatch", ErrVersionMismatch},
+		{804, "cudaErrorCompatNotSupportedOnDevice", ErrNotSupported},
+		{900, "cudaErrorStreamCaptureUnsupported", ErrStreamCapture},
+		{901, "cudaErrorStreamCaptureInvalidated", ErrStreamCapture},
+		{902, "cudaErrorStreamCaptureMerge", ErrStreamCapture},
+		{903, "cudaErrorStreamCaptureUnmatched", ErrStreamCapture},
+		{904, "cudaErrorStreamCaptureUnjoined", ErrStreamCapture},
+		{905, "cudaErrorStreamCaptureIsolation", ErrStreamCapture},
+		{906, "cudaErrorStreamCaptureImplicit", ErrStreamCapture},
+		{907, "cudaErrorCapturedEvent", ErrStreamCapture},
+		{908, "cudaErrorStreamCaptureWrongThread", ErrStreamCapture},
+		{909, "cudaErrorTimeout", ErrTimeout},
+		{910, "cudaErrorGraphExecUpdateFailure", ErrExecutionFailed},
+		{999, "cudaErrorUnknown", ErrUnknown},
+		{10000, "cudaErrorApiFailureBase", ErrInternal},
+	},
+	//CUresult from cuda.h
+	Cuda: {
+		{0, "CUDA_SUCCESS", nil},
+		{1, "CUDA_ERROR_INVALID_VALUE", ErrBadParam},
+		{2, "CUDA_ERROR_OUT_OF_MEMORY", ErrAllocFailed},
+		{3, "CUDA_ERROR_NOT_INITIALIZED", ErrNotInitialized},
+		{4, "CUDA_ERROR_DEINITIALIZED", ErrNotInitialized},
+		{5, "CUDA_ERROR_PROFILER_DISABLED", ErrNotPermitted},
+		{6, "CUDA_ERROR_PROFILER_NOT_INITIALIZED", ErrNotInitialized},
+		{7, "CUDA_ERROR_PROFILER_ALREADY_STARTED", ErrNotPermitted},
+		{8, "CUDA_ERROR_PROFILER_ALREADY_STOPPED", ErrNotPermitted},
+		{34, "CUDA_ERROR_STUB_LIBRARY", ErrNotInitialized},
+		{100, "CUDA_ERROR_NO_DEVICE", ErrInvalidDevice},
+		{101, "CUDA_ERROR_INVALID_DEVICE", ErrInvalidDevice},
+		{102, "CUDA_ERROR_DEVICE_NOT_LICENSED", ErrLicense},
+		{200, "CUDA_ERROR_INVALID_IMAGE", ErrInvalidImage},
+		{201, "CUDA_ERROR_INVALID_CONTEXT", ErrInvalidHandle},
+		{202, "CUDA_ERROR_CONTEXT_ALREADY_CURRENT", ErrNotPermitted},
+		{205, "CUDA_ERROR_MAP_FAILED", ErrMapping},
+		{206, "CUDA_ERROR_UNMAP_FAILED", ErrMapping},
+		{207, "CUDA_ERROR_ARRAY_IS_MAPPED", ErrMapping},
+		{208, "CUDA_ERROR_ALREADY_MAPPED", ErrMapping},
+		{209, "CUDA_ERROR_NO_BINARY_FOR_GPU", ErrInvalidImage},
+		{210, "CUDA_ERROR_ALREADY_ACQUIRED", ErrMapping},
+		{211, "CUDA_ERROR_NOT_MAPPED", ErrMapping},
+		{212, "CUDA_ERROR_NOT_MAPPED_AS_ARRAY", ErrMapping},
+		{213, "CUDA_ERROR_NOT_MAPPED_AS_POINTER", ErrMapping},
+		{214, "CUDA_ERROR_ECC_UNCORRECTABLE", ErrExecutionFailed},
+		{215, "CUDA_ERROR_UNSUPPORTED_LIMIT", ErrNotSupported},
+		{216, "CUDA_ERROR_CONTEXT_ALREADY_IN_USE", ErrNotPermitted},
+		{217, "CUDA_ERROR_PEER_ACCESS_UNSUPPORTED", ErrNotSupported},
+		{218, "CUDA_ERROR_INVALID_PTX", ErrInvalidImage},
+		{219, "CUDA_ERROR_INVALID_GRAPHICS_CONTEXT", ErrInvalidHandle},
+		{220, "CUDA_ERROR_NVLINK_UNCORRECTABLE", ErrExecutionFailed},
+		{221, "CUDA_ERROR_JIT_COMPILER_NOT_FOUND", ErrCompilation},
+		{222, "CUDA_ERROR_UNSUPPORTED_PTX_VERSION", ErrInvalidImage},
+		{223, "CUDA_ERROR_JIT_COMPILATION_DISABLED", ErrCompilation},
+		{300, "CUDA_ERROR_INVALID_SOURCE", ErrInvalidImage},
+		{301, "CUDA_ERROR_FILE_NOT_FOUND", ErrNotFound},
+		{302, "CUDA_ERROR_SHARED_OBJECT_SYMBOL_NOT_FOUND", ErrNotFound},
+		{303, "CUDA_ERROR_SHARED_OBJECT_INIT_FAILED", ErrNotInitialized},
+		{304, "CUDA_ERROR_OPERATING_SYSTEM", ErrInternal},
+		{400, "CUDA_ERROR_INVALID_HANDLE", ErrInvalidHandle},
+		{401, "CUDA_ERROR_ILLEGAL_STATE", ErrNotPermitted},
+		{500, "CUDA_ERROR_NOT_FOUND", ErrNotFound},
+		{600, "CUDA_ERROR_NOT_READY", ErrNotReady},
+		{700, "CUDA_ERROR_ILLEGAL_ADDRESS", ErrIllegalAddress},
+		{701, "CUDA_ERROR_LAUNCH_OUT_OF_RESOURCES", ErrLaunchFailed},
+		{702, "CUDA_ERROR_LAUNCH_TIMEOUT", ErrTimeout},
+		{703, "CUDA_ERROR_LAUNCH_INCOMPATIBLE_TEXTURING", ErrLaunchFailed},
+		{704, "CUDA_ERROR_PEER_ACCESS_ALREADY_ENABLED", ErrNotPermitted},
+		{705, "CUDA_ERROR_PEER_ACCESS_NOT_ENABLED", ErrNotPermitted},
+		{708, "CUDA_ERROR_PRIMARY_CONTEXT_ACTIVE", ErrNotPermitted},
+		{709, "CUDA_ERROR_CONTEXT_IS_DESTROYED", ErrInvalidHandle},
+		{710, "CUDA_ERROR_ASSERT", ErrExecutionFailed},
+		{711, "CUDA_ERROR_TOO_MANY_PEERS", ErrNotSupported},
+		{712, "CUDA_ERROR_HOST_MEMORY_ALREADY_REGISTERED", ErrNotPermitted},
+		{713, "CUDA_ERROR_HOST_MEMORY_NOT_REGISTERED", ErrNotPermitted},
+		{714, "CUDA_ERROR_HARDWARE_STACK_ERROR", ErrExecutionFailed},
+		{715, "CUDA_ERROR_ILLEGAL_INSTRUCTION", ErrExecutionFailed},
+		{716, "CUDA_ERROR_MISALIGNED_ADDRESS", ErrIllegalAddress},
+		{717, "CUDA_ERROR_INVALID_ADDRESS_SPACE", ErrIllegalAddress},
+		{718, "CUDA_ERROR_INVALID_PC", ErrExecutionFailed},
+		{719, "CUDA_ERROR_LAUNCH_FAILED", ErrLaunchFailed},
+		{720, "CUDA_ERROR_COOPERATIVE_LAUNCH_TOO_LARGE", ErrLaunchFailed},
+		{800, "CUDA_ERROR_NOT_PERMITTED", ErrNotPermitted},
+		{801, "CUDA_ERROR_NOT_SUPPORTED", ErrNotSupported},
+		{802, "CUDA_ERROR_SYSTEM_NOT_READY", ErrNotReady},
+		{803, "CUDA_ERROR_SYSTEM_DRIVER_MISMATCH", ErrVersionMismatch},
+		{804, "CUDA_ERROR_COMPAT_NOT_SUPPORTED_ON_DEVICE", ErrNotSupported},
+		{900, "CUDA_ERROR_STREAM_CAPTURE_UNSUPPORTED", ErrStreamCapture},
+		{901, "CUDA_ERROR_STREAM_CAPTURE_INVALIDATED", ErrStreamCapture},
+		{902, "CUDA_ERROR_STREAM_CAPTURE_MERGE", ErrStreamCapture},
+		{903, "CUDA_ERROR_STREAM_CAPTURE_UNMATCHED", ErrStreamCapture},
+		{904, "CUDA_ERROR_STREAM_CAPTURE_UNJOINED", ErrStreamCapture},
+		{905, "CUDA_ERROR_STREAM_CAPTURE_ISOLATION", ErrStreamCapture},
+		{906, "CUDA_ERROR_STREAM_CAPTURE_IMPLICIT", ErrStreamCapture},
+		{907, "CUDA_ERROR_CAPTURED_EVENT", ErrStreamCapture},
+		{908, "CUDA_ERROR_STREAM_CAPTURE_WRONG_THREAD", ErrStreamCapture},
+		{909, "CUDA_ERROR_TIMEOUT", ErrTimeout},
+		{910, "CUDA_ERROR_GRAPH_EXEC_UPDATE_FAILURE", ErrExecutionFailed},
+		{999, "CUDA_ERROR_UNKNOWN", ErrUnknown},
+	},
+	//nvrtcResult from nvrtc.h
+	Nvrtc: {
+		{0, "NVRTC_SUCCESS", nil},
+		{1, "NVRTC_ERROR_OUT_OF_MEMORY", ErrAllocFailed},
+		{2, "NVRTC_ERROR_PROGRAM_CREATION_FAILURE", ErrCompilation},
+		{3, "NVRTC_ERROR_INVALID_INPUT", ErrBadParam},
+		{4, "NVRTC_ERROR_INVALID_PROGRAM", ErrInvalidHandle},
+		{5, "NVRTC_ERROR_INVALID_OPTION", ErrBadParam},
+		{6, "NVRTC_ERROR_COMPILATION", ErrCompilation},
+		{7, "NVRTC_ERROR_BUILTIN_OPERATION_FAILURE", ErrInternal},
+		{8, "NVRTC_ERROR_NO_NAME_EXPRESSIONS_AFTER_COMPILATION", ErrNotPermitted},
+		{9, "NVRTC_ERROR_NO_LOWERED_NAMES_BEFORE_COMPILATION", ErrNotPermitted},
+		{10, "NVRTC_ERROR_NAME_EXPRESSION_NOT_VALID", ErrBadParam},
+		{11, "NVRTC_ERROR_INTERNAL_ERROR", ErrInternal},
+	},
+	//nvjpegStatus_t from nvjpeg.h
+	Nvjpeg: {
+		{0, "NVJPEG_STATUS_SUCCESS", nil},
+		{1, "NVJPEG_STATUS_NOT_INITIALIZED", ErrNotInitialized},
+		{2, "NVJPEG_STATUS_INVALID_PARAMETER", ErrBadParam},
+		{3, "NVJPEG_STATUS_BAD_JPEG", ErrBadData},
+		{4, "NVJPEG_STATUS_JPEG_NOT_SUPPORTED", ErrNotSupported},
+		{5, "NVJPEG_STATUS_ALLOCATOR_FAILURE", ErrAllocFailed},
+		{6, "NVJPEG_STATUS_EXECUTION_FAILED", ErrExecutionFailed},
+		{7, "NVJPEG_STATUS_ARCH_MISMATCH", ErrArchMismatch},
+		{8, "NVJPEG_STATUS_INTERNAL_ERROR", ErrInternal},
+		{9, "NVJPEG_STATUS_IMPLEMENTATION_NOT_SUPPORTED", ErrNotSupported},
+	},
+	//curandStatus_t from curand.h
+	Curand: {
+		{0, "CURAND_STATUS_SUCCESS", nil},
+		{100, "CURAND_STATUS_VERSION_MISMATCH", ErrVersionMismatch},
+		{101, "CURAND_STATUS_NOT_INITIALIZED", ErrNotInitialized},
+		{102, "CURAND_STATUS_ALLOCATION_FAILED", ErrAllocFailed},
+		{103, "CURAND_STATUS_TYPE_ERROR", ErrBadParam},
+		{104, "CURAND_STATUS_OUT_OF_RANGE", ErrOutOfRange},
+		{105, "CURAND_STATUS_LENGTH_NOT_MULTIPLE", ErrBadParam},
+		{106, "CURAND_STATUS_DOUBLE_PRECISION_REQUIRED", ErrArchMismatch},
+		{201, "CURAND_STATUS_LAUNCH_FAILURE", ErrLaunchFailed},
+		{202, "CURAND_STATUS_PREEXISTING_FAILURE", ErrExecutionFailed},
+		{203, "CURAND_STATUS_INITIALIZATION_FAILED", ErrNotInitialized},
+		{204, "CURAND_STATUS_ARCH_MISMATCH", ErrArchMismatch},
+		{999, "CURAND_STATUS_INTERNAL_ERROR", ErrInternal},
+	},
+	//NppStatus from nppdefs.h
+	Npp: {
+		{-9999, "NPP_NOT_SUPPORTED_MODE_ERROR", ErrNotSupported},
+		{-1032, "NPP_INVALID_HOST_POINTER_ERROR", ErrBadParam},
+		{-1031, "NPP_INVALID_DEVICE_POINTER_ERROR", ErrBadParam},
+		{-1030, "NPP_LUT_PALETTE_BITSIZE_ERROR", ErrBadParam},
+		{-1028, "NPP_ZC_MODE_NOT_SUPPORTED_ERROR", ErrNotSupported},
+		{-1027, "NPP_NOT_SUFFICIENT_COMPUTE_CAPABILITY", ErrArchMismatch},
+		{-1024, "NPP_TEXTURE_BIND_ERROR", ErrExecutionFailed},
+		{-1020, "NPP_WRONG_INTERSECTION_ROI_ERROR", ErrBadParam},
+		{-1006, "NPP_HAAR_CLASSIFIER_PIXEL_MATCH_ERROR", ErrExecutionFailed},
+		{-1005, "NPP_MEMFREE_ERROR", ErrAllocFailed},
+		{-1004, "NPP_MEMSET_ERROR", ErrExecutionFailed},
+		{-1003, "NPP_MEMCPY_ERROR", ErrExecutionFailed},
+		{-1002, "NPP_ALIGNMENT_ERROR", ErrBadParam},
+		{-1000, "NPP_CUDA_KERNEL_EXECUTION_ERROR", ErrExecutionFailed},
+		{-213, "NPP_ROUND_MODE_NOT_SUPPORTED_ERROR", ErrNotSupported},
+		{-210, "NPP_QUALITY_INDEX_ERROR", ErrBadData},
+		{-201, "NPP_RESIZE_NO_OPERATION_ERROR", ErrBadParam},
+		{-109, "NPP_OVERFLOW_ERROR", ErrOutOfRange},
+		{-108, "NPP_NOT_EVEN_STEP_ERROR", ErrBadParam},
+		{-107, "NPP_HISTOGRAM_NUMBER_OF_LEVELS_ERROR", ErrBadParam},
+		{-106, "NPP_LUT_NUMBER_OF_LEVELS_ERROR", ErrBadParam},
+		{-61, "NPP_CORRUPTED_DATA_ERROR", ErrBadData},
+		{-60, "NPP_CHANNEL_ORDER_ERROR", ErrBadParam},
+		{-59, "NPP_ZERO_MASK_VALUE_ERROR", ErrBadParam},
+		{-58, "NPP_QUADRANGLE_ERROR", ErrBadParam},
+		{-57, "NPP_RECTANGLE_ERROR", ErrBadParam},
+		{-56, "NPP_COEFFICIENT_ERROR", ErrBadParam},
+		{-53, "NPP_NUMBER_OF_CHANNELS_ERROR", ErrBadParam},
+		{-52, "NPP_COI_ERROR", ErrBadParam},
+		{-51, "NPP_DIVISOR_ERROR", ErrBadParam},
+		{-47, "NPP_CHANNEL_ERROR", ErrBadParam},
+		{-37, "NPP_STRIDE_ERROR", ErrBadParam},
+		{-34, "NPP_ANCHOR_ERROR", ErrBadParam},
+		{-33, "NPP_MASK_SIZE_ERROR", ErrBadParam},
+		{-23, "NPP_RESIZE_FACTOR_ERROR", ErrBadParam},
+		{-22, "NPP_INTERPOLATION_ERROR", ErrBadParam},
+		{-21, "NPP_MIRROR_FLIP_ERROR", ErrBadParam},
+		{-20, "NPP_MOMENT_00_ZERO_ERROR", ErrBadData},
+		{-19, "NPP_THRESHOLD_NEGATIVE_LEVEL_ERROR", ErrBadParam},
+		{-18, "NPP_THRESHOLD_ERROR", ErrBadParam},
+		{-17, "NPP_CONTEXT_MATCH_ERROR", ErrBadParam},
+		{-16, "NPP_FFT_FLAG_ERROR", ErrBadParam},
+		{-15, "NPP_FFT_ORDER_ERROR", ErrBadParam},
+		{-14, "NPP_STEP_ERROR", ErrBadParam},
+		{-13, "NPP_SCALE_RANGE_ERROR", ErrOutOfRange},
+		{-12, "NPP_DATA_TYPE_ERROR", ErrBadParam},
+		{-11, "NPP_OUT_OFF_RANGE_ERROR", ErrOutOfRange},
+		{-10, "NPP_DIVIDE_BY_ZERO_ERROR", ErrBadData},
+		{-9, "NPP_MEMORY_ALLOCATION_ERR", ErrAllocFailed},
+		{-8, "NPP_NULL_POINTER_ERROR", ErrBadParam},
+		{-7, "NPP_RANGE_ERROR", ErrOutOfRange},
+		{-6, "NPP_SIZE_ERROR", ErrBadParam},
+		{-5, "NPP_BAD_ARGUMENT_ERROR", ErrBadParam},
+		{-4, "NPP_NO_MEMORY_ERROR", ErrAllocFailed},
+		{-3, "NPP_NOT_IMPLEMENTED_ERROR", ErrNotSupported},
+		{-2, "NPP_ERROR", ErrUnknown},
+		{-1, "NPP_ERROR_RESERVED", ErrUnknown},
+		{0, "NPP_NO_ERROR", nil},
+		{1, "NPP_NO_OPERATION_WARNING", ErrWarning},
+		{6, "NPP_DIVIDE_BY_ZERO_WARNING", ErrWarning},
+		{28, "NPP_AFFINE_QUAD_INCORRECT_WARNING", ErrWarning},
+		{29, "NPP_WRONG_INTERSECTION_ROI_WARNING", ErrWarning},
+		{30, "NPP_WRONG_INTERSECTION_QUAD_WARNING", ErrWarning},
+		{35, "NPP_DOUBLE_SIZE_WARNING", ErrWarning},
+		{10000, "NPP_MISALIGNED_DST_ROI_WARNING", ErrWarning},
+	},
+}
@@ -0,0 +1,231 @@
+//Package cuerr is the error model shared by gocudnn, cudart, cuda, nvrtc, nvjpeg, curand and npp.
+//
+//Every failed call returns an *Error that holds the library, the numeric code, the name of the code,
+//the op that failed, and a summary of the descriptors that were passed to it when there are any.
+//Each code maps to a sentinel so callers don't have to know the codes of each library.
+//
+//	if errors.Is(err, cuerr.ErrBadParam) {
+//		...
+//	}
+//	var e *cuerr.Error
+//	if errors.As(err, &e) {
+//		fmt.Println(e.Library, e.Code, e.Name)
+//	}
+//
+//The code tables are pure go so they can be tested without a gpu.
+package cuerr
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+//Sentinels that every code maps to.  Use them with errors.Is.
+var (
+	ErrNotInitialized  = errors.New("not initialized")
+	ErrAllocFailed     = errors.New("allocation failed")
+	ErrBadParam        = errors.New("bad parameter")
+	ErrNotSupported    = errors.New("not supported")
+	ErrArchMismatch    = errors.New("architecture mismatch")
+	ErrVersionMismatch = errors.New("version mismatch")
+	ErrExecutionFailed = errors.New("execution failed")
+	ErrLaunchFailed    = errors.New("launch failed")
+	ErrIllegalAddress  = errors.New("illegal address")
+	ErrMapping         = errors.New("mapping error")
+	ErrInvalidDevice   = errors.New("invalid or missing device")
+	ErrInvalidHandle   = errors.New("invalid handle")
+	ErrInvalidImage    = errors.New("invalid kernel image")
+	ErrNotFound        = errors.New("not found")
+	ErrNotReady        = errors.New("not ready")
+	ErrNotPermitted    = errors.New("not permitted")
+	ErrTimeout         = errors.New("timeout")
+	ErrCompilation     = errors.New("compilation failed")
+	ErrBadData         = errors.New("bad data")
+	ErrOutOfRange      = errors.New("out of range")
+	ErrLicense         = errors.New("license error")
+	ErrStreamCapture   = errors.New("stream capture error")
+	ErrInternal        = errors.New("internal error")
+	ErrWarning         = errors.New("warning")
+	ErrUnknown         = errors.New("unknown error")
+)
+
+//Library is the library that returned an Error
+type Library int
+
+//Libraries
+const (
+	Cudnn Library = iota + 1
+	Cudart
+	Cuda
+	Nvrtc
+	Nvjpeg
+	Curand
+	Npp
+)
+
+func (l Library) String() string {
+	switch l {
+	case Cudnn:
+		return "cudnn"
+	case Cudart:
+		return "cudart"
+	case Cuda:
+		return "cuda"
+	case Nvrtc:
+		return "nvrtc"
+	case Nvjpeg:
+		return "nvjpeg"
+	case Curand:
+		return "curand"
+	case Npp:
+		return "npp"
+	}
+	return fmt.Sprintf("Library(%d)", int(l))
+}
+
+//Error is a failed call to one of the libraries.
+type Error struct {
+	Library Library
+	Code    int    //Numeric code returned by the library
+	Name    string //C name of the code, such as CUDNN_STATUS_BAD_PARAM
+	Op      string //Go method or C function that failed
+	Message string //Message from the library if it has one
+	Desc    string //Summary of the descriptors passed to Op
+
+	//Context is the same as Op.  It is kept for code written against the old Error of cuda and cudart.
+	//
+	//Deprecated: use Op.
+	Context string
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	if e.Op != "" {
+		b.WriteString(e.Op)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Name)
+	if e.Message != "" && e.Message != e.Name {
+		b.WriteString(" (")
+		b.WriteString(e.Message)
+		b.WriteString(")")
+	}
+	if e.Desc != "" {
+		b.WriteString("\n")
+		b.WriteString(e.Desc)
+	}
+	return b.String()
+}
+
+//Unwrap returns the sentinel of the code.  It is what makes errors.Is work.
+func (e *Error) Unwrap() error {
+	return Sentinel(e.Library, e.Code)
+}
+
+//New returns nil if code is the success code of lib, else it returns an *Error.
+func New(lib Library, code int, op string) error {
+	return NewMessage(lib, code, op, "")
+}
+
+//NewMessage is like New but keeps the message the library gave for the code.
+func NewMessage(lib Library, code int, op, message string) error {
+	if code == 0 {
+		return nil
+	}
+	return &Error{
+		Library: lib,
+		Code:    code,
+		Name:    Name(lib, code),
+		Op:      op,
+		Message: message,
+		Context: op,
+	}
+}
+
+//WithDesc returns a copy of err with the String of each desc added to its descriptor summary.
+//If err is not an *Error it is returned as is.  The descs are only formatted when err is not nil.
+func WithDesc(err error, descs ...fmt.Stringer) error {
+	var e *Error
+	if err == nil || !errors.As(err, &e) {
+		return err
+	}
+	c := *e
+	s := make([]string, 0, len(descs)+1)
+	if c.Desc != "" {
+		s = append(s, c.Desc)
+	}
+	for _, d := range descs {
+		if d != nil {
+			s = append(s, strings.TrimSpace(d.String()))
+		}
+	}
+	c.Desc = strings.Join(s, "\n")
+	return &c
+}
+
+//Caller returns the name of a function on the call stack, such as npp.ResizeSqrPixel8uC1R.
+//Caller(0) is the function that called Caller.  It is used as the op by packages that don't pass op names to their status types.
+func Caller(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return ""
+	}
+	f := runtime.FuncForPC(pc)
+	if f == nil {
+		return ""
+	}
+	name := f.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+//CodeOf returns the library and code held by err.
+func CodeOf(err error) (Library, int, bool) {
+	var e *Error
+	if !errors.As(err, &e) {
+		return 0, 0, false
+	}
+	return e.Library, e.Code, true
+}
+
+//Name returns the C name of the code.
+func Name(lib Library, code int) string {
+	if c, ok := lookup(lib, code); ok {
+		return c.name
+	}
+	return fmt.Sprintf("%v unknown code %d", lib, code)
+}
+
+//Sentinel returns the sentinel that code maps to.  It returns nil for a success code and ErrUnknown for a code it doesn't know.
+func Sentinel(lib Library, code int) error {
+	if code == 0 {
+		return nil
+	}
+	if c, ok := lookup(lib, code); ok {
+		return c.sentinel
+	}
+	return ErrUnknown
+}
+
+//Codes returns every code known for lib, including the success code.
+func Codes(lib Library) []int {
+	t := tables[lib]
+	codes := make([]int, len(t))
+	for i := range t {
+		codes[i] = t[i].code
+	}
+	return codes
+}
+
+func lookup(lib Library, code int) (entry, bool) {
+	for _, c := range tables[lib] {
+		if c.code == code {
+			return c, true
+		}
+	}
+	return entry{}, false
+}
@@ -0,0 +1,98 @@
+package cuerr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+//TestTables checks that the tables are consistent.  The codes and names are checked against the C headers by the tests of cuerr/ccodes.
+func TestTables(t *testing.T) {
+	for lib, entries := range tables {
+		codes := make(map[int]bool)
+		names := make(map[string]bool)
+		for _, e := range entries {
+			if codes[e.code] || names[e.name] {
+				t.Errorf("%v: %d %s is in the table twice", lib, e.code, e.name)
+			}
+			codes[e.code], names[e.name] = true, true
+			if Name(lib, e.code) != e.name {
+				t.Errorf("%v: Name(%d) = %s want %s", lib, e.code, Name(lib, e.code), e.name)
+			}
+			err := New(lib, e.code, "op")
+			if e.code == 0 {
+				if err != nil || e.sentinel != nil {
+					t.Errorf("%v: success code gave %v", lib, err)
+				}
+				continue
+			}
+			if e.sentinel == nil {
+				t.Errorf("%v: %s has no sentinel", lib, e.name)
+			}
+			if !errors.Is(err, e.sentinel) || Sentinel(lib, e.code) != e.sentinel {
+				t.Errorf("%v: errors.Is(%v, %v) is false", lib, err, e.sentinel)
+			}
+			wrapped := fmt.Errorf("wrapped: %w", err)
+			var cerr *Error
+			if !errors.As(wrapped, &cerr) || cerr.Library != lib || cerr.Code != e.code || cerr.Op != "op" || cerr.Context != "op" {
+				t.Errorf("%v: errors.As lost the code of %s", lib, e.name)
+			}
+		}
+		if !codes[0] {
+			t.Errorf("%v: no success code", lib)
+		}
+	}
+	for lib := Cudnn; lib <= Npp; lib++ {
+		if _, ok := tables[lib]; !ok {
+			t.Errorf("%v: missing from tables", lib)
+		}
+	}
+}
+
+func TestUnknownCode(t *testing.T) {
+	err := New(Cudnn, 12345, "(h *Handle) Op")
+	if !errors.Is(err, ErrUnknown) {
+		t.Error(err)
+	}
+	if !strings.Contains(err.Error(), "12345") {
+		t.Error(err)
+	}
+	if errors.Is(err, ErrBadParam) {
+		t.Error("unknown code is a bad param")
+	}
+}
+
+func TestCaller(t *testing.T) {
+	op := func() string { return Caller(1) }()
+	if op != "cuerr.TestCaller" {
+		t.Error(op)
+	}
+}
+
+type desc string
+
+func (d desc) String() string { return string(d) + "\n" }
+
+func TestWithDesc(t *testing.T) {
+	if WithDesc(nil, desc("x")) != nil {
+		t.Error("nil error")
+	}
+	plain := errors.New("plain")
+	if WithDesc(plain, desc("x")) != plain {
+		t.Error("plain error changed")
+	}
+	err := NewMessage(Cudnn, 3, "(c *ConvolutionD) Forward", "CUDNN_STATUS_BAD_PARAM")
+	derr := WithDesc(err, desc("TensorD"), nil, desc("FilterD"))
+	want := "(c *ConvolutionD) Forward: CUDNN_STATUS_BAD_PARAM\nTensorD\nFilterD"
+	if derr.Error() != want {
+		t.Errorf("got %q want %q", derr.Error(), want)
+	}
+	if err.(*Error).Desc != "" {
+		t.Error("WithDesc changed the original error")
+	}
+	lib, code, ok := CodeOf(derr)
+	if !ok || lib != Cudnn || code != 3 || !errors.Is(derr, ErrBadParam) {
+		t.Error(lib, code, ok)
+	}
+}
@@ -104,10 +104,10 @@ The curandGenerate() function is used to generate pseudo- or quasirandom bits of
 func (c *Generator) Uint(mem cutil.Mem, sizeinbytes uint) error {
 	if c.w != nil {
 		return c.w.Work(func() error {
-			return curandstatus(C.curandGenerate(c.generator, (*C.uint)(mem.Ptr()), C.size_t(sizeinbytes))).error("(c *Generator) Uint")
+			return curandstatus(C.curandGenerate(c.generator, (*C.uint)(mem.Ptr()), C.size_t(sizeinbytes/4))).error("(c *Generator) Uint")
 		})
 	}
-	return curandstatus(C.curandGenerate(c.generator, (*C.uint)(mem.Ptr()), C.size_t(sizeinbytes))).error("(c *Generator) Uint")
+	return curandstatus(C.curandGenerate(c.generator, (*C.uint)(mem.Ptr()), C.size_t(sizeinbytes/4))).error("(c *Generator) Uint")
 }
 
 //Uint64 fills mem with  unsigned long long random numbers
@@ -119,10 +119,10 @@ The curandGenerate() function is used to generate pseudo- or quasirandom bits of
 func (c *Generator) Uint64(mem cutil.Mem, sizeinbytes uint) error {
 	if c.w != nil {
 		return c.w.Work(func() error {
-			return curandstatus(C.curandGenerateLongLong(c.generator, (*C.ulonglong)(mem.Ptr()), C.size_t(sizeinbytes))).error("(c *Generator) Uint64")
+			return curandstatus(C.curandGenerateLongLong(c.generator, (*C.ulonglong)(mem.Ptr()), C.size_t(sizeinbytes/8))).error("(c *Generator) Uint64")
 		})
 	}
-	return curandstatus(C.curandGenerateLongLong(c.generator, (*C.ulonglong)(mem.Ptr()), C.size_t(sizeinbytes))).error("(c *Generator) Uint64")
+	return curandstatus(C.curandGenerateLongLong(c.generator, (*C.ulonglong)(mem.Ptr()), C.size_t(sizeinbytes/8))).error("(c *Generator) Uint64")
 }
 
 //UniformFloat32 - generates uniform distributions in float32
@@ -133,24 +133,25 @@ The curandGenerateUniform() function is used to generate uniformly distributed f
 func (c *Generator) UniformFloat32(mem cutil.Mem, sizeinbytes uint) error {
 	if c.w != nil {
 		return c.w.Work(func() error {
-			return curandstatus(C.curandGenerateUniform(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes))).error("(c *Generator) UniformFloat32")
+			return curandstatus(C.curandGenerateUniform(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes/4))).error("(c *Generator) UniformFloat32")
 		})
 	}
-	return curandstatus(C.curandGenerateUniform(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes))).error("(c *Generator) UniformFloat32")
+	return curandstatus(C.curandGenerateUniform(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes/4))).error("(c *Generator) UniformFloat32")
 }
 
 //NormalFloat32 -generates a Normal distribution in float32
 /*
 from cuRAND documentation:
 The curandGenerateNormal() function is used to generate normally distributed floating point values with the given mean and standard deviation.
+The pseudorandom generators need sizeinbytes/4 to be even.
 */
 func (c *Generator) NormalFloat32(mem cutil.Mem, sizeinbytes uint, mean, std float32) error {
 	if c.w != nil {
 		return c.w.Work(func() error {
-			return curandstatus(C.curandGenerateNormal(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes), C.float(mean), C.float(std))).error("(c *Generator) NormalFloat32")
+			return curandstatus(C.curandGenerateNormal(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes/4), C.float(mean), C.float(std))).error("(c *Generator) NormalFloat32")
 		})
 	}
-	return curandstatus(C.curandGenerateNormal(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes), C.float(mean), C.float(std))).error("(c *Generator) NormalFloat32")
+	return curandstatus(C.curandGenerateNormal(c.generator, (*C.float)(mem.Ptr()), C.size_t(sizeinbytes/4), C.float(mean), C.float(std))).error("(c *Generator) NormalFloat32")
 }
 
 /*
@@ -4,7 +4,7 @@ package curand
 #include <curand.h>
 */
 import "C"
-import "fmt"
+import "github.com/negativeOne1/gocudnn/cuerr"
 
 /*
 
@@ -174,40 +174,10 @@ CuRand Status/Error stuff
 type curandstatus C.curandStatus_t
 
 func (c curandstatus) error(Comment string) error {
-	if c == curandstatus(C.CURAND_STATUS_SUCCESS) {
-		return nil
-	}
-	return fmt.Errorf("%s -- %s", Comment, c.Error())
+	return cuerr.New(cuerr.Curand, int(c), Comment)
 }
 
 //Error is the error for currand
 func (c curandstatus) Error() string {
-	switch c {
-	case curandstatus(C.CURAND_STATUS_VERSION_MISMATCH):
-		return "CURAND_STATUS_VERSION_MISMATCH"
-	case curandstatus(C.CURAND_STATUS_NOT_INITIALIZED):
-		return "CURAND_STATUS_NOT_INITIALIZED"
-	case curandstatus(C.CURAND_STATUS_ALLOCATION_FAILED):
-		return "CURAND_STATUS_ALLOCATION_FAILED"
-	case curandstatus(C.CURAND_STATUS_TYPE_ERROR):
-		return "CURAND_STATUS_TYPE_ERROR"
-	case curandstatus(C.CURAND_STATUS_OUT_OF_RANGE):
-		return "CURAND_STATUS_OUT_OF_RANGE"
-	case curandstatus(C.CURAND_STATUS_LENGTH_NOT_MULTIPLE):
-		return "CURAND_STATUS_LENGTH_NOT_MULTIPLE"
-	case curandstatus(C.CURAND_STATUS_DOUBLE_PRECISION_REQUIRED):
-		return "CURAND_STATUS_DOUBLE_PRECISION_REQUIRED"
-	case curandstatus(C.CURAND_STATUS_LAUNCH_FAILURE):
-		return "CURAND_STATUS_LAUNCH_FAILURE"
-	case curandstatus(C.CURAND_STATUS_PREEXISTING_FAILURE):
-		return "CURAND_STATUS_PREEXISTING_FAILURE"
-	case curandstatus(C.CURAND_STATUS_INITIALIZATION_FAILED):
-		return "CURAND_STATUS_INITIALIZATION_FAILED"
-	case curandstatus(C.CURAND_STATUS_ARCH_MISMATCH):
-		return "CURAND_STATUS_ARCH_MISMATCH"
-	case curandstatus(C.CURAND_STATUS_INTERNAL_ERROR):
-		return "CURAND_STATUS_INTERNAL_ERROR"
-	default:
-		return "Unknown Error. Problably got a CURAND_STATUS_SUCCESS and haven't accounted for it in the function  "
-	}
+	return cuerr.Name(cuerr.Curand, int(c))
 }
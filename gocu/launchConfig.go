@@ -1,5 +1,7 @@
 package gocu
 
+import "github.com/negativeOne1/gocudnn/cuda/occupancy"
+
 //Attributes are the attributes a device needs to return.
 type Attributes interface {
 	MaxThreadsPerMultiProcessor() (int32, error)
@@ -138,6 +140,30 @@ func (l *ConfigHelper) CreateLaunchConfig(x, y, z int32) (launch Config) {
 	return Config{}
 }
 
+//CreateOccupancyLaunchConfig creates a 1d launch config for x elements that uses the block size in r.
+//r can come from occupancy.Device.MaxPotentialBlockSize, so registers and shared memory are taken into account.
+//The grid is no more blocks than can be active on the device at once, since kernels loop over the elements.
+func (l *ConfigHelper) CreateOccupancyLaunchConfig(x int32, r occupancy.Result) Config {
+	if x < 1 || r.BlockSize < 1 {
+		return Config{}
+	}
+	bcount := divup(x, int32(r.BlockSize))
+	if full := int32(r.ActiveBlocks) * l.muliproccessorcount; full > 0 {
+		bcount = min(bcount, full)
+	}
+	return Config{
+		Dimx:            x,
+		Dimy:            1,
+		Dimz:            1,
+		ThreadPerBlockx: uint32(r.BlockSize),
+		ThreadPerBlocky: 1,
+		ThreadPerBlockz: 1,
+		BlockCountx:     uint32(bcount),
+		BlockCounty:     1,
+		BlockCountz:     1,
+	}
+}
+
 func max(a, b int32) int32 {
 	if a > b {
 		return a
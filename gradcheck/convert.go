@@ -0,0 +1,20 @@
+package gradcheck
+
+//ToFloat32 converts x to float32 so it can be copied to a float tensor on the device.
+func ToFloat32(x []float64) []float32 {
+	y := make([]float32, len(x))
+	for i := range x {
+		y[i] = float32(x[i])
+	}
+	return y
+}
+
+//FromFloat32 copies src into dst.  It is used to bring float tensors from the device back into the checker's buffers.
+//Like copy it only copies the shorter of the two, and it returns how many elements were copied.
+func FromFloat32(dst []float64, src []float32) int {
+	n := min(len(dst), len(src))
+	for i := 0; i < n; i++ {
+		dst[i] = float64(src[i])
+	}
+	return n
+}
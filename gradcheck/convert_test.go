@@ -0,0 +1,23 @@
+package gradcheck
+
+import "testing"
+
+func TestFromFloat32(t *testing.T) {
+	src := []float32{1, 2, 3}
+	dst := make([]float64, 4)
+	if n := FromFloat32(dst, src); n != 3 || dst[2] != 3 || dst[3] != 0 {
+		t.Error(n, dst)
+	}
+	dst = make([]float64, 2)
+	if n := FromFloat32(dst, src); n != 2 || dst[1] != 2 {
+		t.Error(n, dst)
+	}
+	x := []float64{0.5, -1, 3}
+	back := make([]float64, len(x))
+	FromFloat32(back, ToFloat32(x))
+	for i := range x {
+		if back[i] != x[i] {
+			t.Error(x, back)
+		}
+	}
+}
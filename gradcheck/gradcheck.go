@@ -0,0 +1,273 @@
+//Package gradcheck numerically checks the gradients produced by backward functions.
+//
+//Every backward method in gocudnn and xtra is wired by hand, and a swapped dx/dw or a missing
+//scale is hard to spot by looking at numbers.  Checker perturbs every element of every input,
+//computes the central finite difference of a random projection of the outputs in float64,
+//and compares it against what the backward function returned.
+//
+//Forward and backward are closures over host buffers.  They can wrap a pure go reference or
+//copy the buffers to the device, run a gocudnn/xtra op, and copy the results back.
+package gradcheck
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+//Shape is anything that can report the dims of a tensor. *gocudnn.TensorD satisfies it.
+type Shape interface {
+	Dims() []int32
+}
+
+//Dims is a Shape made from a plain slice.  It can be used when a descriptor isn't handy.
+type Dims []int32
+
+//Dims satisfies Shape
+func (d Dims) Dims() []int32 { return d }
+
+//Tensor is a named host buffer and the shape it holds.
+//
+//If NoGrad is true the tensor is still fed to forward and backward but its gradient is not checked.
+//This is handy for labels, indexes, or anything else that isn't differentiable.
+type Tensor struct {
+	Name   string
+	Shape  Shape
+	Data   []float64
+	NoGrad bool
+}
+
+//ForwardFunc fills y using x.  x and y are in the same order as the tensors passed to Check.
+type ForwardFunc func(x, y [][]float64) error
+
+//BackwardFunc fills dx using x and dy.  dx will be zeroed before each call,
+//and dx[i] is nil for inputs that are marked NoGrad.
+type BackwardFunc func(x, dy, dx [][]float64) error
+
+//Checker holds the settings for the numerical gradient check.  The zero value is usable.
+type Checker struct {
+	Eps        float64 //Step used for the central difference. Default is 1e-3
+	Seed       int64   //Seed for the random dy. Default is 1
+	MaxSamples int     //Max elements checked per tensor. Zero checks every element
+	AbsTol     float64 //An element fails if its absolute error is over AbsTol and its relative error is over RelTol. Default is 1e-4
+	RelTol     float64 //See AbsTol. Default is 1e-2
+}
+
+//TensorReport holds the worst errors found for a single input tensor.
+type TensorReport struct {
+	Name      string
+	Checked   int
+	Failed    int //elements where both the absolute and the relative error are over the tolerance
+	MaxAbsErr float64
+	MaxRelErr float64
+	Index     int     //flat index of the failed element with the largest absolute error, or of the element with the largest absolute error if none failed
+	Numeric   float64 //numeric gradient at Index
+	Analytic  float64 //analytic gradient at Index
+}
+
+func (t TensorReport) String() string {
+	return fmt.Sprintf("%s: checked %d, failed %d, max abs err %.3e, max rel err %.3e (index %d numeric %.6e analytic %.6e)",
+		t.Name, t.Checked, t.Failed, t.MaxAbsErr, t.MaxRelErr, t.Index, t.Numeric, t.Analytic)
+}
+
+//Report is returned by Check.
+type Report struct {
+	Tensors []TensorReport
+}
+
+func (r *Report) String() string {
+	s := make([]string, len(r.Tensors))
+	for i := range r.Tensors {
+		s[i] = r.Tensors[i].String()
+	}
+	return strings.Join(s, "\n")
+}
+
+//Err returns an error listing every tensor that has an element where both the absolute and the relative error
+//are over the tolerance of the Checker that made the report.  It returns nil if all passed.
+func (r *Report) Err() error {
+	var failed []string
+	for _, t := range r.Tensors {
+		if t.Failed > 0 {
+			failed = append(failed, t.String())
+		}
+	}
+	if failed == nil {
+		return nil
+	}
+	return errors.New("gradcheck: gradient mismatch:\n" + strings.Join(failed, "\n"))
+}
+
+func (c Checker) defaults() Checker {
+	if c.Eps == 0 {
+		c.Eps = 1e-3
+	}
+	if c.Seed == 0 {
+		c.Seed = 1
+	}
+	if c.AbsTol == 0 {
+		c.AbsTol = 1e-4
+	}
+	if c.RelTol == 0 {
+		c.RelTol = 1e-2
+	}
+	return c
+}
+
+//Check runs the gradient check.  x are the inputs to forward.  y are the outputs, only their shapes are used,
+//and their Data will be allocated if it is nil.  The Data of x is restored when Check returns.
+func (c Checker) Check(x, y []Tensor, forward ForwardFunc, backward BackwardFunc) (*Report, error) {
+	c = c.defaults()
+	if forward == nil || backward == nil {
+		return nil, errors.New("gradcheck: forward and backward must not be nil")
+	}
+	xs := make([][]float64, len(x))
+	for i := range x {
+		n, err := volume(x[i].Shape)
+		if err != nil {
+			return nil, fmt.Errorf("gradcheck: input %s: %v", x[i].Name, err)
+		}
+		if len(x[i].Data) != n {
+			return nil, fmt.Errorf("gradcheck: input %s: len(Data) is %d shape needs %d", x[i].Name, len(x[i].Data), n)
+		}
+		xs[i] = x[i].Data
+	}
+	ys := make([][]float64, len(y))
+	dys := make([][]float64, len(y))
+	rng := rand.New(rand.NewSource(c.Seed))
+	for i := range y {
+		n, err := volume(y[i].Shape)
+		if err != nil {
+			return nil, fmt.Errorf("gradcheck: output %s: %v", y[i].Name, err)
+		}
+		if y[i].Data == nil {
+			y[i].Data = make([]float64, n)
+		}
+		if len(y[i].Data) != n {
+			return nil, fmt.Errorf("gradcheck: output %s: len(Data) is %d shape needs %d", y[i].Name, len(y[i].Data), n)
+		}
+		ys[i] = y[i].Data
+		dys[i] = make([]float64, n)
+		for j := range dys[i] {
+			dys[i][j] = rng.Float64()*2 - 1
+		}
+	}
+
+	dxs := make([][]float64, len(x))
+	for i := range x {
+		if !x[i].NoGrad {
+			dxs[i] = make([]float64, len(xs[i]))
+		}
+	}
+	if err := forward(xs, ys); err != nil {
+		return nil, fmt.Errorf("gradcheck: forward: %v", err)
+	}
+	if err := backward(xs, dys, dxs); err != nil {
+		return nil, fmt.Errorf("gradcheck: backward: %v", err)
+	}
+
+	//loss is the projection of the outputs onto dy.  Its gradient with respect to x is what backward returns.
+	loss := func() (float64, error) {
+		if err := forward(xs, ys); err != nil {
+			return 0, err
+		}
+		var l float64
+		for i := range ys {
+			for j := range ys[i] {
+				l += ys[i][j] * dys[i][j]
+			}
+		}
+		return l, nil
+	}
+
+	r := new(Report)
+	for i := range x {
+		if x[i].NoGrad {
+			continue
+		}
+		tr := TensorReport{Name: x[i].Name}
+		var worst float64
+		for _, j := range c.samples(rng, len(xs[i])) {
+			orig := xs[i][j]
+			xs[i][j] = orig + c.Eps
+			lp, err := loss()
+			if err != nil {
+				xs[i][j] = orig
+				return nil, fmt.Errorf("gradcheck: forward: %v", err)
+			}
+			xs[i][j] = orig - c.Eps
+			lm, err := loss()
+			xs[i][j] = orig
+			if err != nil {
+				return nil, fmt.Errorf("gradcheck: forward: %v", err)
+			}
+			numeric := (lp - lm) / (2 * c.Eps)
+			analytic := dxs[i][j]
+			abserr, relerr := Errors(numeric, analytic)
+			//An element fails only if both of its errors are over, so a big value with a small relative error
+			//and a value near zero with a big relative error don't add up to a failure.
+			failed := abserr > c.AbsTol && relerr > c.RelTol
+			if failed {
+				tr.Failed++
+			}
+			//Index is the worst failed element, or the worst element if none failed.
+			if tr.Checked == 0 || failed && tr.Failed == 1 || (failed || tr.Failed == 0) && abserr > worst {
+				worst = abserr
+				tr.Index = j
+				tr.Numeric = numeric
+				tr.Analytic = analytic
+			}
+			tr.MaxAbsErr = math.Max(tr.MaxAbsErr, abserr)
+			tr.MaxRelErr = math.Max(tr.MaxRelErr, relerr)
+			tr.Checked++
+		}
+		r.Tensors = append(r.Tensors, tr)
+	}
+	//leave y holding the outputs for the unperturbed x
+	if err := forward(xs, ys); err != nil {
+		return nil, fmt.Errorf("gradcheck: forward: %v", err)
+	}
+	return r, nil
+}
+
+//Errors returns the absolute and the relative error between a and b.
+//The relative error is taken against the larger magnitude of the two and is zero if both are zero.
+func Errors(a, b float64) (abserr, relerr float64) {
+	abserr = math.Abs(a - b)
+	denom := math.Max(math.Abs(a), math.Abs(b))
+	if denom == 0 {
+		return abserr, 0
+	}
+	return abserr, abserr / denom
+}
+
+func (c Checker) samples(rng *rand.Rand, n int) []int {
+	if c.MaxSamples <= 0 || c.MaxSamples >= n {
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+	return rng.Perm(n)[:c.MaxSamples]
+}
+
+func volume(s Shape) (int, error) {
+	if s == nil {
+		return 0, errors.New("nil shape")
+	}
+	dims := s.Dims()
+	if len(dims) == 0 {
+		return 0, errors.New("shape has no dims")
+	}
+	v := 1
+	for _, d := range dims {
+		if d < 1 {
+			return 0, fmt.Errorf("bad dims %v", dims)
+		}
+		v *= int(d)
+	}
+	return v, nil
+}
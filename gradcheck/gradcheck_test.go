@@ -0,0 +1,196 @@
+package gradcheck
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+//dense is y = leaky(w*x+b) with x (n,c) w (k,c) b (k) y (n,k)
+func dense(n, c, k int, leak float64) (ForwardFunc, BackwardFunc) {
+	pre := func(x [][]float64, i, j int) float64 {
+		in, w, b := x[0], x[1], x[2]
+		s := b[j]
+		for l := 0; l < c; l++ {
+			s += w[j*c+l] * in[i*c+l]
+		}
+		return s
+	}
+	fwd := func(x, y [][]float64) error {
+		for i := 0; i < n; i++ {
+			for j := 0; j < k; j++ {
+				s := pre(x, i, j)
+				if s < 0 {
+					s *= leak
+				}
+				y[0][i*k+j] = s
+			}
+		}
+		return nil
+	}
+	bwd := func(x, dy, dx [][]float64) error {
+		in, w := x[0], x[1]
+		for i := 0; i < n; i++ {
+			for j := 0; j < k; j++ {
+				g := dy[0][i*k+j]
+				if pre(x, i, j) < 0 {
+					g *= leak
+				}
+				dx[2][j] += g
+				for l := 0; l < c; l++ {
+					dx[0][i*c+l] += g * w[j*c+l]
+					dx[1][j*c+l] += g * in[i*c+l]
+				}
+			}
+		}
+		return nil
+	}
+	return fwd, bwd
+}
+
+func randdata(rng *rand.Rand, n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = rng.Float64()*2 - 1
+	}
+	return x
+}
+
+func denseinputs(n, c, k int) (x, y []Tensor) {
+	rng := rand.New(rand.NewSource(3))
+	x = []Tensor{
+		{Name: "x", Shape: Dims{int32(n), int32(c)}, Data: randdata(rng, n*c)},
+		{Name: "w", Shape: Dims{int32(k), int32(c)}, Data: randdata(rng, k*c)},
+		{Name: "b", Shape: Dims{int32(k)}, Data: randdata(rng, k)},
+	}
+	y = []Tensor{{Name: "y", Shape: Dims{int32(n), int32(k)}}}
+	return x, y
+}
+
+func TestCheckerPasses(t *testing.T) {
+	n, c, k := 3, 4, 5
+	x, y := denseinputs(n, c, k)
+	xcopy := append([]float64(nil), x[0].Data...)
+	fwd, bwd := dense(n, c, k, 0.1)
+	r, err := Checker{}.Check(x, y, fwd, bwd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Err(); err != nil {
+		t.Error(err)
+	}
+	if len(r.Tensors) != 3 || r.Tensors[1].Checked != k*c {
+		t.Errorf("unexpected report %v", r)
+	}
+	for i := range xcopy {
+		if xcopy[i] != x[0].Data[i] {
+			t.Fatal("input was not restored")
+		}
+	}
+}
+
+func TestCheckerFindsSwappedGradients(t *testing.T) {
+	n, c, k := 3, 3, 3
+	x, y := denseinputs(n, c, k)
+	fwd, bwd := dense(n, c, k, 0.1)
+	swapped := func(x, dy, dx [][]float64) error {
+		//common wiring bug.  dx and dw buffers swapped.
+		return bwd(x, dy, [][]float64{dx[1], dx[0], dx[2]})
+	}
+	r, err := Checker{}.Check(x, y, fwd, swapped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Err() == nil {
+		t.Error("expected mismatch")
+	}
+	if r.Tensors[2].MaxAbsErr > 1e-6 {
+		t.Errorf("bias gradient should still match %v", r.Tensors[2])
+	}
+}
+
+//The largest absolute error is on a big gradient and the largest relative error is on a tiny one.
+//Neither element is over both tolerances, so the check passes.
+func TestCheckerPerElement(t *testing.T) {
+	a := []float64{1000, 1e-6}
+	x := []Tensor{{Name: "x", Shape: Dims{2}, Data: []float64{0.5, 0.5}}}
+	y := []Tensor{{Name: "y", Shape: Dims{2}}}
+	fwd := func(x, y [][]float64) error {
+		for i := range a {
+			y[0][i] = a[i] * x[0][i]
+		}
+		return nil
+	}
+	bwd := func(x, dy, dx [][]float64) error {
+		dx[0][0] = a[0] * dy[0][0] * 1.005
+		dx[0][1] = a[1] * dy[0][1] * 1.5
+		return nil
+	}
+	r, err := Checker{}.Check(x, y, fwd, bwd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := r.Tensors[0]
+	if tr.MaxAbsErr < 1e-4 || tr.MaxRelErr < 1e-2 {
+		t.Fatalf("test needs both maxima over the tolerances %v", tr)
+	}
+	if err = r.Err(); err != nil {
+		t.Error(err)
+	}
+
+	//Now the tiny gradient is far enough off to fail, and it is the element reported.
+	bwd2 := func(x, dy, dx [][]float64) error {
+		dx[0][0] = a[0] * dy[0][0] * 1.005
+		dx[0][1] = a[1]*dy[0][1] + 1
+		return nil
+	}
+	r, err = Checker{}.Check(x, y, fwd, bwd2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Err() == nil || r.Tensors[0].Failed != 1 || r.Tensors[0].Index != 1 {
+		t.Errorf("expected element 1 to fail %v", r.Tensors[0])
+	}
+}
+
+func TestCheckerNoGradAndSamples(t *testing.T) {
+	n, c, k := 4, 4, 4
+	x, y := denseinputs(n, c, k)
+	x[0].NoGrad = true
+	fwd, bwd := dense(n, c, k, 1)
+	r, err := Checker{MaxSamples: 5, Eps: 1e-4}.Check(x, y, fwd, func(x, dy, dx [][]float64) error {
+		if dx[0] != nil {
+			t.Error("dx for NoGrad input should be nil")
+		}
+		dx[0] = make([]float64, n*c)
+		return bwd(x, dy, dx)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Tensors) != 2 || r.Tensors[0].Name != "w" || r.Tensors[0].Checked != 5 {
+		t.Errorf("unexpected report %v", r)
+	}
+	if err = r.Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCheckerShapeMismatch(t *testing.T) {
+	x := []Tensor{{Name: "x", Shape: Dims{2, 2}, Data: make([]float64, 3)}}
+	y := []Tensor{{Name: "y", Shape: Dims{1}}}
+	_, err := Checker{}.Check(x, y, func(x, y [][]float64) error { return nil }, func(x, dy, dx [][]float64) error { return nil })
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	a, r := Errors(1, 1.5)
+	if a != .5 || math.Abs(r-1.0/3) > 1e-12 {
+		t.Error(a, r)
+	}
+	if _, r = Errors(0, 0); r != 0 {
+		t.Error(r)
+	}
+}
@@ -0,0 +1,144 @@
+//Package curandinit fills device memory with the schemes in the initializer package using a curand.Generator.
+//
+//Packed float tensors that use Constant or one of the uniform or normal methods are filled on the device.  The normal methods
+//also need an even number of elements, because that is what the curand pseudorandom generators need.
+//Everything else, like TruncatedNormal, Orthogonal, Identity, half tensors and tensors with padding, is made on the host
+//with initializer.Fill and the seeded rng of the Initializer and then copied to the device.
+package curandinit
+
+import (
+	"errors"
+	"math/rand"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/curand"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/initializer"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+//Desc is a descriptor that can be initialized.  *gocudnn.TensorD and *gocudnn.FilterD are both Descs.
+type Desc interface {
+	HostDesc() (tensorutil.Desc, error)
+	HostDataType() (tensorutil.DataType, error)
+}
+
+//Initializer fills weights.
+type Initializer struct {
+	h      *gocudnn.Handle
+	gen    *curand.Generator
+	rng    *rand.Rand
+	shift  *gocu.CudaPtr
+	shiftD *gocudnn.TensorD
+}
+
+//New makes an Initializer. The device path uses gen and h, and the host path uses a math/rand.Rand seeded with seed.
+func New(h *gocudnn.Handle, gen *curand.Generator, seed int64) (*Initializer, error) {
+	if h == nil || gen == nil {
+		return nil, errors.New("curandinit.New: nil handle or generator")
+	}
+	return &Initializer{h: h, gen: gen, rng: rand.New(rand.NewSource(seed))}, nil
+}
+
+//Fill fills mem, which d describes, using p.
+func (i *Initializer) Fill(d Desc, mem cutil.Mem, p initializer.Params) error {
+	hd, err := d.HostDesc()
+	if err != nil {
+		return err
+	}
+	dtype, err := d.HostDataType()
+	if err != nil {
+		return err
+	}
+	n := hd.Volume()
+	if dtype == tensorutil.Float && hd.Span() == n {
+		switch {
+		case p.Method == initializer.Constant, p.Method.Uniform(), p.Method.Normal() && n%2 == 0:
+			return i.filldevice(hd, mem, p)
+		}
+	}
+	return i.fillhost(hd, dtype, mem, p)
+}
+
+func (i *Initializer) filldevice(hd tensorutil.Desc, mem cutil.Mem, p initializer.Params) error {
+	scale, err := initializer.Scale(hd, p)
+	if err != nil {
+		return err
+	}
+	n := hd.Volume()
+	sib := uint(n * 4)
+	if p.Method.Normal() {
+		return i.gen.NormalFloat32(mem, sib, 0, float32(scale))
+	}
+	flatD, err := flat(n)
+	if err != nil {
+		return err
+	}
+	if p.Method == initializer.Constant {
+		return gocudnn.SetTensor(i.h, flatD, mem, scale)
+	}
+	//uniform in (0,1] to (-scale,scale]
+	if err = i.gen.UniformFloat32(mem, sib); err != nil {
+		return err
+	}
+	if err = gocudnn.ScaleTensor(i.h, flatD, mem, 2*scale); err != nil {
+		return err
+	}
+	if err = i.setshift(float32(-scale)); err != nil {
+		return err
+	}
+	return gocudnn.AddTensor(i.h, 1, i.shiftD, i.shift, 1, flatD, mem)
+}
+
+//setshift puts x in the one element tensor that is broadcast added for the uniform methods
+func (i *Initializer) setshift(x float32) error {
+	if i.shift == nil {
+		shiftD, err := flat(1)
+		if err != nil {
+			return err
+		}
+		shift := new(gocu.CudaPtr)
+		if err = cudart.MallocManagedGlobal(shift, 4); err != nil {
+			return err
+		}
+		i.shift, i.shiftD = shift, shiftD
+	}
+	return gocudnn.SetTensor(i.h, i.shiftD, i.shift, float64(x))
+}
+
+func (i *Initializer) fillhost(hd tensorutil.Desc, dtype tensorutil.DataType, mem cutil.Mem, p initializer.Params) error {
+	span := hd.Span()
+	sib := uint(span * dtype.SizeOf())
+	data, err := tensorutil.MakeSlice(dtype, span)
+	if err != nil {
+		return err
+	}
+	hptr, err := gocu.MakeGoMem(data)
+	if err != nil {
+		return err
+	}
+	var kind cudart.MemcpyKind
+	if span != hd.Volume() {
+		//keep what is in the padding
+		if err = cudart.Memcpy(hptr, mem, sib, kind.Default()); err != nil {
+			return err
+		}
+	}
+	if err = initializer.Fill(hd, data, p, i.rng); err != nil {
+		return err
+	}
+	return cudart.Memcpy(mem, hptr, sib, kind.Default())
+}
+
+//flat makes a packed float tensor with n elements
+func flat(n int) (*gocudnn.TensorD, error) {
+	d, err := gocudnn.CreateTensorDescriptor()
+	if err != nil {
+		return nil, err
+	}
+	var frmt gocudnn.TensorFormat
+	var dtype gocudnn.DataType
+	return d, d.Set(frmt.NCHW(), dtype.Float(), []int32{1, 1, 1, int32(n)}, nil)
+}
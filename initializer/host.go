@@ -0,0 +1,152 @@
+package initializer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/dereklstinson/half"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+//Fill fills data, which d describes, with p using rng. data can be []float32, []float64 or []half.Float16.
+//Elements that are only padding in the layout are left alone.
+func Fill(d tensorutil.Desc, data interface{}, p Params, rng *rand.Rand) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	if n := tensorutil.Len(data); n < d.Span() {
+		return fmt.Errorf("initializer: data has %d elements, the tensor needs %d", n, d.Span())
+	}
+	values, err := Values(d, p, rng)
+	if err != nil {
+		return err
+	}
+	i := 0
+	switch x := data.(type) {
+	case []float32:
+		d.Each(func(_ []int, off int) { x[off] = float32(values[i]); i++ })
+	case []float64:
+		d.Each(func(_ []int, off int) { x[off] = values[i]; i++ })
+	case []half.Float16:
+		d.Each(func(_ []int, off int) { x[off] = half.NewFloat16(float32(values[i])); i++ })
+	default:
+		return fmt.Errorf("initializer: unsupported data %T", data)
+	}
+	return nil
+}
+
+//Values returns the values of the tensor d describes in n,c,h,w,... order.
+func Values(d tensorutil.Desc, p Params, rng *rand.Rand) ([]float64, error) {
+	scale, err := Scale(d, p)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]float64, d.Volume())
+	switch {
+	case p.Method == Constant:
+		for i := range values {
+			values[i] = scale
+		}
+	case p.Method.Uniform():
+		for i := range values {
+			values[i] = (2*rng.Float64() - 1) * scale
+		}
+	case p.Method.Normal():
+		for i := range values {
+			values[i] = rng.NormFloat64() * scale
+		}
+	case p.Method == TruncatedNormal:
+		for i := range values {
+			z := rng.NormFloat64()
+			for math.Abs(z) > 2 {
+				z = rng.NormFloat64()
+			}
+			values[i] = p.Mean + z*scale
+		}
+	case p.Method == Identity:
+		if err = identity(d.LogicalDims(), p.groups(), scale, values); err != nil {
+			return nil, err
+		}
+	case p.Method == Orthogonal:
+		dims := d.LogicalDims()
+		orthogonal(dims[0], len(values)/dims[0], scale, rng, values)
+	default:
+		return nil, fmt.Errorf("initializer: unsupported %v", p.Method)
+	}
+	return values, nil
+}
+
+//identity puts gain at [g*kg+i][i][center...] for every group g and i < min(kg,c)
+func identity(dims []int, groups int, gain float64, values []float64) error {
+	if len(dims) == 1 {
+		return errors.New("initializer: Identity needs at least 2 dims")
+	}
+	k, c := dims[0], dims[1]
+	if k%groups != 0 {
+		return fmt.Errorf("initializer: %d groups don't divide %d filters", groups, k)
+	}
+	kg := k / groups
+	receptive, center := 1, 0
+	for _, x := range dims[2:] {
+		center = center*x + x/2
+		receptive *= x
+	}
+	for g := 0; g < groups; g++ {
+		for i := 0; i < kg && i < c; i++ {
+			values[((g*kg+i)*c+i)*receptive+center] = gain
+		}
+	}
+	return nil
+}
+
+//orthogonal fills values as a rows x cols matrix with orthonormal rows or columns, whichever there are fewer of.
+//The matrix is the Q of the QR of a normal matrix, done with Gram-Schmidt twice.
+func orthogonal(rows, cols int, gain float64, rng *rand.Rand, values []float64) {
+	m, n := rows, cols
+	if m < n {
+		m, n = n, m
+	}
+	//q is n columns of length m
+	q := make([][]float64, n)
+	for j := range q {
+		q[j] = make([]float64, m)
+		for {
+			for i := range q[j] {
+				q[j][i] = rng.NormFloat64()
+			}
+			for pass := 0; pass < 2; pass++ {
+				for l := 0; l < j; l++ {
+					dot := 0.0
+					for i := range q[j] {
+						dot += q[l][i] * q[j][i]
+					}
+					for i := range q[j] {
+						q[j][i] -= dot * q[l][i]
+					}
+				}
+			}
+			norm := 0.0
+			for _, x := range q[j] {
+				norm += x * x
+			}
+			norm = math.Sqrt(norm)
+			if norm > 1e-6 {
+				for i := range q[j] {
+					q[j][i] /= norm
+				}
+				break
+			}
+		}
+	}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if rows >= cols {
+				values[r*cols+c] = gain * q[c][r]
+			} else {
+				values[r*cols+c] = gain * q[r][c]
+			}
+		}
+	}
+}
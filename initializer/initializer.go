@@ -0,0 +1,180 @@
+//Package initializer has the weight initialization schemes that are used for filters and tensors.
+//
+//It works out the fan-in and fan-out from a tensorutil.Desc, and has a host path that fills a go slice with a seeded math/rand.Rand,
+//so everything can be tested without a gpu.  The curandinit package fills device memory with the same schemes using a curand.Generator.
+package initializer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+//Method is the initialization scheme.
+type Method int32
+
+//Methods that can be used in Params.
+const (
+	Constant        Method = iota //Every value is Value.
+	Identity                      //Gain on the diagonal, or in the center of the window for filters. Zero everywhere else.
+	XavierUniform                 //Uniform in [-b,b] with b = Gain*sqrt(6/(fanin+fanout)). Also called Glorot uniform.
+	XavierNormal                  //Normal with std = Gain*sqrt(2/(fanin+fanout)). Also called Glorot normal.
+	HeUniform                     //Uniform in [-b,b] with b = Gain*sqrt(6/fan). Also called Kaiming uniform.
+	HeNormal                      //Normal with std = Gain*sqrt(2/fan). Also called Kaiming normal.
+	LeCunUniform                  //Uniform in [-b,b] with b = Gain*sqrt(3/fan).
+	LeCunNormal                   //Normal with std = Gain*sqrt(1/fan).
+	TruncatedNormal               //Normal with Mean and std = Gain*Std, redrawn if it is more than 2 std from Mean.
+	Orthogonal                    //The tensor as a dims[0] x (volume/dims[0]) matrix has orthonormal rows or columns, times Gain.
+)
+
+func (m Method) String() string {
+	switch m {
+	case Constant:
+		return "Constant"
+	case Identity:
+		return "Identity"
+	case XavierUniform:
+		return "XavierUniform"
+	case XavierNormal:
+		return "XavierNormal"
+	case HeUniform:
+		return "HeUniform"
+	case HeNormal:
+		return "HeNormal"
+	case LeCunUniform:
+		return "LeCunUniform"
+	case LeCunNormal:
+		return "LeCunNormal"
+	case TruncatedNormal:
+		return "TruncatedNormal"
+	case Orthogonal:
+		return "Orthogonal"
+	}
+	return fmt.Sprintf("Method(%d)", int32(m))
+}
+
+//Uniform returns true if the method draws from a uniform distribution.
+func (m Method) Uniform() bool {
+	return m == XavierUniform || m == HeUniform || m == LeCunUniform
+}
+
+//Normal returns true if the method draws from a normal distribution that isn't truncated.
+func (m Method) Normal() bool {
+	return m == XavierNormal || m == HeNormal || m == LeCunNormal
+}
+
+//FanMode is the fan that the He and LeCun methods use.
+type FanMode int32
+
+//FanModes that can be used in Params
+const (
+	FanIn  FanMode = iota //Keeps the variance of the forward pass.
+	FanOut                //Keeps the variance of the backward pass.
+	FanAvg                //(fanin+fanout)/2
+)
+
+//Params are the params of an initialization.  The zero value of Gain and Groups is 1.
+type Params struct {
+	Method Method
+	Gain   float64
+	Mode   FanMode //Used by He and LeCun.
+	Value  float64 //Used by Constant.
+	Mean   float64 //Used by TruncatedNormal.
+	Std    float64 //Used by TruncatedNormal.
+	Groups int     //The group count of the convolution the filter is used in.
+	Deconv bool    //True if the filter is used for a deconvolution.
+}
+
+func (p Params) gain() float64 {
+	if p.Gain == 0 {
+		return 1
+	}
+	return p.Gain
+}
+
+func (p Params) groups() int {
+	if p.Groups < 1 {
+		return 1
+	}
+	return p.Groups
+}
+
+//Fans returns the fan-in and fan-out of a filter or a weight tensor.
+//
+//The logical dims (see tensorutil.Desc.LogicalDims) are k,c,spatial... the same as a cudnn filter.
+//For a convolution each output gets c*spatial inputs and each input goes to (k/groups)*spatial outputs.
+//A deconvolution runs the filter backwards so the two are swapped.  A tensor with one dim has fan-in and fan-out of dims[0].
+func Fans(d tensorutil.Desc, groups int, deconv bool) (fanin, fanout int, err error) {
+	if err = d.Validate(); err != nil {
+		return 0, 0, err
+	}
+	if groups < 1 {
+		groups = 1
+	}
+	dims := d.LogicalDims()
+	if len(dims) == 1 {
+		return dims[0], dims[0], nil
+	}
+	k, c := dims[0], dims[1]
+	if k%groups != 0 {
+		return 0, 0, fmt.Errorf("initializer: %d groups don't divide %d filters", groups, k)
+	}
+	receptive := 1
+	for _, x := range dims[2:] {
+		receptive *= x
+	}
+	fanin, fanout = c*receptive, (k/groups)*receptive
+	if deconv {
+		fanin, fanout = fanout, fanin
+	}
+	return fanin, fanout, nil
+}
+
+//Scale returns the number that describes the distribution of the method.
+//It is the bound b for the uniform methods, the std for the normal ones, Value for Constant, and Gain for Identity and Orthogonal.
+func Scale(d tensorutil.Desc, p Params) (float64, error) {
+	switch p.Method {
+	case Constant:
+		return p.Value, nil
+	case Identity, Orthogonal:
+		return p.gain(), nil
+	case TruncatedNormal:
+		if !(p.Std > 0) {
+			return 0, errors.New("initializer: TruncatedNormal needs Std > 0")
+		}
+		return p.gain() * p.Std, nil
+	}
+	fanin, fanout, err := Fans(d, p.groups(), p.Deconv)
+	if err != nil {
+		return 0, err
+	}
+	fan := float64(fanin)
+	switch p.Method {
+	case XavierUniform, XavierNormal:
+		fan = float64(fanin+fanout) / 2
+	default:
+		switch p.Mode {
+		case FanOut:
+			fan = float64(fanout)
+		case FanAvg:
+			fan = float64(fanin+fanout) / 2
+		}
+	}
+	switch p.Method {
+	case XavierUniform:
+		return p.gain() * math.Sqrt(3/fan), nil
+	case XavierNormal:
+		return p.gain() * math.Sqrt(1/fan), nil
+	case HeUniform:
+		return p.gain() * math.Sqrt(6/fan), nil
+	case HeNormal:
+		return p.gain() * math.Sqrt(2/fan), nil
+	case LeCunUniform:
+		return p.gain() * math.Sqrt(3/fan), nil
+	case LeCunNormal:
+		return p.gain() * math.Sqrt(1/fan), nil
+	}
+	return 0, fmt.Errorf("initializer: unsupported %v", p.Method)
+}
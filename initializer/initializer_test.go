@@ -0,0 +1,201 @@
+package initializer
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/dereklstinson/half"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+func filter(dims ...int32) tensorutil.Desc {
+	return tensorutil.Desc{Layout: tensorutil.NCHW, Dims: dims}
+}
+
+func TestFans(t *testing.T) {
+	tests := []struct {
+		d             tensorutil.Desc
+		groups        int
+		deconv        bool
+		fanin, fanout int
+	}{
+		{filter(16, 8, 3, 3), 1, false, 72, 144},
+		{filter(16, 8, 3, 3), 1, true, 144, 72},
+		{filter(16, 4, 3, 3), 4, false, 36, 36},
+		{filter(32, 64), 1, false, 64, 32},
+		{filter(10), 1, false, 10, 10},
+		{tensorutil.Desc{Layout: tensorutil.NHWC, Dims: []int32{16, 3, 3, 8}}, 1, false, 72, 144},
+	}
+	for i, x := range tests {
+		fanin, fanout, err := Fans(x.d, x.groups, x.deconv)
+		if err != nil || fanin != x.fanin || fanout != x.fanout {
+			t.Error(i, fanin, fanout, err)
+		}
+	}
+	if _, _, err := Fans(filter(10, 4, 3, 3), 3, false); err == nil {
+		t.Error("groups that don't divide k should fail")
+	}
+}
+
+func TestScale(t *testing.T) {
+	d := filter(16, 8, 3, 3) //fanin 72, fanout 144
+	tests := []struct {
+		p    Params
+		want float64
+	}{
+		{Params{Method: XavierUniform}, math.Sqrt(6.0 / 216)},
+		{Params{Method: XavierNormal, Gain: 2}, 2 * math.Sqrt(2.0/216)},
+		{Params{Method: HeUniform}, math.Sqrt(6.0 / 72)},
+		{Params{Method: HeNormal, Mode: FanOut}, math.Sqrt(2.0 / 144)},
+		{Params{Method: LeCunUniform}, math.Sqrt(3.0 / 72)},
+		{Params{Method: LeCunNormal, Mode: FanAvg}, math.Sqrt(1.0 / 108)},
+		{Params{Method: TruncatedNormal, Std: .02}, .02},
+		{Params{Method: Constant, Value: 3}, 3},
+		{Params{Method: Orthogonal}, 1},
+	}
+	for _, x := range tests {
+		got, err := Scale(d, x.p)
+		if err != nil || math.Abs(got-x.want) > 1e-12 {
+			t.Error(x.p.Method, got, x.want, err)
+		}
+	}
+	if _, err := Scale(d, Params{Method: TruncatedNormal}); err == nil {
+		t.Error("TruncatedNormal without Std should fail")
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := filter(256, 64, 3, 3)
+	for _, m := range []Method{XavierUniform, XavierNormal, HeUniform, HeNormal, LeCunUniform, LeCunNormal} {
+		p := Params{Method: m}
+		scale, _ := Scale(d, p)
+		values, err := Values(d, p, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var mean, sq float64
+		for _, v := range values {
+			if m.Uniform() && math.Abs(v) > scale {
+				t.Fatal(m, "out of bound", v, scale)
+			}
+			mean += v
+			sq += v * v
+		}
+		mean /= float64(len(values))
+		std := math.Sqrt(sq/float64(len(values)) - mean*mean)
+		want := scale
+		if m.Uniform() {
+			want = scale / math.Sqrt(3)
+		}
+		if math.Abs(mean) > .01*want || math.Abs(std-want) > .02*want {
+			t.Error(m, mean, std, want)
+		}
+	}
+}
+
+func TestTruncatedNormal(t *testing.T) {
+	values, err := Values(filter(1000), Params{Method: TruncatedNormal, Mean: 1, Std: .5}, rand.New(rand.NewSource(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range values {
+		if v < 0 || v > 2 {
+			t.Fatal(v)
+		}
+	}
+}
+
+func TestOrthogonal(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	for _, d := range []tensorutil.Desc{filter(4, 2, 3), filter(8, 2), filter(3, 3)} {
+		values, err := Values(d, Params{Method: Orthogonal, Gain: 2}, rng)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows := int(d.Dims[0])
+		cols := len(values) / rows
+		//the smaller side is orthogonal with norm gain
+		n, stride, step := rows, cols, 1
+		if rows > cols {
+			n, stride, step = cols, 1, cols
+		}
+		length := len(values) / n
+		for a := 0; a < n; a++ {
+			for b := 0; b < n; b++ {
+				dot := 0.0
+				for i := 0; i < length; i++ {
+					dot += values[a*stride+i*step] * values[b*stride+i*step]
+				}
+				want := 0.0
+				if a == b {
+					want = 4
+				}
+				if math.Abs(dot-want) > 1e-9 {
+					t.Error(d.Dims, a, b, dot)
+				}
+			}
+		}
+	}
+}
+
+func TestIdentity(t *testing.T) {
+	d := filter(4, 2, 3, 3)
+	values, err := Values(d, Params{Method: Identity, Groups: 2}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	//2 groups of 2 filters that each see 2 channels
+	if sum != 4 {
+		t.Error(sum)
+	}
+	for k := 0; k < 4; k++ {
+		c := k % 2
+		if v := values[d.Offset([]int{k, c, 1, 1})]; v != 1 {
+			t.Error(k, c, v)
+		}
+	}
+	m, _ := Values(filter(2, 3), Params{Method: Identity}, nil)
+	if m[0] != 1 || m[4] != 1 || m[1] != 0 {
+		t.Error(m)
+	}
+}
+
+func TestFill(t *testing.T) {
+	//same seed gives the same values in every layout and type
+	nchw := filter(2, 3, 2, 2)
+	nhwc := tensorutil.Desc{Layout: tensorutil.NHWC, Dims: []int32{2, 2, 2, 3}}
+	p := Params{Method: HeNormal}
+	a := make([]float32, nchw.Span())
+	b := make([]float32, nhwc.Span())
+	c := make([]half.Float16, nchw.Span())
+	if err := Fill(nchw, a, p, rand.New(rand.NewSource(4))); err != nil {
+		t.Fatal(err)
+	}
+	if err := Fill(nhwc, b, p, rand.New(rand.NewSource(4))); err != nil {
+		t.Fatal(err)
+	}
+	if err := Fill(nchw, c, p, rand.New(rand.NewSource(4))); err != nil {
+		t.Fatal(err)
+	}
+	got, err := tensorutil.Relayout(nhwc, b, nchw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range a {
+		if got.([]float32)[i] != a[i] || math.Abs(float64(c[i].Float32()-a[i])) > 1e-2 {
+			t.Error(i, a[i], got.([]float32)[i], c[i])
+		}
+	}
+	if err := Fill(nchw, make([]float32, 3), p, rand.New(rand.NewSource(4))); err == nil {
+		t.Error("short data should fail")
+	}
+	if err := Fill(nchw, make([]int32, nchw.Span()), p, rand.New(rand.NewSource(4))); err == nil {
+		t.Error("int data should fail")
+	}
+}
@@ -0,0 +1,89 @@
+//Command kernelbuild compiles .cu files for a list of architectures and writes the manifest of the bundles.
+//
+//It only compiles the outputs that changed since the last build.  With no files it builds every .cu file in the current directory.
+//
+//	//go:generate go run ./kernelbuild/cmd/kernelbuild -o ../xtra/bundle -arch 61,75 gocudnnxtra.cu
+//
+//-compiler nvrtc needs kernelbuild to be built with -tags nvrtc.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/negativeOne1/gocudnn/kernels/kernelbuild"
+	"github.com/negativeOne1/gocudnn/xtra/bundle"
+)
+
+func main() {
+	out := flag.String("o", "../xtra/bundle", "output directory")
+	archs := flag.String("arch", "61,75", "comma separated compute capabilities")
+	format := flag.String("format", "ptx", "ptx, cubin or fatbin")
+	compiler := flag.String("compiler", "nvcc", "nvcc or nvrtc")
+	nvcc := flag.String("nvcc", "nvcc", "path to nvcc")
+	includes := flag.String("I", "", "comma separated include directories")
+	jobs := flag.Int("j", 0, "compiles that run at the same time. The number of cpus if 0")
+	force := flag.Bool("f", false, "build everything even if it is up to date")
+	manifest := flag.Bool("manifest", true, "write manifest.json in the output directory")
+	verbose := flag.Bool("v", false, "print every compile")
+	flag.Parse()
+	if err := run(*out, *archs, *format, *compiler, *nvcc, *includes, *jobs, *force, *manifest, *verbose, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "kernelbuild:", err)
+		os.Exit(1)
+	}
+}
+
+func run(out, archs, format, compiler, nvcc, includes string, jobs int, force, manifest, verbose bool, sources []string) error {
+	a, err := kernelbuild.ParseArchs(archs)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		sources, err = filepath.Glob("*.cu")
+		if err != nil {
+			return err
+		}
+		if len(sources) == 0 {
+			return fmt.Errorf("no .cu files")
+		}
+	}
+	var c kernelbuild.Compiler
+	switch compiler {
+	case "nvcc":
+		c = kernelbuild.NVCC{Path: nvcc}
+	case "nvrtc":
+		if c, err = kernelbuild.NewNVRTC(); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown compiler %q", compiler)
+	}
+	cfg := kernelbuild.Config{
+		Sources:  sources,
+		Out:      out,
+		Archs:    a,
+		Format:   bundle.Format(format),
+		Jobs:     jobs,
+		Force:    force,
+		Manifest: manifest,
+	}
+	if includes != "" {
+		cfg.Includes = strings.Split(includes, ",")
+	}
+	if verbose {
+		cfg.Log = os.Stderr
+	}
+	res, err := kernelbuild.Build(c, cfg)
+	if res != nil && verbose {
+		report(os.Stderr, res)
+	}
+	return err
+}
+
+func report(w io.Writer, res *kernelbuild.Result) {
+	fmt.Fprintf(w, "%d built, %d up to date\n", len(res.Built), len(res.UpToDate))
+}
@@ -0,0 +1,99 @@
+package kernelbuild
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/negativeOne1/gocudnn/xtra/bundle"
+)
+
+//Job is one compile of a .cu file.
+type Job struct {
+	Source   string        //The .cu file
+	Output   string        //The file that is written
+	Format   bundle.Format //PTX and Cubin jobs have one arch.  Fatbin jobs have all of them.
+	Archs    []Arch
+	Includes []string //Include directories
+	Options  []string //Extra options passed to the compiler
+}
+
+//Compiler compiles jobs.  Version is part of the key of every output, so outputs are built again when the compiler changes.
+type Compiler interface {
+	Version() (string, error)
+	Compile(j Job) error
+}
+
+//NVCC compiles with nvcc.
+type NVCC struct {
+	Path string   //nvcc if empty
+	Env  []string //Added to the environment of nvcc
+}
+
+func (n NVCC) path() string {
+	if n.Path == "" {
+		return "nvcc"
+	}
+	return n.Path
+}
+
+func (n NVCC) run(args ...string) ([]byte, error) {
+	cmd := exec.Command(n.path(), args...)
+	cmd.Env = append(os.Environ(), n.Env...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return out, fmt.Errorf("%s %s: %v\n%s", n.path(), strings.Join(args, " "), err, stderr.String())
+	}
+	return out, nil
+}
+
+//Version returns the last line of nvcc --version, like "Build cuda_11.8.r11.8/compiler.31833905_0".
+func (n NVCC) Version() (string, error) {
+	out, err := n.run("--version")
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1]), nil
+}
+
+//Compile runs nvcc for j.
+func (n NVCC) Compile(j Job) error {
+	args, err := NVCCArgs(j)
+	if err != nil {
+		return err
+	}
+	_, err = n.run(args...)
+	return err
+}
+
+//NVCCArgs returns the nvcc args for j.  A fatbin gets a cubin and ptx for every arch, so it also runs on devices newer than all of them.
+func NVCCArgs(j Job) ([]string, error) {
+	if len(j.Archs) == 0 {
+		return nil, fmt.Errorf("kernelbuild: %s has no arch", j.Output)
+	}
+	var args []string
+	switch j.Format {
+	case bundle.PTX:
+		args = append(args, "--gpu-architecture="+j.Archs[0].Compute(), "--gpu-code="+j.Archs[0].Compute(), "--ptx")
+	case bundle.Cubin:
+		args = append(args, "--gpu-architecture="+j.Archs[0].Compute(), "--gpu-code="+j.Archs[0].String(), "--cubin")
+	case bundle.Fatbin:
+		for _, a := range j.Archs {
+			args = append(args, "--generate-code=arch="+a.Compute()+",code=["+a.String()+","+a.Compute()+"]")
+		}
+		args = append(args, "--fatbin")
+	default:
+		return nil, fmt.Errorf("kernelbuild: unknown format %q", j.Format)
+	}
+	for _, inc := range j.Includes {
+		args = append(args, "-I"+inc)
+	}
+	args = append(args, j.Options...)
+	args = append(args, "-o", j.Output, j.Source)
+	return args, nil
+}
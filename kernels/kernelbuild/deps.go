@@ -0,0 +1,53 @@
+package kernelbuild
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+)
+
+var includere = regexp.MustCompile(`(?m)^\s*#\s*include\s*"([^"]+)"`)
+
+//Dependencies returns the files that src includes with #include "file", and the files they include, sorted.
+//A file is looked for next to the file that includes it and then in includes.
+//Includes with <> are system headers and aren't followed, and neither are includes that can't be found.
+func Dependencies(src string, includes []string) ([]string, error) {
+	seen := map[string]bool{}
+	var deps []string
+	var walk func(file string) error
+	walk = func(file string) error {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		for _, m := range includere.FindAllSubmatch(b, -1) {
+			dep, ok := findinclude(string(m[1]), filepath.Dir(file), includes)
+			if !ok || seen[dep] {
+				continue
+			}
+			seen[dep] = true
+			deps = append(deps, dep)
+			if err = walk(dep); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	seen[filepath.Clean(src)] = true
+	if err := walk(src); err != nil {
+		return nil, err
+	}
+	sort.Strings(deps)
+	return deps, nil
+}
+
+func findinclude(name, dir string, includes []string) (string, bool) {
+	for _, d := range append([]string{dir}, includes...) {
+		p := filepath.Clean(filepath.Join(d, name))
+		if fi, err := os.Stat(p); err == nil && !fi.IsDir() {
+			return p, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,341 @@
+//Package kernelbuild compiles the .cu files in kernels for a list of architectures and writes the bundle manifest that xtra/bundle embeds.
+//
+//Every output has a key that is a hash of the compiler version, the options, the source and the files it includes with #include "file".
+//The keys are kept in .kernelbuild.json in the output directory, and an output is only compiled again when its key changes or it is missing.
+//
+//	res, err := kernelbuild.Build(kernelbuild.NVCC{}, kernelbuild.Config{
+//		Sources:  []string{"gocudnnxtra.cu"},
+//		Out:      "../xtra/bundle",
+//		Archs:    []kernelbuild.Arch{{6, 1}, {7, 5}},
+//		Format:   bundle.PTX,
+//		Manifest: true,
+//	})
+//
+//The compiler is an interface so nvcc, nvrtc (with the nvrtc build tag) or a fake can be used.
+package kernelbuild
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/negativeOne1/gocudnn/xtra/bundle"
+)
+
+//Arch is a compute capability.
+type Arch struct {
+	Major, Minor int
+}
+
+//String returns the real architecture, like sm_75.
+func (a Arch) String() string { return fmt.Sprintf("sm_%d%d", a.Major, a.Minor) }
+
+//Compute returns the virtual architecture, like compute_75.
+func (a Arch) Compute() string { return fmt.Sprintf("compute_%d%d", a.Major, a.Minor) }
+
+//ParseArchs parses a comma separated list like "61,75" or "sm_61,sm_75".
+func ParseArchs(s string) ([]Arch, error) {
+	var archs []Arch
+	for _, f := range strings.Split(s, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !strings.HasPrefix(f, "sm_") && !strings.HasPrefix(f, "compute_") {
+			f = "sm_" + f
+		}
+		major, minor, err := bundle.ParseArch(f)
+		if err != nil {
+			return nil, fmt.Errorf("kernelbuild: %v", err)
+		}
+		archs = append(archs, Arch{major, minor})
+	}
+	if len(archs) == 0 {
+		return nil, errors.New("kernelbuild: no archs")
+	}
+	return archs, nil
+}
+
+//Config is what Build builds.
+type Config struct {
+	Sources  []string      //.cu files
+	Out      string        //Output directory.  Outputs go in Out/ptx/sm_XX, Out/cubin/sm_XX or Out/fatbin.
+	Archs    []Arch        //Architectures to build for
+	Format   bundle.Format //PTX if empty
+	Includes []string      //Include directories
+	Options  []string      //Extra compiler options
+	Jobs     int           //Compiles that run at the same time. runtime.NumCPU() if < 1.
+	Force    bool          //Build everything even if it is up to date
+	Manifest bool          //Write Out/manifest.json
+	Log      io.Writer     //Gets a line for every compile if not nil
+}
+
+//Result is what Build did.  The paths are relative to Config.Out.
+type Result struct {
+	Built    []string
+	UpToDate []string
+	Manifest *bundle.Manifest //nil if Config.Manifest is false
+}
+
+type plan struct {
+	Job
+	rel    string
+	source string
+	key    string
+}
+
+//Build compiles the outputs of cfg that aren't up to date with c.  If some of the compiles fail the rest are still built and kept,
+//and the errors are returned together.  The manifest isn't written if there was an error.
+func Build(c Compiler, cfg Config) (*Result, error) {
+	if len(cfg.Sources) == 0 {
+		return nil, errors.New("kernelbuild: no sources")
+	}
+	if len(cfg.Archs) == 0 {
+		return nil, errors.New("kernelbuild: no archs")
+	}
+	if cfg.Format == "" {
+		cfg.Format = bundle.PTX
+	}
+	if cfg.Jobs < 1 {
+		cfg.Jobs = runtime.NumCPU()
+	}
+	plans, err := makeplans(cfg)
+	if err != nil {
+		return nil, err
+	}
+	version, err := c.Version()
+	if err != nil {
+		return nil, err
+	}
+	deps := make(map[string][]string)
+	for _, src := range cfg.Sources {
+		if _, ok := deps[src]; ok {
+			continue
+		}
+		d, err := Dependencies(src, cfg.Includes)
+		if err != nil {
+			return nil, err
+		}
+		deps[src] = append([]string{src}, d...)
+	}
+	st, err := loadstate(cfg.Out)
+	if err != nil {
+		return nil, err
+	}
+	res := new(Result)
+	var todo []*plan
+	for _, p := range plans {
+		p.key, err = key(version, p.Job, deps[p.source])
+		if err != nil {
+			return nil, err
+		}
+		if !cfg.Force && st.Outputs[p.rel] == p.key && exists(p.Output) {
+			res.UpToDate = append(res.UpToDate, p.rel)
+			continue
+		}
+		todo = append(todo, p)
+	}
+	var (
+		mu   sync.Mutex
+		errs []error
+		wg   sync.WaitGroup
+		sem  = make(chan struct{}, cfg.Jobs)
+	)
+	for _, p := range todo {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(p *plan) {
+			defer func() { <-sem; wg.Done() }()
+			if cfg.Log != nil {
+				mu.Lock()
+				fmt.Fprintf(cfg.Log, "%s -> %s\n", p.source, p.rel)
+				mu.Unlock()
+			}
+			err := compile(c, p)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				delete(st.Outputs, p.rel)
+				errs = append(errs, fmt.Errorf("%s: %v", p.rel, err))
+				return
+			}
+			st.Outputs[p.rel] = p.key
+			res.Built = append(res.Built, p.rel)
+		}(p)
+	}
+	wg.Wait()
+	sort.Strings(res.Built)
+	if err = st.save(cfg.Out); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return res, errors.Join(errs...)
+	}
+	if cfg.Manifest {
+		res.Manifest, err = writemanifest(cfg.Out, plans)
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+func makeplans(cfg Config) ([]*plan, error) {
+	var ext string
+	switch cfg.Format {
+	case bundle.PTX:
+		ext = ".ptx"
+	case bundle.Cubin:
+		ext = ".cubin"
+	case bundle.Fatbin:
+		ext = ".fatbin"
+	default:
+		return nil, fmt.Errorf("kernelbuild: unknown format %q", cfg.Format)
+	}
+	archs := append([]Arch(nil), cfg.Archs...)
+	sort.Slice(archs, func(i, j int) bool {
+		return archs[i].Major < archs[j].Major || (archs[i].Major == archs[j].Major && archs[i].Minor < archs[j].Minor)
+	})
+	bases := make(map[string]string)
+	var plans []*plan
+	for _, src := range cfg.Sources {
+		base := strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
+		if other, ok := bases[base]; ok {
+			return nil, fmt.Errorf("kernelbuild: %s and %s have the same output name", other, src)
+		}
+		bases[base] = src
+		add := func(rel string, archs []Arch) {
+			plans = append(plans, &plan{
+				Job: Job{
+					Source:   src,
+					Output:   filepath.Join(cfg.Out, filepath.FromSlash(rel)),
+					Format:   cfg.Format,
+					Archs:    archs,
+					Includes: cfg.Includes,
+					Options:  cfg.Options,
+				},
+				rel:    rel,
+				source: src,
+			})
+		}
+		if cfg.Format == bundle.Fatbin {
+			add("fatbin/"+base+ext, archs)
+			continue
+		}
+		for _, a := range archs {
+			add(string(cfg.Format)+"/"+a.String()+"/"+base+ext, []Arch{a})
+		}
+	}
+	return plans, nil
+}
+
+//compile compiles to a temp file next to the output and renames it, so a failed compile doesn't leave half an output.
+func compile(c Compiler, p *plan) error {
+	dir := filepath.Dir(p.Output)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	j := p.Job
+	j.Output = filepath.Join(dir, ".tmp-"+filepath.Base(p.Output))
+	defer os.Remove(j.Output)
+	if err := c.Compile(j); err != nil {
+		return err
+	}
+	if !exists(j.Output) {
+		return errors.New("compiler didn't write the output")
+	}
+	return os.Rename(j.Output, p.Output)
+}
+
+func exists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
+
+var kernelre = regexp.MustCompile(`extern\s+"C"\s+__global__\s+void\s+(\w+)\s*\(`)
+
+//KernelNames returns the names of the extern "C" __global__ functions in cuda source, sorted.
+func KernelNames(src []byte) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range kernelre.FindAllSubmatch(src, -1) {
+		if n := string(m[1]); !seen[n] {
+			seen[n] = true
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+//writemanifest writes Out/manifest.json with the outputs of plans.  Bundles already in the manifest that aren't outputs of plans are kept if their files are still there.
+func writemanifest(out string, plans []*plan) (*bundle.Manifest, error) {
+	name := filepath.Join(out, bundle.ManifestFile)
+	var m bundle.Manifest
+	ours := make(map[string]bool)
+	for _, p := range plans {
+		ours[p.rel] = true
+	}
+	var old bundle.Manifest
+	if data, err := os.ReadFile(name); err == nil && json.Unmarshal(data, &old) == nil {
+		for _, b := range old.Bundles {
+			if !ours[b.File] && exists(filepath.Join(out, filepath.FromSlash(b.File))) {
+				m.Bundles = append(m.Bundles, b)
+			}
+		}
+	}
+	for _, p := range plans {
+		b, err := describe(p)
+		if err != nil {
+			return nil, err
+		}
+		m.Bundles = append(m.Bundles, b)
+	}
+	sort.SliceStable(m.Bundles, func(i, j int) bool { return m.Bundles[i].File < m.Bundles[j].File })
+	data, err := m.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	if old, err := os.ReadFile(name); err == nil && string(old) == string(data) {
+		return &m, nil
+	}
+	if err = writeatomic(name, data); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func describe(p *plan) (bundle.Bundle, error) {
+	if p.Format == bundle.PTX {
+		data, err := os.ReadFile(p.Output)
+		if err != nil {
+			return bundle.Bundle{}, err
+		}
+		return bundle.PTXBundle(p.rel, data)
+	}
+	src, err := os.ReadFile(p.source)
+	if err != nil {
+		return bundle.Bundle{}, err
+	}
+	base := strings.TrimSuffix(filepath.Base(p.source), filepath.Ext(p.source))
+	b := bundle.Bundle{
+		Name:    base + "_" + p.Archs[0].String(),
+		File:    p.rel,
+		Format:  p.Format,
+		Arch:    p.Archs[0].String(),
+		Kernels: KernelNames(src),
+	}
+	if p.Format == bundle.Fatbin {
+		b.Name = base + "_fatbin"
+	}
+	b.DataTypes = bundle.DataTypes(b.Kernels)
+	return b, nil
+}
@@ -0,0 +1,348 @@
+package kernelbuild
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/negativeOne1/gocudnn/xtra/bundle"
+)
+
+//When the test binary is run with fakenvccenv set it acts like nvcc.  It writes ptx with an entry for every kernel in the source,
+//appends its args to the file in fakenvcclog, and fails if the source has #error in it.
+const (
+	fakenvccenv = "KERNELBUILD_FAKE_NVCC"
+	fakenvcclog = "KERNELBUILD_FAKE_NVCC_LOG"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv(fakenvccenv) == "1" {
+		if err := fakenvcc(os.Args[1:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func fakenvcc(args []string) error {
+	if len(args) == 1 && args[0] == "--version" {
+		fmt.Println("nvcc: fake\nBuild fake_1.0")
+		return nil
+	}
+	if log := os.Getenv(fakenvcclog); log != "" {
+		f, err := os.OpenFile(log, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(f, strings.Join(args, " "))
+		f.Close()
+	}
+	var out, arch string
+	for i, a := range args {
+		if a == "-o" && i+1 < len(args) {
+			out = args[i+1]
+		}
+		if strings.HasPrefix(a, "--gpu-architecture=compute_") {
+			arch = strings.TrimPrefix(a, "--gpu-architecture=compute_")
+		}
+	}
+	src, err := os.ReadFile(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+	if strings.Contains(string(src), "#error") {
+		return errors.New("fake nvcc: #error")
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, ".version 6.4\n.target sm_%s\n.address_size 64\n\n", arch)
+	for _, k := range KernelNames(src) {
+		fmt.Fprintf(&b, ".visible .entry %s(\n\t.param .u32 %s_param_0\n)\n{\n\tret;\n}\n\n", k, k)
+	}
+	return os.WriteFile(out, []byte(b.String()), 0644)
+}
+
+type testtree struct {
+	t   *testing.T
+	dir string
+	log string
+}
+
+func newtree(t *testing.T) *testtree {
+	dir := t.TempDir()
+	tr := &testtree{t: t, dir: dir, log: filepath.Join(dir, "nvcc.log")}
+	tr.write("src/common.h", "#define X 1\n")
+	tr.write("src/inner.h", "#include \"common.h\"\n")
+	tr.write("src/a.cu", "#include <cuda.h>\n#include \"inner.h\"\nextern \"C\" __global__ void Add(int n){}\nextern \"C\" __global__ void AddFP16(int n){}\n")
+	tr.write("src/b.cu", "#include <cuda.h>\nextern \"C\" __global__ void Scale(int n){}\n")
+	return tr
+}
+
+func (tr *testtree) write(name, data string) {
+	p := filepath.Join(tr.dir, name)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		tr.t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(data), 0644); err != nil {
+		tr.t.Fatal(err)
+	}
+}
+
+func (tr *testtree) compiler() NVCC {
+	exe, err := os.Executable()
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+	return NVCC{Path: exe, Env: []string{fakenvccenv + "=1", fakenvcclog + "=" + tr.log}}
+}
+
+func (tr *testtree) config() Config {
+	return Config{
+		Sources:  []string{filepath.Join(tr.dir, "src/a.cu"), filepath.Join(tr.dir, "src/b.cu")},
+		Out:      filepath.Join(tr.dir, "out"),
+		Archs:    []Arch{{7, 5}, {6, 1}},
+		Jobs:     2,
+		Manifest: true,
+	}
+}
+
+//compiles returns how many times nvcc compiled since the last call.
+func (tr *testtree) compiles() int {
+	b, err := os.ReadFile(tr.log)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0
+	}
+	if err != nil {
+		tr.t.Fatal(err)
+	}
+	os.Remove(tr.log)
+	return strings.Count(string(b), "\n")
+}
+
+func TestBuildIncremental(t *testing.T) {
+	tr := newtree(t)
+	c := tr.compiler()
+	cfg := tr.config()
+	res, err := Build(c, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"ptx/sm_61/a.ptx", "ptx/sm_61/b.ptx", "ptx/sm_75/a.ptx", "ptx/sm_75/b.ptx"}
+	if !reflect.DeepEqual(res.Built, want) || len(res.UpToDate) != 0 {
+		t.Error(res.Built, res.UpToDate)
+	}
+	if n := tr.compiles(); n != 4 {
+		t.Error("compiles", n)
+	}
+	if res, err = Build(c, cfg); err != nil || len(res.Built) != 0 || len(res.UpToDate) != 4 {
+		t.Error("second build", res.Built, res.UpToDate, err)
+	}
+	if n := tr.compiles(); n != 0 {
+		t.Error("second build compiles", n)
+	}
+
+	//A header two includes down only rebuilds the source that includes it.
+	tr.write("src/common.h", "#define X 2\n")
+	if res, err = Build(c, cfg); err != nil || !reflect.DeepEqual(res.Built, []string{"ptx/sm_61/a.ptx", "ptx/sm_75/a.ptx"}) {
+		t.Error("header change", res.Built, err)
+	}
+
+	os.Remove(filepath.Join(cfg.Out, "ptx/sm_75/b.ptx"))
+	if res, err = Build(c, cfg); err != nil || !reflect.DeepEqual(res.Built, []string{"ptx/sm_75/b.ptx"}) {
+		t.Error("missing output", res.Built, err)
+	}
+
+	cfg.Options = []string{"--use_fast_math"}
+	if res, err = Build(c, cfg); err != nil || len(res.Built) != 4 {
+		t.Error("option change", res.Built, err)
+	}
+	cfg.Force = true
+	if res, err = Build(c, cfg); err != nil || len(res.Built) != 4 {
+		t.Error("force", res.Built, err)
+	}
+	tr.compiles()
+	cfg.Force = false
+	cfg.Archs = append(cfg.Archs, Arch{8, 6})
+	if res, err = Build(c, cfg); err != nil || !reflect.DeepEqual(res.Built, []string{"ptx/sm_86/a.ptx", "ptx/sm_86/b.ptx"}) {
+		t.Error("new arch", res.Built, err)
+	}
+}
+
+func TestBuildManifest(t *testing.T) {
+	tr := newtree(t)
+	cfg := tr.config()
+	//A bundle that was already in the manifest is kept if its file is still there, and dropped if it isn't.
+	tr.write("out/ptx/sm_52/old.ptx", ".version 6.4\n.target sm_52\n.visible .entry Old(\n)\n{\n\tret;\n}\n")
+	tr.write("out/manifest.json", `{"bundles":[
+		{"name":"old_sm_52","file":"ptx/sm_52/old.ptx","format":"ptx","arch":"sm_52","kernels":["Old"]},
+		{"name":"gone_sm_52","file":"ptx/sm_52/gone.ptx","format":"ptx","arch":"sm_52","kernels":["Gone"]}]}`)
+	res, err := Build(tr.compiler(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, b := range res.Manifest.Bundles {
+		names = append(names, b.Name)
+	}
+	if want := []string{"old_sm_52", "a_sm_61", "b_sm_61", "a_sm_75", "b_sm_75"}; !reflect.DeepEqual(names, want) {
+		t.Error(names, want)
+	}
+	r, err := bundle.New(os.DirFS(cfg.Out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := r.Select(7, 5, bundle.Float, bundle.Half)
+	if err != nil || b.Name != "a_sm_75" {
+		t.Error(b, err)
+	}
+	b, err = r.Lookup("Scale", 8, 6)
+	if err != nil || b.Name != "b_sm_75" {
+		t.Error(b, err)
+	}
+	if b, err = r.Lookup("Old", 6, 1); err != nil || b.Name != "old_sm_52" {
+		t.Error(b, err)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tr := newtree(t)
+	cfg := tr.config()
+	tr.write("src/b.cu", "#error nope\n")
+	res, err := Build(tr.compiler(), cfg)
+	if err == nil || !strings.Contains(err.Error(), "ptx/sm_61/b.ptx") || !strings.Contains(err.Error(), "ptx/sm_75/b.ptx") {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Built, []string{"ptx/sm_61/a.ptx", "ptx/sm_75/a.ptx"}) {
+		t.Error(res.Built)
+	}
+	if _, err := os.Stat(filepath.Join(cfg.Out, bundle.ManifestFile)); err == nil {
+		t.Error("manifest written after an error")
+	}
+	matches, _ := filepath.Glob(filepath.Join(cfg.Out, "ptx/*/.tmp-*"))
+	if len(matches) > 0 {
+		t.Error("temp files left", matches)
+	}
+	tr.compiles()
+	tr.write("src/b.cu", "extern \"C\" __global__ void Scale(int n){}\n")
+	if res, err = Build(tr.compiler(), cfg); err != nil || !reflect.DeepEqual(res.Built, []string{"ptx/sm_61/b.ptx", "ptx/sm_75/b.ptx"}) {
+		t.Error(res.Built, err)
+	}
+
+	for _, bad := range []Config{
+		{Archs: cfg.Archs, Out: cfg.Out},
+		{Sources: cfg.Sources, Out: cfg.Out},
+		{Sources: cfg.Sources, Archs: cfg.Archs, Out: cfg.Out, Format: "elf"},
+		{Sources: []string{"x/a.cu", "y/a.cu"}, Archs: cfg.Archs, Out: cfg.Out},
+	} {
+		if _, err := Build(tr.compiler(), bad); err == nil {
+			t.Error("no error for", bad)
+		}
+	}
+}
+
+func TestNVCCArgs(t *testing.T) {
+	j := Job{Source: "a.cu", Output: "a.out", Archs: []Arch{{6, 1}, {7, 5}}, Includes: []string{"inc"}, Options: []string{"-lineinfo"}}
+	tests := map[bundle.Format]string{
+		bundle.PTX:    "--gpu-architecture=compute_61 --gpu-code=compute_61 --ptx -Iinc -lineinfo -o a.out a.cu",
+		bundle.Cubin:  "--gpu-architecture=compute_61 --gpu-code=sm_61 --cubin -Iinc -lineinfo -o a.out a.cu",
+		bundle.Fatbin: "--generate-code=arch=compute_61,code=[sm_61,compute_61] --generate-code=arch=compute_75,code=[sm_75,compute_75] --fatbin -Iinc -lineinfo -o a.out a.cu",
+	}
+	for f, want := range tests {
+		j.Format = f
+		args, err := NVCCArgs(j)
+		if err != nil || strings.Join(args, " ") != want {
+			t.Error(f, args, err)
+		}
+	}
+	j.Format = "elf"
+	if _, err := NVCCArgs(j); err == nil {
+		t.Error("no error for unknown format")
+	}
+}
+
+func TestNVCCVersion(t *testing.T) {
+	tr := newtree(t)
+	v, err := tr.compiler().Version()
+	if err != nil || v != "Build fake_1.0" {
+		t.Error(v, err)
+	}
+}
+
+func TestBuildFatbin(t *testing.T) {
+	tr := newtree(t)
+	cfg := tr.config()
+	cfg.Format = bundle.Fatbin
+	res, err := Build(tr.compiler(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(res.Built, []string{"fatbin/a.fatbin", "fatbin/b.fatbin"}) {
+		t.Error(res.Built)
+	}
+	b, _ := os.ReadFile(tr.log)
+	if !regexp.MustCompile(`compute_61.*compute_75.*--fatbin`).Match(b) {
+		t.Error(string(b))
+	}
+	m := res.Manifest.Bundles
+	if len(m) != 2 || m[0].Name != "a_fatbin" || m[0].Arch != "sm_61" || m[0].Format != bundle.Fatbin || !reflect.DeepEqual(m[0].Kernels, []string{"Add", "AddFP16"}) {
+		t.Error(m)
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	tr := newtree(t)
+	tr.write("inc/extra.h", "#include \"common.h\"\n#include \"missing.h\"\n")
+	tr.write("src/c.cu", "#include \"extra.h\"\n#include \"c.cu\"\n")
+	deps, err := Dependencies(filepath.Join(tr.dir, "src/c.cu"), []string{filepath.Join(tr.dir, "inc")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(tr.dir, "inc/extra.h")}
+	if !reflect.DeepEqual(deps, want) {
+		t.Error(deps, want)
+	}
+	deps, err = Dependencies(filepath.Join(tr.dir, "src/a.cu"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []string{filepath.Join(tr.dir, "src/common.h"), filepath.Join(tr.dir, "src/inner.h")}
+	if !reflect.DeepEqual(deps, want) {
+		t.Error(deps, want)
+	}
+}
+
+func TestParseArchs(t *testing.T) {
+	archs, err := ParseArchs("61, sm_75,compute_86")
+	if err != nil || !reflect.DeepEqual(archs, []Arch{{6, 1}, {7, 5}, {8, 6}}) {
+		t.Error(archs, err)
+	}
+	for _, bad := range []string{"", "x", "sm_"} {
+		if _, err := ParseArchs(bad); err == nil {
+			t.Error("no error for", bad)
+		}
+	}
+}
+
+func TestKernelNames(t *testing.T) {
+	b, err := os.ReadFile("../gocudnnxtra.cu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := KernelNames(b)
+	if len(names) == 0 || names[0] > names[len(names)-1] {
+		t.Fatal(names)
+	}
+	found := false
+	for _, n := range names {
+		found = found || n == "Transpose"
+	}
+	if !found {
+		t.Error("Transpose not found")
+	}
+}
@@ -0,0 +1,72 @@
+//go:build nvrtc
+// +build nvrtc
+
+package kernelbuild
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/negativeOne1/gocudnn/nvrtc"
+	"github.com/negativeOne1/gocudnn/nvrtc/nvrtcbuild"
+	"github.com/negativeOne1/gocudnn/xtra/bundle"
+)
+
+//NVRTC compiles with nvrtc.  It can only make ptx.
+type NVRTC struct{}
+
+//NewNVRTC returns the nvrtc compiler.
+func NewNVRTC() (Compiler, error) {
+	return NVRTC{}, nil
+}
+
+//Version returns the nvrtc version.
+func (NVRTC) Version() (string, error) {
+	major, minor, err := nvrtc.Version()
+	if err != nil {
+		return "", err
+	}
+	return "nvrtc " + strconv.Itoa(major) + "." + strconv.Itoa(minor), nil
+}
+
+//Compile compiles j to ptx.  The files j.Source includes with #include "file" are passed to nvrtc as headers.
+//j.Options are nvcc options, so they aren't passed to nvrtc.
+func (NVRTC) Compile(j Job) error {
+	if j.Format != bundle.PTX || len(j.Archs) != 1 {
+		return errors.New("kernelbuild: nvrtc can only make ptx for one arch")
+	}
+	src, err := os.ReadFile(j.Source)
+	if err != nil {
+		return err
+	}
+	deps, err := Dependencies(j.Source, j.Includes)
+	if err != nil {
+		return err
+	}
+	headers := make([]nvrtc.Include, len(deps))
+	for i, d := range deps {
+		b, err := os.ReadFile(d)
+		if err != nil {
+			return err
+		}
+		headers[i] = nvrtc.Include{Source: string(b), Name: filepath.Base(d)}
+	}
+	p, err := nvrtc.CreateProgram(string(src), filepath.Base(j.Source), headers...)
+	if err != nil {
+		return err
+	}
+	opts := nvrtcbuild.NewOptions().Arch(j.Archs[0].Major, j.Archs[0].Minor, false)
+	for _, inc := range j.Includes {
+		opts.Include(inc)
+	}
+	if err = p.CompileWith(opts); err != nil {
+		return err
+	}
+	ptx, err := p.PTX()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(j.Output, []byte(ptx), 0644)
+}
@@ -0,0 +1,11 @@
+//go:build !nvrtc
+// +build !nvrtc
+
+package kernelbuild
+
+import "errors"
+
+//NewNVRTC returns the nvrtc compiler.  kernelbuild has to be built with the nvrtc tag to use it.
+func NewNVRTC() (Compiler, error) {
+	return nil, errors.New("kernelbuild: built without the nvrtc tag")
+}
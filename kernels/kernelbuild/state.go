@@ -0,0 +1,91 @@
+package kernelbuild
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+//StateFile keeps the key of every output in the output directory.  An output is only built again when its key changes.
+const StateFile = ".kernelbuild.json"
+
+type state struct {
+	Outputs map[string]string `json:"outputs"`
+}
+
+func loadstate(dir string) (*state, error) {
+	s := &state{Outputs: map[string]string{}}
+	b, err := os.ReadFile(filepath.Join(dir, StateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(b, s); err != nil || s.Outputs == nil {
+		//A bad state file only means everything is built again.
+		return &state{Outputs: map[string]string{}}, nil
+	}
+	return s, nil
+}
+
+func (s *state) save(dir string) error {
+	b, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return err
+	}
+	return writeatomic(filepath.Join(dir, StateFile), append(b, '\n'))
+}
+
+//key hashes everything that goes into the output of j.  files are the source and its dependencies.
+func key(version string, j Job, files []string) (string, error) {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(strconv.Itoa(len(s))))
+		h.Write([]byte{':'})
+		h.Write([]byte(s))
+	}
+	write(version)
+	write(string(j.Format))
+	for _, a := range j.Archs {
+		write(a.String())
+	}
+	write(strings.Join(j.Includes, "\x00"))
+	write(strings.Join(j.Options, "\x00"))
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return "", err
+		}
+		write(filepath.ToSlash(f))
+		write(string(b))
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func writeatomic(name string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-"+filepath.Base(name))
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
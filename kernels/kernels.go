@@ -4,39 +4,37 @@ import "C"
 import (
 	"fmt"
 	"io/ioutil"
-	"os"
-	"os/exec"
-	"strconv"
+	"path/filepath"
+	"runtime"
 	"strings"
-	"time"
+
+	"github.com/negativeOne1/gocudnn/kernels/kernelbuild"
+	"github.com/negativeOne1/gocudnn/xtra/bundle"
 	//	"github.com/negativeOne1/gocudnn"
 )
 
+//go:generate go run ./kernelbuild/cmd/kernelbuild -o ../xtra/bundle -arch 61,75 gocudnnxtra.cu gocudnnxtrafp16.cu gocudnnextraboth.cu
+
 //Device just has to return those two things in order to compute the kernels
 type Device interface {
 	Major() (int, error)
 	Minor() (int, error)
 }
 
-const nvcccmd = "nvcc "
-
-//const nvccarg = " --gpu-architecture=compute_"
-//const nvccarg1 = " --gpu-code=compute_"
-//const nvccarg2 = " --ptx "
-const nvccarg = "--gpu-architecture=compute_"
-const nvccarg1 = "--gpu-code=compute_"
-const nvccarg2 = "--ptx "
-
-const defaultmakedirectory = "/home/derek/go/src/github.com/negativeOne1/gocudnn/kernels/"
-
-type makefile struct {
-	lines []string
+//sourcedir is the directory of this file, which has the .cu files.  It is only right when the package is built from source.
+func sourcedir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file) + string(filepath.Separator)
 }
 
-//MakeMakeFile Makes the make file
+//MakeMakeFile compiles the .cu file in directory to ptx for the device with nvcc, and returns the name of the ptx file relative to directory,
+//like ptx/sm_75/gocudnnxtra.ptx.  It is only compiled again if the .cu file or the files it includes changed.
+//If directory is "__default__" the directory with the .cu files of this package is used.
+//
+//Deprecated: It doesn't make a Makefile anymore.  Use kernelbuild, which builds for more than one architecture and writes the bundle manifest.
 func MakeMakeFile(directory string, dotCUname string, device Device) string {
 	if directory == "__default__" {
-		directory = defaultmakedirectory
+		directory = sourcedir()
 	}
 	major, err := device.Major()
 	if err != nil {
@@ -46,49 +44,23 @@ func MakeMakeFile(directory string, dotCUname string, device Device) string {
 	if err != nil {
 		panic(err)
 	}
-	majstr := strconv.Itoa(major)
-	minstr := strconv.Itoa(minor)
-	computecapability := majstr + minstr + " "
-	newname := dotCUname
-	if strings.Contains(dotCUname, ".cu") {
-		newname = strings.TrimSuffix(dotCUname, ".cu")
-
-	} else {
+	if !strings.HasSuffix(dotCUname, ".cu") {
 		dotCUname = dotCUname + ".cu"
 	}
-	newname = newname + ".ptx"
-	var some makefile
-	//some.lines=make([]string,13)
-	some.lines = make([]string, 2)
-	some.lines[0] = "run:\n"
-	some.lines[1] = "\t" + nvcccmd + nvccarg + computecapability + nvccarg1 + computecapability + nvccarg2 + dotCUname + "\n"
-
-	data := []byte(some.lines[0] + some.lines[1])
-	err = os.MkdirAll(directory, 0644)
-	if err != nil {
-		fmt.Println(err)
-		fmt.Println(directory)
-		panic(err)
-	}
-	err = ioutil.WriteFile(directory+"Makefile", data, 0644)
-	if err != nil {
-		fmt.Println(err)
-		panic(err)
-	}
-
-	//	newcommand := exec.Command(nvcccmd, nvccarg+computecapability, nvccarg1+computecapability, nvccarg2+dotCUname)
-	newcommand := exec.Command("make")
-	newcommand.Dir = directory
-	time.Sleep(time.Millisecond)
-	response, err := newcommand.Output()
-	//err = newcommand.Run()
-
+	res, err := kernelbuild.Build(kernelbuild.NVCC{}, kernelbuild.Config{
+		Sources: []string{filepath.Join(directory, dotCUname)},
+		Out:     directory,
+		Archs:   []kernelbuild.Arch{{Major: major, Minor: minor}},
+		Format:  bundle.PTX,
+	})
 	if err != nil {
 		fmt.Println("*****Something Is wrong with the" + dotCUname + " file*******")
-		fmt.Println(string(response))
 		panic(err)
 	}
-	return newname
+	if len(res.Built) > 0 {
+		return res.Built[0]
+	}
+	return res.UpToDate[0]
 }
 
 //LoadPTXFile Loads the ptx file
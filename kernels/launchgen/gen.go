@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"strings"
+)
+
+//Options are the options for Generate.
+type Options struct {
+	Package string   //The package of the generated file.
+	Config  string   //The launch config type. It needs BlockCount and ThreadPerBlock fields. Default is "Config".
+	Sources []string //Put in the header so it is known what the file was made from.
+}
+
+//Generate makes a go file that has a type for each kernel with a typed Launch method.
+//
+//	//TransposeKernel launches the Transpose kernel with typed args.
+//	type TransposeKernel struct {
+//		*cuda.Kernel
+//	}
+//
+//	//Launch launches Transpose with a 1d config.
+//	func (k TransposeKernel) Launch(cfg Config, s gocu.Streamer, numthreads int32, src, buf cutil.Mem, ndims int32, dest cutil.Mem) error
+//
+//Kernels that need a 2d or 3d launch can still use the Launch of the embedded *cuda.Kernel.
+func Generate(opts Options, kernels []Kernel) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("launchgen: no package")
+	}
+	if opts.Config == "" {
+		opts.Config = "Config"
+	}
+	var body bytes.Buffer
+	var usesmem, useshalf bool
+	for _, k := range kernels {
+		params := goparams(k.Params)
+		for _, p := range params {
+			usesmem = usesmem || p.GoType == "cutil.Mem"
+			useshalf = useshalf || p.GoType == "half.Float16"
+		}
+		tname := k.Name + "Kernel"
+		fmt.Fprintf(&body, "\n//%s launches the %s kernel with typed args.\n", tname, k.Name)
+		fmt.Fprintf(&body, "type %s struct {\n*cuda.Kernel\n}\n", tname)
+		fmt.Fprintf(&body, "\n//Launch launches %s with a 1d config.\n", k.Name)
+		fmt.Fprintf(&body, "func (k %s) Launch(cfg %s, s gocu.Streamer%s) error {\n", tname, opts.Config, signature(params))
+		fmt.Fprintf(&body, "return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s")
+		for _, p := range params {
+			fmt.Fprintf(&body, ", %s", p.Name)
+		}
+		fmt.Fprintf(&body, ")\n}\n")
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by launchgen. DO NOT EDIT.\n")
+	if len(opts.Sources) > 0 {
+		fmt.Fprintf(&out, "//Sources: %s\n", strings.Join(opts.Sources, " "))
+	}
+	fmt.Fprintf(&out, "\npackage %s\n\nimport (\n", opts.Package)
+	if usesmem {
+		fmt.Fprintf(&out, "%q\n", "github.com/dereklstinson/cutil")
+	}
+	if useshalf {
+		fmt.Fprintf(&out, "%q\n", "github.com/dereklstinson/half")
+	}
+	fmt.Fprintf(&out, "%q\n%q\n)\n", "github.com/negativeOne1/gocudnn/cuda", "github.com/negativeOne1/gocudnn/gocu")
+	out.Write(body.Bytes())
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("launchgen: %v", err)
+	}
+	return src, nil
+}
+
+//goparams makes the param names safe to use in go. They can't be keywords, the names used by Launch, or the same as each other.
+func goparams(params []Param) []Param {
+	out := make([]Param, len(params))
+	used := map[string]bool{"k": true, "cfg": true, "s": true}
+	for i, p := range params {
+		name := p.Name
+		if token.IsKeyword(name) || used[name] || !token.IsIdentifier(name) {
+			name = fmt.Sprintf("p%d", i)
+		}
+		for used[name] {
+			name += "_"
+		}
+		used[name] = true
+		out[i] = Param{Name: name, GoType: p.GoType}
+	}
+	return out
+}
+
+//signature writes the params with params next to each other that have the same type put together.
+func signature(params []Param) string {
+	var b strings.Builder
+	for i, p := range params {
+		if i == 0 || params[i-1].GoType != p.GoType {
+			b.WriteString(", ")
+		}
+		b.WriteString(p.Name)
+		if i+1 < len(params) && params[i+1].GoType == p.GoType {
+			b.WriteString(", ")
+			continue
+		}
+		b.WriteString(" " + p.GoType)
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("%s doesn't match. got:\n%s", name, got)
+	}
+}
+
+func TestGenerateCUDA(t *testing.T) {
+	b, err := os.ReadFile("testdata/kernels.cu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err := ParseCUDA(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ks) != 3 {
+		t.Fatal(ks)
+	}
+	src, err := Generate(Options{Package: "xtra", Sources: []string{"kernels.cu"}}, ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "cuda.golden", src)
+}
+
+func TestGeneratePTX(t *testing.T) {
+	b, err := os.ReadFile("testdata/kernels.ptx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err := ParsePTX(string(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := Generate(Options{Package: "kerns", Config: "LaunchConfig"}, ks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "ptx.golden", src)
+}
+
+func TestParseCUDABad(t *testing.T) {
+	for _, s := range []string{
+		`extern "C" __global__ void K(const int n`,
+		`extern "C" __global__ void K(size_t n)`,
+		`extern "C" __global__ void K(float)`,
+	} {
+		if _, err := ParseCUDA(s); err == nil {
+			t.Errorf("%q should fail", s)
+		}
+	}
+}
+
+func TestParseCUDATemplate(t *testing.T) {
+	ks, err := ParseCUDA("const code = `extern \"C\" __global__ void NAME(const int n, const TYPE *x)`\n" +
+		"const code2 = `extern \"C\" __global__ void K2(const int n)`")
+	if err != nil || len(ks) != 1 || ks[0].Name != "K2" {
+		t.Error(ks, err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := []Kernel{{Name: "A", Params: []Param{{"n", "int32"}}}}
+	b := []Kernel{{Name: "A", Params: []Param{{"length", "int32"}}}, {Name: "B"}}
+	ks, err := Merge(a, b)
+	if err != nil || len(ks) != 2 {
+		t.Error(ks, err)
+	}
+	c := []Kernel{{Name: "A", Params: []Param{{"n", "float32"}}}}
+	if _, err := Merge(a, c); err == nil {
+		t.Error("different params should fail")
+	}
+}
+
+func TestGoParams(t *testing.T) {
+	ps := goparams([]Param{{"s", "int32"}, {"type", "int32"}, {"p1", "int32"}, {"x", "int32"}, {"x", "int32"}})
+	want := []string{"p0", "p1", "p2", "x", "p4"}
+	for i := range want {
+		if ps[i].Name != want[i] {
+			t.Error(i, ps[i].Name, want[i])
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	out := filepath.Join(dir, "zlaunch.go")
+	if err := run("xtra", out, "Config", true, []string{"testdata/kernels.cu"}); err == nil {
+		t.Error("check should fail when the file isn't there")
+	}
+	if err := run("xtra", out, "Config", false, []string{"testdata/kernels.cu"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := run("xtra", out, "Config", true, []string{"testdata/kernels.cu"}); err != nil {
+		t.Error("check should pass after writing", err)
+	}
+	if err := run("xtra", "", "Config", false, []string{"testdata/*.cu"}); err != nil {
+		t.Error("glob", err)
+	}
+	if err := run("xtra", "", "Config", false, []string{"testdata/*.nope"}); err == nil {
+		t.Error("glob that matches nothing should fail")
+	}
+}
@@ -0,0 +1,104 @@
+//Command launchgen makes typed go wrappers for the Launch of cuda kernels.
+//
+//It reads the kernel signatures out of .cu files, go files that have the cuda code in strings (like the ones in xtrakerns),
+//or .ptx files, and writes a go file with a type for each kernel that has a Launch method with typed args.
+//It is meant to be run with go generate.  go generate doesn't expand globs, so launchgen does.  _test.go files are skipped.
+//
+//	//go:generate go run ../kernels/launchgen -pkg xtra -o zlaunch.go ../kernels/gocudnnxtra.cu xtrakerns/*.go
+//
+//The output is only written if it changed.  With -check it isn't written at all, and launchgen fails if it would have changed,
+//so a test or CI can tell that the wrappers need to be made again.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	pkg := flag.String("pkg", "", "package of the generated file")
+	out := flag.String("o", "", "output file. Prints to stdout if empty")
+	config := flag.String("config", "Config", "launch config type with BlockCount and ThreadPerBlock fields")
+	check := flag.Bool("check", false, "fail if the output file isn't up to date instead of writing it")
+	flag.Parse()
+	if err := run(*pkg, *out, *config, *check, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "launchgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(pkg, out, config string, check bool, inputs []string) error {
+	files, err := expand(inputs)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no input files")
+	}
+	var sources [][]Kernel
+	for _, in := range files {
+		b, err := os.ReadFile(in)
+		if err != nil {
+			return err
+		}
+		var ks []Kernel
+		if strings.HasSuffix(in, ".ptx") {
+			ks, err = ParsePTX(string(b))
+		} else {
+			ks, err = ParseCUDA(string(b))
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", in, err)
+		}
+		sources = append(sources, ks)
+	}
+	kernels, err := Merge(sources...)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(inputs))
+	for i := range inputs {
+		names[i] = filepath.ToSlash(inputs[i])
+	}
+	src, err := Generate(Options{Package: pkg, Config: config, Sources: names}, kernels)
+	if err != nil {
+		return err
+	}
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	old, err := os.ReadFile(out)
+	if err == nil && bytes.Equal(old, src) {
+		return nil
+	}
+	if check {
+		return fmt.Errorf("%s is out of date, run go generate", out)
+	}
+	return os.WriteFile(out, src, 0644)
+}
+
+//expand expands the globs in inputs.  Go test files are left out.
+func expand(inputs []string) ([]string, error) {
+	var files []string
+	for _, in := range inputs {
+		matches, err := filepath.Glob(in)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			//not a glob, so os.ReadFile will say what is wrong with it
+			matches = []string{in}
+		}
+		for _, m := range matches {
+			if !strings.HasSuffix(m, "_test.go") {
+				files = append(files, m)
+			}
+		}
+	}
+	return files, nil
+}
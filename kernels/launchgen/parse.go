@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/negativeOne1/gocudnn/cuda/ptx"
+)
+
+//Kernel is the signature of a kernel that a wrapper is made for.
+type Kernel struct {
+	Name   string
+	Params []Param
+}
+
+//Param is a param of a kernel with the go type that is passed for it.
+type Param struct {
+	Name   string
+	GoType string
+}
+
+func (k Kernel) same(x Kernel) bool {
+	if k.Name != x.Name || len(k.Params) != len(x.Params) {
+		return false
+	}
+	for i := range k.Params {
+		if k.Params[i].GoType != x.Params[i].GoType {
+			return false
+		}
+	}
+	return true
+}
+
+var globalre = regexp.MustCompile(`extern\s+"C"\s+__global__\s+void\s+([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+
+//ParseCUDA finds the extern "C" __global__ kernels in src.  src can be a .cu file or a go file with the cuda code in strings,
+//like the ones in xtrakerns.
+//
+//A kernel whose name is only upper case letters and underscores, like NAME, is taken to be a template that the name and types are filled into at run time,
+//like the losses in xtrakerns.  It is skipped.
+func ParseCUDA(src string) ([]Kernel, error) {
+	src = stripcomments(src)
+	var kernels []Kernel
+	for _, loc := range globalre.FindAllStringSubmatchIndex(src, -1) {
+		name := src[loc[2]:loc[3]]
+		if istemplate(name) {
+			continue
+		}
+		end := strings.IndexByte(src[loc[1]:], ')')
+		if end < 0 {
+			return nil, fmt.Errorf("%s: params aren't closed", name)
+		}
+		k := Kernel{Name: name}
+		list := strings.TrimSpace(src[loc[1] : loc[1]+end])
+		if list != "" && list != "void" {
+			for i, p := range strings.Split(list, ",") {
+				param, err := parsecparam(p, i)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", name, err)
+				}
+				k.Params = append(k.Params, param)
+			}
+		}
+		kernels = append(kernels, k)
+	}
+	return kernels, nil
+}
+
+var templatere = regexp.MustCompile(`^[A-Z][A-Z_]*[A-Z]$`)
+
+func istemplate(name string) bool {
+	return templatere.MatchString(name)
+}
+
+//parsecparam parses a c param like "const float *x"
+func parsecparam(p string, i int) (Param, error) {
+	p = strings.ReplaceAll(p, "*", " * ")
+	var words []string
+	pointer := false
+	for _, w := range strings.Fields(p) {
+		switch w {
+		case "const", "__restrict__", "__restrict", "volatile":
+		case "*":
+			pointer = true
+		default:
+			words = append(words, w)
+		}
+	}
+	if len(words) < 2 {
+		return Param{}, fmt.Errorf("param %d %q needs a type and a name", i, strings.TrimSpace(p))
+	}
+	name := words[len(words)-1]
+	ctype := strings.Join(words[:len(words)-1], " ")
+	if pointer {
+		return Param{Name: name, GoType: "cutil.Mem"}, nil
+	}
+	gotype, ok := ctypes[ctype]
+	if !ok {
+		return Param{}, fmt.Errorf("param %s has unsupported type %q", name, ctype)
+	}
+	return Param{Name: name, GoType: gotype}, nil
+}
+
+var ctypes = map[string]string{
+	"int":           "int32",
+	"signed int":    "int32",
+	"int32_t":       "int32",
+	"unsigned":      "uint32",
+	"unsigned int":  "uint32",
+	"uint32_t":      "uint32",
+	"float":         "float32",
+	"double":        "float64",
+	"__half":        "half.Float16",
+	"half":          "half.Float16",
+	"bool":          "bool",
+	"char":          "int8",
+	"signed char":   "int8",
+	"int8_t":        "int8",
+	"unsigned char": "uint8",
+	"uint8_t":       "uint8",
+}
+
+//ParsePTX gets the kernels out of ptx.  Ptx doesn't keep the param names or say which params are pointers,
+//so the params are named p0,p1,... and every 64 bit int is taken to be a pointer.
+func ParsePTX(src string) ([]Kernel, error) {
+	m, err := ptx.Parse(src)
+	if err != nil {
+		return nil, err
+	}
+	var kernels []Kernel
+	for _, e := range m.Entries {
+		k := Kernel{Name: e.Name}
+		for i, p := range e.Params {
+			gotype, err := ptxgotype(p)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", e.Name, err)
+			}
+			k.Params = append(k.Params, Param{Name: fmt.Sprintf("p%d", i), GoType: gotype})
+		}
+		kernels = append(kernels, k)
+	}
+	return kernels, nil
+}
+
+func ptxgotype(p ptx.Param) (string, error) {
+	switch {
+	case p.Pointer, p.Type == "u64" || p.Type == "b64" || p.Type == "s64":
+		return "cutil.Mem", nil
+	case p.Count == 0 && (p.Type == "u32" || p.Type == "s32" || p.Type == "b32"):
+		return "int32", nil
+	case p.Count == 0 && p.Type == "f32":
+		return "float32", nil
+	case p.Count == 0 && p.Type == "f64":
+		return "float64", nil
+	case p.Count == 0 && (p.Type == "u8" || p.Type == "s8" || p.Type == "b8"):
+		return "bool", nil
+	case p.Size == 2:
+		//__half is passed as .align 2 .b8 x[2]
+		return "half.Float16", nil
+	}
+	return "", fmt.Errorf("param %s .%s of %d bytes isn't supported", p.Name, p.Type, p.Size)
+}
+
+//Merge puts the kernels of every source together.  A kernel can be in more than one source if it has the same signature.
+func Merge(sources ...[]Kernel) ([]Kernel, error) {
+	var all []Kernel
+	seen := make(map[string]int)
+	for _, ks := range sources {
+		for _, k := range ks {
+			if i, ok := seen[k.Name]; ok {
+				if !all[i].same(k) {
+					return nil, fmt.Errorf("%s is in more than one source with different params", k.Name)
+				}
+				continue
+			}
+			seen[k.Name] = len(all)
+			all = append(all, k)
+		}
+	}
+	return all, nil
+}
+
+//stripcomments removes // and /* */ comments
+func stripcomments(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '/' && i+1 < len(s) {
+			switch s[i+1] {
+			case '/':
+				for i < len(s) && s[i] != '\n' {
+					i++
+				}
+				b.WriteByte('\n')
+				continue
+			case '*':
+				end := strings.Index(s[i+2:], "*/")
+				if end < 0 {
+					return b.String()
+				}
+				i += end + 3
+				b.WriteByte(' ')
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
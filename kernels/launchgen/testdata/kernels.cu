@@ -0,0 +1,20 @@
+#include <cuda_fp16.h>
+
+//Scale scales x
+extern "C" __global__ void Scale(const int n, float *x, const float alpha)
+{
+}
+
+extern "C" __global__ void Convert(const int n,
+                                   const float *__restrict__ src, //from
+                                   __half *dst,
+                                   const __half alpha,
+                                   bool inverse,
+                                   unsigned int type)
+{
+}
+
+/* extern "C" __global__ void Commented(const int n) {} */
+extern "C" __global__ void NoArgs()
+{
+}
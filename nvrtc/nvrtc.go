@@ -5,6 +5,8 @@ import "C"
 import (
 	"runtime"
 	"unsafe"
+
+	"github.com/negativeOne1/gocudnn/nvrtc/nvrtcbuild"
 )
 
 //Version returns the version major and minor
@@ -99,6 +101,24 @@ func (p *Program) Compile(options ...string) error {
 	return status(C.nvrtcCompileProgram(p.c, p.numofoptions, &p.options[0])).error("Compile")
 }
 
+//CompileWith compiles the program with opts. If the compile fails the error is a *nvrtcbuild.BuildError
+//that has the diagnostics from the log.
+func (p *Program) CompileWith(opts *nvrtcbuild.Options) error {
+	args, err := opts.Args()
+	if err != nil {
+		return err
+	}
+	err = p.Compile(args...)
+	if err == nil {
+		return nil
+	}
+	log, lerr := p.GetLog()
+	if lerr != nil {
+		return err
+	}
+	return nvrtcbuild.NewBuildError(err, log)
+}
+
 //PTX returns a string of the ptx code for the program
 func (p *Program) PTX() (ptx string, err error) {
 	var size C.size_t
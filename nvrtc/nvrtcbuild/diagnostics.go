@@ -0,0 +1,152 @@
+package nvrtcbuild
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//Severity is how bad a Diagnostic is.
+type Severity int32
+
+//Severities of a Diagnostic
+const (
+	Remark Severity = iota
+	Warning
+	Error
+	Fatal //nvrtc calls these catastrophic errors. The compile stops at them.
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Remark:
+		return "remark"
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	case Fatal:
+		return "catastrophic error"
+	}
+	return fmt.Sprintf("Severity(%d)", int32(s))
+}
+
+//Diagnostic is one message from the nvrtc log.
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int //0 if the log doesn't have it
+	Severity Severity
+	Code     string //like "177-D". "" if the log doesn't have it
+	Message  string
+	Context  []string //The lines after the message, like the source line and the ^ under it
+}
+
+func (d Diagnostic) String() string {
+	pos := d.File
+	if d.Line > 0 {
+		pos += ":" + strconv.Itoa(d.Line)
+		if d.Column > 0 {
+			pos += ":" + strconv.Itoa(d.Column)
+		}
+	}
+	return pos + ": " + d.Severity.String() + ": " + d.Message
+}
+
+//file(line): severity #code: message
+var nvrtcre = regexp.MustCompile(`^(.+?)\((\d+)\): (remark|warning|error|catastrophic error)(?: #([0-9A-Za-z-]+))?: (.*)$`)
+
+//file:line:column: severity: message
+var clangre = regexp.MustCompile(`^(.+?):(\d+):(?:(\d+):)? (remark|warning|error|fatal error): (.*)$`)
+
+//summary lines like: 1 error detected in the compilation of "kernel.cu".
+var summaryre = regexp.MustCompile(`^\d+ (errors?|catastrophic errors?) detected in the compilation of`)
+
+//ParseLog turns the log of an nvrtc compile into diagnostics. Lines that aren't a message and come after one are put in its Context.
+//The summary line at the end is left out.
+func ParseLog(log string) []Diagnostic {
+	var ds []Diagnostic
+	for _, line := range strings.Split(strings.ReplaceAll(log, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimRight(line, " \t\x00")
+		if strings.TrimSpace(trimmed) == "" || summaryre.MatchString(trimmed) || strings.HasPrefix(trimmed, "Compilation terminated") {
+			continue
+		}
+		if m := nvrtcre.FindStringSubmatch(trimmed); m != nil {
+			ln, _ := strconv.Atoi(m[2])
+			ds = append(ds, Diagnostic{File: m[1], Line: ln, Severity: severity(m[3]), Code: m[4], Message: m[5]})
+			continue
+		}
+		if m := clangre.FindStringSubmatch(trimmed); m != nil {
+			ln, _ := strconv.Atoi(m[2])
+			col, _ := strconv.Atoi(m[3])
+			ds = append(ds, Diagnostic{File: m[1], Line: ln, Column: col, Severity: severity(m[4]), Message: m[5]})
+			continue
+		}
+		if len(ds) > 0 {
+			ds[len(ds)-1].Context = append(ds[len(ds)-1].Context, trimmed)
+		}
+	}
+	return ds
+}
+
+func severity(s string) Severity {
+	switch s {
+	case "remark":
+		return Remark
+	case "warning":
+		return Warning
+	case "catastrophic error", "fatal error":
+		return Fatal
+	}
+	return Error
+}
+
+//BuildError is the error of a compile that failed.
+type BuildError struct {
+	Err         error //The error nvrtc returned
+	Log         string
+	Diagnostics []Diagnostic
+}
+
+//NewBuildError makes a BuildError from the error of a compile and its log.
+func NewBuildError(err error, log string) *BuildError {
+	return &BuildError{Err: err, Log: log, Diagnostics: ParseLog(log)}
+}
+
+//Errors returns the diagnostics that are errors.
+func (e *BuildError) Errors() []Diagnostic {
+	var ds []Diagnostic
+	for _, d := range e.Diagnostics {
+		if d.Severity >= Error {
+			ds = append(ds, d)
+		}
+	}
+	return ds
+}
+
+//Error has the nvrtc error and then one line for each error in the log. If none of the log could be parsed, the log is used.
+func (e *BuildError) Error() string {
+	var b strings.Builder
+	if e.Err != nil {
+		b.WriteString(e.Err.Error())
+	} else {
+		b.WriteString("nvrtc compile failed")
+	}
+	errs := e.Errors()
+	if len(errs) == 0 {
+		if log := strings.TrimSpace(e.Log); log != "" {
+			b.WriteString("\n" + log)
+		}
+		return b.String()
+	}
+	for _, d := range errs {
+		b.WriteString("\n" + d.String())
+	}
+	return b.String()
+}
+
+//Unwrap returns the nvrtc error
+func (e *BuildError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,147 @@
+package nvrtcbuild
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestOptions(t *testing.T) {
+	args, err := NewOptions().
+		Arch(7, 5, false).
+		RelocatableDeviceCode(true).
+		FastMath(true).
+		LineInfo(true).
+		Std("c++14").
+		MaxRegisters(64).
+		Define("BLOCK", "256").
+		Define("DEBUG", "").
+		Include("/opt/cuda/include").
+		Args()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"--gpu-architecture=compute_75",
+		"--relocatable-device-code=true",
+		"--use_fast_math",
+		"--generate-line-info",
+		"--std=c++14",
+		"--maxrregcount=64",
+		"--define-macro=BLOCK=256",
+		"--define-macro=DEBUG",
+		"--include-path=/opt/cuda/include",
+	}
+	if strings.Join(args, " ") != strings.Join(want, " ") {
+		t.Errorf("got  %v\nwant %v", args, want)
+	}
+	if o := NewOptions().Arch(8, 6, true); o.Target() != "sm_86" {
+		t.Error(o.Target())
+	}
+	for _, a := range []struct {
+		major, minor int
+		want         string
+	}{{10, 0, "compute_100"}, {12, 0, "compute_120"}} {
+		o := NewOptions().Arch(a.major, a.minor, false)
+		if args, err := o.Args(); err != nil || o.Target() != a.want || args[0] != "--gpu-architecture="+a.want {
+			t.Error(a, o.Target(), args, err)
+		}
+	}
+	if args, err := NewOptions().Args(); err != nil || len(args) != 0 {
+		t.Error(args, err)
+	}
+}
+
+func TestOptionsValidation(t *testing.T) {
+	bad := []*Options{
+		NewOptions().Arch(2, 0, false),
+		NewOptions().Arch(7, 10, false),
+		NewOptions().Std("c++98"),
+		NewOptions().MaxRegisters(8),
+		NewOptions().MaxRegisters(300),
+		NewOptions().Define("1BAD", ""),
+		NewOptions().Define("A", "1").Define("A", "2"),
+		NewOptions().Include(""),
+	}
+	for i, o := range bad {
+		if _, err := o.Args(); err == nil {
+			t.Error(i, "should fail")
+		}
+	}
+	//every error is kept
+	_, err := NewOptions().Std("x").MaxRegisters(1).Args()
+	if err == nil || !strings.Contains(err.Error(), "std") || !strings.Contains(err.Error(), "registers") {
+		t.Error(err)
+	}
+}
+
+const samplelog = `adam.cu(12): warning #177-D: variable "unused" was declared but never referenced
+      float unused;
+            ^
+
+adam.cu(20): error: identifier "betaa" is undefined
+      w[i] -= betaa * dw[i];
+              ^
+
+adam.cu(31): error #20: too few arguments in function call
+
+2 errors detected in the compilation of "adam.cu".
+`
+
+func TestParseLog(t *testing.T) {
+	ds := ParseLog(samplelog)
+	if len(ds) != 3 {
+		t.Fatal(ds)
+	}
+	want := []Diagnostic{
+		{File: "adam.cu", Line: 12, Severity: Warning, Code: "177-D", Message: `variable "unused" was declared but never referenced`},
+		{File: "adam.cu", Line: 20, Severity: Error, Message: `identifier "betaa" is undefined`},
+		{File: "adam.cu", Line: 31, Severity: Error, Code: "20", Message: "too few arguments in function call"},
+	}
+	for i := range want {
+		d := ds[i]
+		if d.File != want[i].File || d.Line != want[i].Line || d.Severity != want[i].Severity || d.Code != want[i].Code || d.Message != want[i].Message {
+			t.Errorf("%d: got %+v want %+v", i, d, want[i])
+		}
+	}
+	if len(ds[1].Context) != 2 || !strings.Contains(ds[1].Context[0], "betaa") {
+		t.Error(ds[1].Context)
+	}
+}
+
+func TestParseLogOtherForms(t *testing.T) {
+	ds := ParseLog("kern.cu(1): catastrophic error: cannot open source file \"missing.h\"\n\n1 catastrophic error detected in the compilation of \"kern.cu\".\nCompilation terminated.\n" +
+		"inc/helper.h:7:3: error: expected ';'\n")
+	if len(ds) != 2 {
+		t.Fatal(ds)
+	}
+	if ds[0].Severity != Fatal || ds[0].Line != 1 {
+		t.Error(ds[0])
+	}
+	if ds[1].File != "inc/helper.h" || ds[1].Line != 7 || ds[1].Column != 3 || ds[1].Severity != Error {
+		t.Error(ds[1])
+	}
+	if s := ds[1].String(); s != "inc/helper.h:7:3: error: expected ';'" {
+		t.Error(s)
+	}
+}
+
+func TestBuildError(t *testing.T) {
+	nvrtcerr := errors.New("NVRTC_ERROR_COMPILATION")
+	err := NewBuildError(nvrtcerr, samplelog)
+	if !errors.Is(err, nvrtcerr) {
+		t.Error("should unwrap to the nvrtc error")
+	}
+	if len(err.Errors()) != 2 {
+		t.Error(err.Errors())
+	}
+	msg := err.Error()
+	if !strings.Contains(msg, "adam.cu:20: error: identifier \"betaa\" is undefined") || strings.Contains(msg, "warning") {
+		t.Error(msg)
+	}
+	//a log that can't be parsed is kept as is
+	raw := NewBuildError(nvrtcerr, "something went wrong").Error()
+	if !strings.Contains(raw, "something went wrong") {
+		t.Error(raw)
+	}
+}
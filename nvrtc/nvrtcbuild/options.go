@@ -0,0 +1,163 @@
+//Package nvrtcbuild has the typed compile options for nvrtc and parses the nvrtc log into diagnostics.
+//
+//It doesn't use nvrtc, so it can be tested without cuda.  nvrtc.Program.CompileWith uses both.
+package nvrtcbuild
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"strconv"
+)
+
+//Options builds the options that are passed to nvrtc. The setters return o so they can be chained.
+//Bad values are kept as errors and returned by Args.
+//
+//	args, err := nvrtcbuild.NewOptions().Arch(7, 5, false).FastMath(true).Define("BLOCK", "256").Args()
+type Options struct {
+	arch     string
+	rdc      bool
+	fastmath bool
+	lineinfo bool
+	std      string
+	maxreg   int
+	defines  []define
+	includes []string
+	errs     []error
+}
+
+type define struct {
+	name, value string
+}
+
+//NewOptions returns empty Options.
+func NewOptions() *Options {
+	return new(Options)
+}
+
+func (o *Options) errorf(format string, args ...interface{}) {
+	o.errs = append(o.errs, fmt.Errorf("nvrtcbuild: "+format, args...))
+}
+
+//Arch sets the architecture to compute capability major.minor. If real is true the output is for that real gpu (sm_XY),
+//else it is for the virtual one (compute_XY). nvrtc only makes cubin for a real gpu.
+//There is no upper limit on major, so newer gpus like 12.0 (compute_120) work.  nvrtc returns an error for ones it doesn't know.
+func (o *Options) Arch(major, minor int, real bool) *Options {
+	if major < 3 || minor < 0 || minor > 9 {
+		o.errorf("bad compute capability %d.%d", major, minor)
+		return o
+	}
+	prefix := "compute_"
+	if real {
+		prefix = "sm_"
+	}
+	o.arch = prefix + strconv.Itoa(major) + strconv.Itoa(minor)
+	return o
+}
+
+//RelocatableDeviceCode makes code that can be linked with other device code. (-rdc=true)
+func (o *Options) RelocatableDeviceCode(on bool) *Options {
+	o.rdc = on
+	return o
+}
+
+//FastMath uses the fast, less precise math functions. (--use_fast_math)
+func (o *Options) FastMath(on bool) *Options {
+	o.fastmath = on
+	return o
+}
+
+//LineInfo adds line info for profilers. (-lineinfo)
+func (o *Options) LineInfo(on bool) *Options {
+	o.lineinfo = on
+	return o
+}
+
+//Std sets the c++ version. It can be "c++03", "c++11", "c++14", "c++17" or "c++20".
+func (o *Options) Std(version string) *Options {
+	switch version {
+	case "c++03", "c++11", "c++14", "c++17", "c++20":
+		o.std = version
+	default:
+		o.errorf("unsupported std %q", version)
+	}
+	return o
+}
+
+//MaxRegisters sets the most registers a thread can use. It needs to be in [16,255].
+func (o *Options) MaxRegisters(n int) *Options {
+	if n < 16 || n > 255 {
+		o.errorf("max registers %d isn't in [16,255]", n)
+		return o
+	}
+	o.maxreg = n
+	return o
+}
+
+//Define defines a macro. An empty value is -Dname.
+func (o *Options) Define(name, value string) *Options {
+	if !token.IsIdentifier(name) {
+		o.errorf("bad macro name %q", name)
+		return o
+	}
+	for _, d := range o.defines {
+		if d.name == name {
+			o.errorf("macro %s is defined twice", name)
+			return o
+		}
+	}
+	o.defines = append(o.defines, define{name: name, value: value})
+	return o
+}
+
+//Include adds a path to look for headers in.
+func (o *Options) Include(path string) *Options {
+	if path == "" {
+		o.errorf("empty include path")
+		return o
+	}
+	o.includes = append(o.includes, path)
+	return o
+}
+
+//Args returns the options in the form nvrtc takes them. It returns the errors of any bad values that were set.
+func (o *Options) Args() ([]string, error) {
+	if len(o.errs) > 0 {
+		return nil, errors.Join(o.errs...)
+	}
+	var args []string
+	if o.arch != "" {
+		args = append(args, "--gpu-architecture="+o.arch)
+	}
+	if o.rdc {
+		args = append(args, "--relocatable-device-code=true")
+	}
+	if o.fastmath {
+		args = append(args, "--use_fast_math")
+	}
+	if o.lineinfo {
+		args = append(args, "--generate-line-info")
+	}
+	if o.std != "" {
+		args = append(args, "--std="+o.std)
+	}
+	if o.maxreg != 0 {
+		args = append(args, "--maxrregcount="+strconv.Itoa(o.maxreg))
+	}
+	for _, d := range o.defines {
+		if d.value == "" {
+			args = append(args, "--define-macro="+d.name)
+		} else {
+			args = append(args, "--define-macro="+d.name+"="+d.value)
+		}
+	}
+	for _, p := range o.includes {
+		args = append(args, "--include-path="+p)
+	}
+	return args, nil
+}
+
+//Target returns the architecture that was set, like "compute_75". It is "" if Arch wasn't called.
+func (o *Options) Target() string {
+	return o.arch
+}
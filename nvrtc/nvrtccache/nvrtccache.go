@@ -0,0 +1,369 @@
+//Package nvrtccache caches the output of nvrtc in memory and on disk so kernels don't need to be compiled on every run.
+//
+//An entry is keyed by a hash of everything that changes the output: the source, the headers, the name expressions,
+//the compile options, the nvrtc version, the architecture, and the kind of output.  Files are written to a temp file
+//and renamed so a crash or another process never sees half of an entry.  When the cache is over its size limit the entries
+//that were used least recently are removed.
+//
+//The compiling is done by a Compiler, so the cache can be tested without nvrtc.  xtrakerns has the one that uses nvrtc.
+package nvrtccache
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Header is a header that the source can include.
+type Header struct {
+	Name   string
+	Source string
+}
+
+//Request is everything that is needed to compile a program.
+type Request struct {
+	Name    string //Name of the program.  It only shows up in error messages, but it is part of the key.
+	Source  string
+	Headers []Header
+	Names   []string //Name expressions
+	Options []string
+	Arch    string //like "compute_75" or "sm_75"
+	Version string //nvrtc version like "11.2"
+	Output  string //"ptx" or "cubin"
+}
+
+//Key returns the hex sha256 of the request.  Every field is length prefixed so moving text from one field to the next changes the key.
+func (r Request) Key() string {
+	h := sha256.New()
+	write := func(s string) {
+		var n [8]byte
+		binary.LittleEndian.PutUint64(n[:], uint64(len(s)))
+		h.Write(n[:])
+		io.WriteString(h, s)
+	}
+	write("nvrtccache1")
+	write(r.Name)
+	write(r.Source)
+	write(fmt.Sprint(len(r.Headers)))
+	for _, x := range r.Headers {
+		write(x.Name)
+		write(x.Source)
+	}
+	write(fmt.Sprint(len(r.Names)))
+	for _, x := range r.Names {
+		write(x)
+	}
+	write(fmt.Sprint(len(r.Options)))
+	for _, x := range r.Options {
+		write(x)
+	}
+	write(r.Arch)
+	write(r.Version)
+	write(r.Output)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+//Compiler compiles a Request.
+type Compiler interface {
+	Compile(r Request) ([]byte, error)
+}
+
+//CompilerFunc makes a func a Compiler
+type CompilerFunc func(r Request) ([]byte, error)
+
+//Compile calls f
+func (f CompilerFunc) Compile(r Request) ([]byte, error) { return f(r) }
+
+//Options are the limits of a Cache.  Zero means no limit.
+type Options struct {
+	MaxDiskBytes int64
+	MaxMemBytes  int64
+}
+
+//Stats counts what the Cache has done
+type Stats struct {
+	MemHits   int
+	DiskHits  int
+	Misses    int //Times the compiler was called
+	Evictions int //Entries removed from memory or disk to stay under the limits
+}
+
+//Cache is a compile cache. It is safe to use from more than one goroutine.
+type Cache struct {
+	dir      string
+	compiler Compiler
+	opts     Options
+
+	mu       sync.Mutex
+	lru      *list.List //front is the most recently used
+	entries  map[string]*list.Element
+	membytes int64
+	inflight map[string]*call
+	stats    Stats
+}
+
+type entry struct {
+	key  string
+	data []byte
+}
+
+type call struct {
+	wg   sync.WaitGroup
+	data []byte
+	err  error
+}
+
+const fileext = ".nvrtc"
+
+//New makes a Cache that keeps entries in dir. If dir is "" it is only kept in memory. dir is made if it isn't there.
+func New(dir string, compiler Compiler, opts Options) (*Cache, error) {
+	if compiler == nil {
+		return nil, errors.New("nvrtccache.New: nil compiler")
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &Cache{
+		dir:      dir,
+		compiler: compiler,
+		opts:     opts,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element),
+		inflight: make(map[string]*call),
+	}, nil
+}
+
+//DefaultDir returns the directory the cache is kept in by default. It is in os.UserCacheDir.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gocudnn", "nvrtc"), nil
+}
+
+//Dir returns the directory of the cache. It is "" if the cache is only in memory.
+func (c *Cache) Dir() string { return c.dir }
+
+//Stats returns what the cache has done
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+//Get returns the compiled output of r. It is looked for in memory and then on disk, and if it isn't there it is compiled and saved.
+//If more than one goroutine asks for the same request at once it is only compiled once.
+//Don't change the returned slice.
+func (c *Cache) Get(r Request) ([]byte, error) {
+	key := r.Key()
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(el)
+		c.stats.MemHits++
+		c.mu.Unlock()
+		return el.Value.(*entry).data, nil
+	}
+	if cl, ok := c.inflight[key]; ok {
+		c.mu.Unlock()
+		cl.wg.Wait()
+		return cl.data, cl.err
+	}
+	cl := new(call)
+	cl.wg.Add(1)
+	c.inflight[key] = cl
+	c.mu.Unlock()
+
+	cl.data, cl.err = c.load(key, r)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if cl.err == nil {
+		c.addmem(key, cl.data)
+	}
+	c.mu.Unlock()
+	cl.wg.Done()
+	return cl.data, cl.err
+}
+
+//load gets the entry from disk or compiles it
+func (c *Cache) load(key string, r Request) ([]byte, error) {
+	if c.dir != "" {
+		data, err := c.readfile(key)
+		if err == nil {
+			c.mu.Lock()
+			c.stats.DiskHits++
+			c.mu.Unlock()
+			return data, nil
+		}
+	}
+	c.mu.Lock()
+	c.stats.Misses++
+	c.mu.Unlock()
+	data, err := c.compiler.Compile(r)
+	if err != nil {
+		return nil, err
+	}
+	if c.dir != "" {
+		//a cache that can't be written to still compiles
+		if err = c.writefile(key, data); err == nil {
+			c.trimdisk(key)
+		}
+	}
+	return data, nil
+}
+
+//addmem needs c.mu
+func (c *Cache) addmem(key string, data []byte) {
+	if c.opts.MaxMemBytes > 0 && int64(len(data)) > c.opts.MaxMemBytes {
+		return
+	}
+	c.entries[key] = c.lru.PushFront(&entry{key: key, data: data})
+	c.membytes += int64(len(data))
+	for c.opts.MaxMemBytes > 0 && c.membytes > c.opts.MaxMemBytes {
+		el := c.lru.Back()
+		e := el.Value.(*entry)
+		c.lru.Remove(el)
+		delete(c.entries, e.key)
+		c.membytes -= int64(len(e.data))
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+fileext)
+}
+
+//The file is the key on the first line, the length of the data on the second line, and then the data.
+func (c *Cache) readfile(key string) ([]byte, error) {
+	f, err := os.Open(c.path(key))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(f)
+	var filekey string
+	var n int
+	//n is checked against what is left of the file before it is allocated, so a bad length can't ask for a huge buffer.
+	if _, err = fmt.Fscanf(br, "%s\n%d\n", &filekey, &n); err != nil || filekey != key || n < 0 || int64(n) > fi.Size()-int64(len(key))-2 {
+		os.Remove(c.path(key))
+		return nil, fmt.Errorf("nvrtccache: bad entry %s", key)
+	}
+	data := make([]byte, n)
+	if _, err = io.ReadFull(br, data); err != nil {
+		os.Remove(c.path(key))
+		return nil, fmt.Errorf("nvrtccache: short entry %s", key)
+	}
+	//the mod time is used as the last time the entry was used
+	now := time.Now()
+	os.Chtimes(c.path(key), now, now)
+	return data, nil
+}
+
+func (c *Cache) writefile(key string, data []byte) error {
+	tmp, err := os.CreateTemp(c.dir, key+".tmp*")
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n%d\n", key, len(data))
+	buf.Write(data)
+	if _, err = tmp.Write(buf.Bytes()); err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err = os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+//trimdisk removes the least recently used files until the cache is under MaxDiskBytes. keep is never removed.
+func (c *Cache) trimdisk(keep string) {
+	if c.opts.MaxDiskBytes <= 0 {
+		return
+	}
+	infos, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	type file struct {
+		name string
+		size int64
+		mod  time.Time
+	}
+	var files []file
+	var total int64
+	for _, d := range infos {
+		if d.IsDir() || !strings.HasSuffix(d.Name(), fileext) {
+			continue
+		}
+		info, err := d.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{name: d.Name(), size: info.Size(), mod: info.ModTime()})
+		total += info.Size()
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].mod.Before(files[j].mod) })
+	for _, f := range files {
+		if total <= c.opts.MaxDiskBytes {
+			break
+		}
+		if f.name == keep+fileext {
+			continue
+		}
+		if os.Remove(filepath.Join(c.dir, f.name)) == nil {
+			total -= f.size
+			c.mu.Lock()
+			c.stats.Evictions++
+			c.mu.Unlock()
+		}
+	}
+}
+
+//Clear removes every entry from memory and disk.
+func (c *Cache) Clear() error {
+	c.mu.Lock()
+	c.lru.Init()
+	c.entries = make(map[string]*list.Element)
+	c.membytes = 0
+	c.mu.Unlock()
+	if c.dir == "" {
+		return nil
+	}
+	files, err := filepath.Glob(filepath.Join(c.dir, "*"+fileext))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if err = os.Remove(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package nvrtccache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakecompiler struct {
+	calls int32
+}
+
+func (f *fakecompiler) Compile(r Request) ([]byte, error) {
+	atomic.AddInt32(&f.calls, 1)
+	if strings.Contains(r.Source, "error") {
+		return nil, errors.New("compile error")
+	}
+	return []byte("ptx for " + r.Source + " " + r.Arch), nil
+}
+
+func request(src string) Request {
+	return Request{Name: "k.cu", Source: src, Options: []string{"-lineinfo"}, Arch: "compute_75", Version: "11.2", Output: "ptx"}
+}
+
+func TestKey(t *testing.T) {
+	base := request("a")
+	changes := []Request{
+		request("b"),
+		func() Request { r := base; r.Arch = "compute_61"; return r }(),
+		func() Request { r := base; r.Version = "11.3"; return r }(),
+		func() Request { r := base; r.Options = []string{"-lineinfo", "-G"}; return r }(),
+		func() Request { r := base; r.Headers = []Header{{"a.h", ""}}; return r }(),
+		func() Request { r := base; r.Names = []string{"K"}; return r }(),
+		func() Request { r := base; r.Output = "cubin"; return r }(),
+		//moving text between fields changes the key
+		func() Request { r := base; r.Options = []string{"-line", "info"}; return r }(),
+	}
+	if base.Key() != request("a").Key() {
+		t.Error("same request should have the same key")
+	}
+	for i, r := range changes {
+		if r.Key() == base.Key() {
+			t.Error(i, "should change the key")
+		}
+	}
+}
+
+func TestMemAndDisk(t *testing.T) {
+	dir := t.TempDir()
+	fc := new(fakecompiler)
+	c, err := New(dir, fc, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := c.Get(request("a"))
+	if err != nil || string(a) != "ptx for a compute_75" {
+		t.Fatal(string(a), err)
+	}
+	if _, err = c.Get(request("a")); err != nil {
+		t.Fatal(err)
+	}
+	if s := c.Stats(); s.Misses != 1 || s.MemHits != 1 || fc.calls != 1 {
+		t.Error(s, fc.calls)
+	}
+	//a new cache on the same dir, like a new run, reads it from disk
+	c2, _ := New(dir, fc, Options{})
+	b, err := c2.Get(request("a"))
+	if err != nil || string(b) != string(a) {
+		t.Fatal(string(b), err)
+	}
+	if s := c2.Stats(); s.DiskHits != 1 || s.Misses != 0 || fc.calls != 1 {
+		t.Error(s, fc.calls)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(matches) != 1 {
+		t.Error("should only have the entry and no temp files", matches)
+	}
+}
+
+func TestErrorsNotCached(t *testing.T) {
+	fc := new(fakecompiler)
+	c, _ := New(t.TempDir(), fc, Options{})
+	for i := 0; i < 2; i++ {
+		if _, err := c.Get(request("error")); err == nil {
+			t.Fatal("should fail")
+		}
+	}
+	if fc.calls != 2 {
+		t.Error(fc.calls)
+	}
+}
+
+func TestCorruptEntry(t *testing.T) {
+	dir := t.TempDir()
+	fc := new(fakecompiler)
+	c, _ := New(dir, fc, Options{})
+	r := request("a")
+	c.Get(r)
+	if err := os.WriteFile(c.path(r.Key()), []byte(r.Key()+"\n100\nshort"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c2, _ := New(dir, fc, Options{})
+	data, err := c2.Get(r)
+	if err != nil || string(data) != "ptx for a compute_75" || fc.calls != 2 {
+		t.Error(string(data), err, fc.calls)
+	}
+}
+
+//A length that is more than the file holds is a bad entry, and it mustn't be allocated.
+func TestCorruptLength(t *testing.T) {
+	c, _ := New(t.TempDir(), new(fakecompiler), Options{})
+	key := request("a").Key()
+	for _, n := range []string{"6", "4611686018427387904"} {
+		if err := os.WriteFile(c.path(key), []byte(key+"\n"+n+"\nshort"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.readfile(key); err == nil {
+			t.Error(n, "no error")
+		}
+	}
+}
+
+func TestDiskEviction(t *testing.T) {
+	dir := t.TempDir()
+	fc := new(fakecompiler)
+	c, _ := New(dir, fc, Options{})
+	old := time.Now().Add(-time.Hour)
+	for i, src := range []string{"a", "b", "c"} {
+		r := request(src)
+		c.Get(r)
+		//a is the oldest
+		mod := old.Add(time.Duration(i) * time.Minute)
+		os.Chtimes(c.path(r.Key()), mod, mod)
+	}
+	info, _ := os.Stat(c.path(request("a").Key()))
+	//room for three entries, so adding a fourth removes a
+	c2, _ := New(dir, fc, Options{MaxDiskBytes: 3 * info.Size()})
+	c2.Get(request("d"))
+	for _, x := range []struct {
+		src   string
+		there bool
+	}{{"a", false}, {"b", true}, {"c", true}, {"d", true}} {
+		_, err := os.Stat(c.path(request(x.src).Key()))
+		if (err == nil) != x.there {
+			t.Error(x.src, err)
+		}
+	}
+	if c2.Stats().Evictions != 1 {
+		t.Error(c2.Stats())
+	}
+}
+
+func TestMemEviction(t *testing.T) {
+	fc := new(fakecompiler)
+	n := int64(len("ptx for a compute_75"))
+	c, _ := New("", fc, Options{MaxMemBytes: 2 * n})
+	c.Get(request("a"))
+	c.Get(request("b"))
+	c.Get(request("a")) //b is now the least recently used
+	c.Get(request("c"))
+	c.Get(request("a"))
+	if fc.calls != 3 {
+		t.Error("a should still be in memory", fc.calls)
+	}
+	c.Get(request("b"))
+	if fc.calls != 4 {
+		t.Error("b should have been evicted", fc.calls)
+	}
+}
+
+type slowcompiler struct {
+	fakecompiler
+	release chan struct{}
+}
+
+func (s *slowcompiler) Compile(r Request) ([]byte, error) {
+	<-s.release
+	return s.fakecompiler.Compile(r)
+}
+
+func TestConcurrent(t *testing.T) {
+	sc := &slowcompiler{release: make(chan struct{})}
+	c, _ := New(t.TempDir(), sc, Options{})
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if data, err := c.Get(request("a")); err != nil || len(data) == 0 {
+				t.Error(err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(sc.release)
+	wg.Wait()
+	if sc.calls != 1 {
+		t.Error("should compile once", sc.calls)
+	}
+}
+
+func TestClear(t *testing.T) {
+	dir := t.TempDir()
+	fc := new(fakecompiler)
+	c, _ := New(dir, fc, Options{})
+	c.Get(request("a"))
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	}
+	c.Get(request("a"))
+	if fc.calls != 2 {
+		t.Error(fc.calls)
+	}
+}
@@ -0,0 +1,255 @@
+package tensorutil
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dereklstinson/half"
+)
+
+//Tolerance is used by Compare to decide if an element is a mismatch.
+//
+//An element matches if |got-want| <= Abs + Rel*|want|.  If ULP is more than zero and the data is
+//float, double or half, an element that is within ULP units in the last place also matches.
+type Tolerance struct {
+	Abs   float64
+	Rel   float64
+	ULP   int64
+	Worst int //Number of worst elements to keep in the Report. Default is 10
+}
+
+//Mismatch is a single element of a comparison.
+type Mismatch struct {
+	Coord  []int //n,c,h,w,... for every layout
+	Got    float64
+	Want   float64
+	AbsErr float64
+	RelErr float64
+	ULP    int64
+}
+
+func (m Mismatch) String() string {
+	return fmt.Sprintf("%v got %v want %v abs %.3e rel %.3e ulp %d", m.Coord, m.Got, m.Want, m.AbsErr, m.RelErr, m.ULP)
+}
+
+//Report is the result of Compare.
+type Report struct {
+	Desc          Desc
+	DataType      DataType
+	Tolerance     Tolerance
+	Count         int //Number of elements compared
+	Mismatches    int //Number of elements over the tolerance including NaN and Inf mismatches
+	NaNMismatches int //Number of elements where only one of got and want is NaN
+	InfMismatches int //Number of elements where got and want are not the same Inf
+	MaxAbsErr     float64
+	MaxRelErr     float64
+	MaxULP        int64 //Only set for float, double and half
+	Worst         []Mismatch
+}
+
+//OK returns true if there were no mismatches.
+func (r *Report) OK() bool { return r.Mismatches == 0 }
+
+//Err returns nil if there were no mismatches, else it returns an error holding the report.
+func (r *Report) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return fmt.Errorf("tensorutil: %d of %d elements mismatched\n%v", r.Mismatches, r.Count, r)
+}
+
+func (r *Report) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Compare %v %v dims %v: %d elements, %d mismatched (NaN %d, Inf %d)\n",
+		r.Desc.Layout, r.DataType, r.Desc.Dims, r.Count, r.Mismatches, r.NaNMismatches, r.InfMismatches)
+	fmt.Fprintf(&b, "max abs err %.6e, max rel err %.6e", r.MaxAbsErr, r.MaxRelErr)
+	if r.DataType == Float || r.DataType == Double || r.DataType == Half {
+		fmt.Fprintf(&b, ", max ulp %d", r.MaxULP)
+	}
+	b.WriteString("\n")
+	for i := range r.Worst {
+		fmt.Fprintf(&b, "\t%v\n", r.Worst[i])
+	}
+	return b.String()
+}
+
+//Compare compares got against want.  Both have to be the same supported slice type and be large enough for d.
+func Compare(d Desc, got, want interface{}, tol Tolerance) (*Report, error) {
+	if err := checkbuffer(d, got); err != nil {
+		return nil, err
+	}
+	if err := checkbuffer(d, want); err != nil {
+		return nil, err
+	}
+	dtype, _ := TypeOf(got)
+	if wtype, _ := TypeOf(want); wtype != dtype {
+		return nil, fmt.Errorf("tensorutil: got is %v and want is %v", dtype, wtype)
+	}
+	g, _ := Float64s(got)
+	w, _ := Float64s(want)
+	ulp := ulpfunc(got, want)
+	if tol.Worst == 0 {
+		tol.Worst = 10
+	}
+	r := &Report{Desc: d, DataType: dtype, Tolerance: tol}
+	worst := make([]Mismatch, 0, tol.Worst+1)
+	d.Each(func(coord []int, off int) {
+		r.Count++
+		m := Mismatch{Got: g[off], Want: w[off]}
+		gnan, wnan := math.IsNaN(m.Got), math.IsNaN(m.Want)
+		switch {
+		case gnan || wnan:
+			if gnan != wnan {
+				r.NaNMismatches++
+				r.Mismatches++
+				m.AbsErr, m.RelErr = math.Inf(1), math.Inf(1)
+			} else {
+				return
+			}
+		case math.IsInf(m.Got, 0) || math.IsInf(m.Want, 0):
+			if m.Got != m.Want {
+				r.InfMismatches++
+				r.Mismatches++
+				m.AbsErr, m.RelErr = math.Inf(1), math.Inf(1)
+			} else {
+				return
+			}
+		default:
+			m.AbsErr = math.Abs(m.Got - m.Want)
+			if m.Want != 0 {
+				m.RelErr = m.AbsErr / math.Abs(m.Want)
+			} else if m.AbsErr != 0 {
+				m.RelErr = math.Inf(1)
+			}
+			if ulp != nil {
+				m.ULP = ulp(off)
+				if m.ULP > r.MaxULP {
+					r.MaxULP = m.ULP
+				}
+			}
+			if m.AbsErr > r.MaxAbsErr {
+				r.MaxAbsErr = m.AbsErr
+			}
+			if m.RelErr > r.MaxRelErr {
+				r.MaxRelErr = m.RelErr
+			}
+			within := m.AbsErr <= tol.Abs+tol.Rel*math.Abs(m.Want)
+			if !within && ulp != nil && tol.ULP > 0 {
+				within = m.ULP <= tol.ULP
+			}
+			if !within {
+				r.Mismatches++
+			}
+			if m.AbsErr == 0 {
+				return
+			}
+		}
+		if len(worst) == tol.Worst && !worse(m, worst[len(worst)-1]) {
+			return
+		}
+		m.Coord = append([]int(nil), coord...)
+		worst = append(worst, m)
+		sort.SliceStable(worst, func(i, j int) bool { return worse(worst[i], worst[j]) })
+		if len(worst) > tol.Worst {
+			worst = worst[:tol.Worst]
+		}
+	})
+	r.Worst = worst
+	return r, nil
+}
+
+func worse(a, b Mismatch) bool {
+	if a.AbsErr != b.AbsErr {
+		return a.AbsErr > b.AbsErr
+	}
+	return a.RelErr > b.RelErr
+}
+
+func ulpfunc(got, want interface{}) func(i int) int64 {
+	switch g := got.(type) {
+	case []float32:
+		w := want.([]float32)
+		return func(i int) int64 { return ULPFloat32(g[i], w[i]) }
+	case []float64:
+		w := want.([]float64)
+		return func(i int) int64 { return ULPFloat64(g[i], w[i]) }
+	case []half.Float16:
+		w := want.([]half.Float16)
+		return func(i int) int64 { return ULPHalf(g[i], w[i]) }
+	}
+	return nil
+}
+
+//ULPFloat32 returns the number of representable float32 values between a and b.
+func ULPFloat32(a, b float32) int64 {
+	return absdiff(orderedbits32(a), orderedbits32(b))
+}
+
+//ULPFloat64 returns the number of representable float64 values between a and b.
+//It saturates at math.MaxInt64.
+func ULPFloat64(a, b float64) int64 {
+	oa, ob := orderedbits64(a), orderedbits64(b)
+	if (oa > 0 && ob < 0 && oa-math.MaxInt64 > ob) || (ob > 0 && oa < 0 && ob-math.MaxInt64 > oa) {
+		return math.MaxInt64
+	}
+	return absdiff(oa, ob)
+}
+
+//ULPHalf returns the number of representable half values between a and b.
+func ULPHalf(a, b half.Float16) int64 {
+	return absdiff(orderedbits16(a), orderedbits16(b))
+}
+
+//orderedbits maps the bits of a float onto integers so that neighboring floats are neighboring integers
+//and -0 and +0 are the same.
+func orderedbits32(f float32) int64 {
+	b := int64(int32(math.Float32bits(f)))
+	if b < 0 {
+		b = math.MinInt32 - b
+	}
+	return b
+}
+func orderedbits64(f float64) int64 {
+	b := int64(math.Float64bits(f))
+	if b < 0 {
+		b = math.MinInt64 - b
+	}
+	return b
+}
+func orderedbits16(f half.Float16) int64 {
+	b := int64(int16(f))
+	if b < 0 {
+		b = math.MinInt16 - b
+	}
+	return b
+}
+func absdiff(a, b int64) int64 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+//TB is the part of testing.TB that Check uses.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+//Check compares got against want and reports a test error with the Report if they don't match.
+//It returns true if they matched.
+func Check(t TB, d Desc, got, want interface{}, tol Tolerance) bool {
+	t.Helper()
+	r, err := Compare(d, got, want, tol)
+	if err != nil {
+		t.Errorf("%v", err)
+		return false
+	}
+	if !r.OK() {
+		t.Errorf("%v", r.Err())
+		return false
+	}
+	return true
+}
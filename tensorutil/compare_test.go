@@ -0,0 +1,128 @@
+package tensorutil
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dereklstinson/half"
+)
+
+func TestDescOffsets(t *testing.T) {
+	//n,c,h,w = 1,2,2,3
+	nchw := Desc{Layout: NCHW, Dims: []int32{1, 2, 2, 3}}
+	nhwc := Desc{Layout: NHWC, Dims: []int32{1, 2, 3, 2}}
+	strided := Desc{Layout: Strided, Dims: []int32{1, 2, 2, 3}, Strides: []int32{24, 1, 6, 2}}
+	vect := Desc{Layout: NCHWVectC, Dims: []int32{1, 2, 2, 3}, Vect: 4}
+	coord := []int{0, 1, 1, 2}
+	if got := nchw.Offset(coord); got != 11 {
+		t.Error("NCHW", got)
+	}
+	if got := nhwc.Offset(coord); got != 11 {
+		t.Error("NHWC", got)
+	}
+	if got := strided.Offset(coord); got != 11 {
+		t.Error("Strided", got)
+	}
+	if got := vect.Offset(coord); got != 21 {
+		t.Error("NCHWVectC", got)
+	}
+	if vect.Span() != 22 || nhwc.Span() != 12 {
+		t.Error("Span", vect.Span(), nhwc.Span())
+	}
+	var order []int
+	nhwc.Each(func(c []int, off int) { order = append(order, off) })
+	want := []int{0, 2, 4, 6, 8, 10, 1, 3, 5, 7, 9, 11}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatal("NHWC Each order", order)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	d := Desc{Layout: NHWC, Dims: []int32{1, 2, 2, 3}}
+	want := make([]float32, 12)
+	for i := range want {
+		want[i] = float32(i)
+	}
+	got := append([]float32(nil), want...)
+	got[7] += .5                  //h=1,w=0,c=1
+	got[2] = float32(math.NaN())  //h=0,w=0,c=2
+	got[4] = float32(math.Inf(1)) //h=0,w=1,c=1
+	got[9] = math.Nextafter32(want[9], 100)
+	r, err := Compare(d, got, want, Tolerance{Abs: 1e-7, Worst: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Count != 12 || r.Mismatches != 4 || r.NaNMismatches != 1 || r.InfMismatches != 1 {
+		t.Errorf("unexpected report %v", r)
+	}
+	if r.MaxAbsErr != .5 || r.MaxULP == 0 {
+		t.Errorf("unexpected max %v", r)
+	}
+	if len(r.Worst) != 2 {
+		t.Fatalf("unexpected worst %v", r.Worst)
+	}
+	//n,c,h,w coords of the Inf and the NaN
+	if c := r.Worst[0].Coord; c[1] != 1 || c[2] != 0 || c[3] != 1 {
+		t.Errorf("Inf should be at [0 1 0 1] %v", r.Worst[0])
+	}
+	if c := r.Worst[1].Coord; c[1] != 2 || c[2] != 0 || c[3] != 0 {
+		t.Errorf("NaN should be at [0 2 0 0] %v", r.Worst[1])
+	}
+	r, err = Compare(d, got[:12], want, Tolerance{Abs: 1, ULP: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Mismatches != 2 {
+		t.Errorf("only NaN and Inf should mismatch %v", r)
+	}
+	if !strings.Contains(r.String(), "max ulp") {
+		t.Error(r.String())
+	}
+	if _, err = Compare(d, got[:11], want, Tolerance{}); err == nil {
+		t.Error("expected short buffer error")
+	}
+	if _, err = Compare(d, got, make([]float64, 12), Tolerance{}); err == nil {
+		t.Error("expected type mismatch error")
+	}
+}
+
+func TestULP(t *testing.T) {
+	if u := ULPFloat32(1, math.Nextafter32(1, 2)); u != 1 {
+		t.Error(u)
+	}
+	if u := ULPFloat32(float32(math.Copysign(0, -1)), 0); u != 0 {
+		t.Error(u)
+	}
+	if u := ULPFloat32(-math.SmallestNonzeroFloat32, math.SmallestNonzeroFloat32); u != 2 {
+		t.Error(u)
+	}
+	if u := ULPFloat64(-math.MaxFloat64, math.MaxFloat64); u <= 0 {
+		t.Error(u)
+	}
+	if u := ULPHalf(half.NewFloat16(1), half.NewFloat16(1.0009766)); u != 1 {
+		t.Error(u)
+	}
+}
+
+type fakeTB struct {
+	errs int
+}
+
+func (f *fakeTB) Helper() {}
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errs++
+}
+
+func TestCheckHelper(t *testing.T) {
+	d := Desc{Layout: NCHW, Dims: []int32{2, 2}}
+	tb := new(fakeTB)
+	if !Check(tb, d, []int32{1, 2, 3, 4}, []int32{1, 2, 3, 4}, Tolerance{}) || tb.errs != 0 {
+		t.Error("should match")
+	}
+	if Check(tb, d, []int32{1, 2, 3, 5}, []int32{1, 2, 3, 4}, Tolerance{}) || tb.errs != 1 {
+		t.Error("should not match")
+	}
+}
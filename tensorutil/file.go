@@ -0,0 +1,186 @@
+package tensorutil
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+//File format for tensors saved with WriteTensor. Everything is little endian.
+//
+//	magic   [4]byte "GCDT"
+//	version uint32
+//	dtype   int32
+//	layout  int32
+//	vect    int32
+//	ndims   int32
+//	dims    [ndims]int32
+//	strides [ndims]int32 (zeros unless layout is Strided)
+//	count   int64
+//	data    [count]element
+const (
+	filemagic   = "GCDT"
+	fileversion = uint32(1)
+)
+
+type fileheader struct {
+	Version uint32
+	DType   int32
+	Layout  int32
+	Vect    int32
+	NDims   int32
+}
+
+//WriteTensor writes the Desc and data to w.
+func WriteTensor(w io.Writer, d Desc, data interface{}) error {
+	if err := checkbuffer(d, data); err != nil {
+		return err
+	}
+	dtype, _ := TypeOf(data)
+	bw := bufio.NewWriter(w)
+	if _, err := bw.WriteString(filemagic); err != nil {
+		return err
+	}
+	h := fileheader{
+		Version: fileversion,
+		DType:   int32(dtype),
+		Layout:  int32(d.Layout),
+		Vect:    d.Vect,
+		NDims:   int32(len(d.Dims)),
+	}
+	strides := make([]int32, len(d.Dims))
+	if d.Layout == Strided {
+		copy(strides, d.Strides)
+	}
+	for _, x := range []interface{}{h, d.Dims, strides, int64(Len(data)), data} {
+		if err := binary.Write(bw, binary.LittleEndian, x); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+//ReadTensor reads a tensor written by WriteTensor.
+func ReadTensor(r io.Reader) (Desc, interface{}, error) {
+	var d Desc
+	br := bufio.NewReader(r)
+	magic := make([]byte, len(filemagic))
+	if _, err := io.ReadFull(br, magic); err != nil {
+		return d, nil, err
+	}
+	if string(magic) != filemagic {
+		return d, nil, errors.New("tensorutil: not a tensor file")
+	}
+	var h fileheader
+	if err := binary.Read(br, binary.LittleEndian, &h); err != nil {
+		return d, nil, err
+	}
+	if h.Version != fileversion {
+		return d, nil, fmt.Errorf("tensorutil: unsupported file version %d", h.Version)
+	}
+	if h.NDims < 1 || h.NDims > 32 {
+		return d, nil, fmt.Errorf("tensorutil: bad number of dims %d", h.NDims)
+	}
+	d.Layout = Layout(h.Layout)
+	d.Vect = h.Vect
+	d.Dims = make([]int32, h.NDims)
+	strides := make([]int32, h.NDims)
+	if err := binary.Read(br, binary.LittleEndian, d.Dims); err != nil {
+		return d, nil, err
+	}
+	if err := binary.Read(br, binary.LittleEndian, strides); err != nil {
+		return d, nil, err
+	}
+	if d.Layout == Strided {
+		d.Strides = strides
+	}
+	if err := d.Validate(); err != nil {
+		return d, nil, err
+	}
+	var count int64
+	if err := binary.Read(br, binary.LittleEndian, &count); err != nil {
+		return d, nil, err
+	}
+	if count < int64(d.Span()) || count > math.MaxInt32 {
+		return d, nil, fmt.Errorf("tensorutil: file holds %d elements for dims %v", count, d.Dims)
+	}
+	dtype := DataType(h.DType)
+	if dtype.SizeOf() == 0 {
+		return d, nil, fmt.Errorf("tensorutil: unsupported %v", dtype)
+	}
+	//count comes from the file, so nothing is allocated for it until the file is known to hold that much.
+	//Readers that can't seek are read first into a buffer that only grows as data arrives.
+	size := count * int64(dtype.SizeOf())
+	var src io.Reader = br
+	left, ok, err := remaining(r, br)
+	if err != nil {
+		return d, nil, err
+	}
+	if !ok {
+		raw, err := io.ReadAll(io.LimitReader(br, size))
+		if err != nil {
+			return d, nil, err
+		}
+		left, src = int64(len(raw)), bytes.NewReader(raw)
+	}
+	if left < size {
+		return d, nil, fmt.Errorf("tensorutil: file holds %d elements but only %d bytes are left: %w", count, left, io.ErrUnexpectedEOF)
+	}
+	data, err := MakeSlice(dtype, int(count))
+	if err != nil {
+		return d, nil, err
+	}
+	if err = binary.Read(src, binary.LittleEndian, data); err != nil {
+		return d, nil, err
+	}
+	return d, data, nil
+}
+
+//remaining returns how many bytes are left to read from r, counting what br has already buffered.
+//ok is false if r can't seek.
+func remaining(r io.Reader, br *bufio.Reader) (left int64, ok bool, err error) {
+	s, ok := r.(io.Seeker)
+	if !ok {
+		return 0, false, nil
+	}
+	cur, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return 0, false, nil
+	}
+	end, err := s.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, false, nil
+	}
+	if _, err = s.Seek(cur, io.SeekStart); err != nil {
+		return 0, false, err
+	}
+	return end - cur + int64(br.Buffered()), true, nil
+}
+
+//SaveTensor writes the tensor to a file
+func SaveTensor(filename string, d Desc, data interface{}) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = WriteTensor(f, d, data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+//LoadTensor reads a tensor from a file written by SaveTensor
+func LoadTensor(filename string) (Desc, interface{}, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return Desc{}, nil, err
+	}
+	defer f.Close()
+	return ReadTensor(f)
+}
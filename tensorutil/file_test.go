@@ -0,0 +1,104 @@
+package tensorutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/dereklstinson/half"
+)
+
+func TestWriteReadTensor(t *testing.T) {
+	d := Desc{Layout: Strided, Dims: []int32{2, 3}, Strides: []int32{1, 2}}
+	data := half.NewFloat16Array([]float32{1, 2, 3, 4, 5, 6})
+	var buf bytes.Buffer
+	if err := WriteTensor(&buf, d, data); err != nil {
+		t.Fatal(err)
+	}
+	rd, rdata, err := ReadTensor(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rd.Layout != Strided || rd.Strides[1] != 2 || rd.Dims[1] != 3 {
+		t.Error(rd)
+	}
+	r, err := Compare(rd, rdata, data, Tolerance{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !r.OK() {
+		t.Error(r)
+	}
+	if _, _, err = ReadTensor(bytes.NewReader([]byte("nope"))); err == nil {
+		t.Error("expected error")
+	}
+}
+
+//A file whose count is bigger than what is left in it fails before the data is allocated.
+func TestReadTensorShortFile(t *testing.T) {
+	d := Desc{Layout: NCHW, Dims: []int32{1, 1, 2, 2}}
+	var buf bytes.Buffer
+	if err := WriteTensor(&buf, d, []float32{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	binary.LittleEndian.PutUint64(b[len(b)-16-8:], math.MaxInt32)
+	if _, _, err := ReadTensor(bytes.NewReader(b)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Error("seeker:", err)
+	}
+	if _, _, err := ReadTensor(bytes.NewBuffer(b)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Error("reader:", err)
+	}
+	binary.LittleEndian.PutUint64(b[len(b)-16-8:], 4)
+	if _, data, err := ReadTensor(bytes.NewBuffer(b)); err != nil || data.([]float32)[3] != 4 {
+		t.Error(data, err)
+	}
+}
+
+func TestValidateStrides(t *testing.T) {
+	bad := []Desc{
+		{Layout: Strided, Dims: []int32{2, 3}, Strides: []int32{1, 0}},
+		{Layout: Strided, Dims: []int32{2, 3}, Strides: []int32{-1, 2}},
+		{Layout: Strided, Dims: []int32{2, 0}, Strides: []int32{1, 2}},
+		{Layout: Strided, Dims: []int32{1 << 20, 1 << 20}, Strides: []int32{1 << 20, 1}},
+	}
+	for _, d := range bad {
+		if err := d.Validate(); err == nil {
+			t.Error("no error for", d)
+		}
+	}
+
+	//A file with a negative stride has to be an error and not a panic.
+	d := Desc{Layout: Strided, Dims: []int32{2, 3}, Strides: []int32{1, 2}}
+	var buf bytes.Buffer
+	if err := WriteTensor(&buf, d, []float32{1, 2, 3, 4, 5, 6}); err != nil {
+		t.Fatal(err)
+	}
+	b := buf.Bytes()
+	stride := len(filemagic) + binary.Size(fileheader{}) + 4*len(d.Dims) + 4
+	binary.LittleEndian.PutUint32(b[stride:], uint32(0xfffffffe))
+	if _, _, err := ReadTensor(bytes.NewReader(b)); err == nil {
+		t.Error("no error for a negative stride")
+	}
+}
+
+func TestRelayout(t *testing.T) {
+	src := Desc{Layout: NCHW, Dims: []int32{1, 2, 1, 3}}
+	dst := Desc{Layout: NHWC, Dims: []int32{1, 1, 3, 2}}
+	out, err := Relayout(src, []int32{0, 1, 2, 10, 11, 12}, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int32{0, 10, 1, 11, 2, 12}
+	for i, v := range out.([]int32) {
+		if v != want[i] {
+			t.Fatal(out)
+		}
+	}
+	if _, err = Relayout(src, []int32{0, 1, 2, 10, 11, 12}, Desc{Layout: NCHW, Dims: []int32{1, 3, 1, 2}}); err == nil {
+		t.Error("expected dims error")
+	}
+}
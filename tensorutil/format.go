@@ -0,0 +1,234 @@
+package tensorutil
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+//PrintOptions are used by Format.  The zero value uses the defaults.
+type PrintOptions struct {
+	Precision int  //Digits after the decimal point for float types. Default is 4
+	Threshold int  //Tensors with more elements than this are summarized. Default is 1000. Negative never summarizes
+	EdgeItems int  //Number of items kept at the start and end of each dim when summarizing. Default is 3
+	Stats     bool //Adds min, max, mean, std, NaN and Inf counts for each channel
+}
+
+func (p PrintOptions) defaults() PrintOptions {
+	if p.Precision <= 0 {
+		p.Precision = 4
+	}
+	if p.Threshold == 0 {
+		p.Threshold = 1000
+	}
+	if p.EdgeItems <= 0 {
+		p.EdgeItems = 3
+	}
+	return p
+}
+
+//Format returns a NumPy style string of the tensor.
+//
+//NCHW and strided tensors are nested in the order of their dims. NHWC tensors are nested as n,h,w,c so that
+//the innermost brackets hold the channels of a pixel.  NCHWVectC tensors are printed as n,c,h,w.
+func Format(d Desc, data interface{}, opts PrintOptions) (string, error) {
+	if err := checkbuffer(d, data); err != nil {
+		return "", err
+	}
+	opts = opts.defaults()
+	dtype, _ := TypeOf(data)
+	vals, _ := Float64s(data)
+	p := printer{
+		vals:      vals,
+		edge:      opts.EdgeItems,
+		summarize: opts.Threshold >= 0 && d.Volume() > opts.Threshold,
+		isfloat:   dtype == Float || dtype == Double || dtype == Half,
+		precision: opts.Precision,
+	}
+	if d.Layout == NHWC {
+		//dims are already in memory order
+		p.dims = make([]int, len(d.Dims))
+		for i := range d.Dims {
+			p.dims[i] = int(d.Dims[i])
+		}
+		packed := Desc{Layout: NCHW, Dims: d.Dims}
+		p.ix = packed.indexer()
+	} else {
+		p.ix = d.indexer()
+		p.dims = p.ix.dims
+	}
+	//first pass finds the width of the widest value so the columns line up
+	p.walk(0, make([]int, len(p.dims)), func(off int) {
+		if w := len(p.value(off)); w > p.width {
+			p.width = w
+		}
+	})
+	var b strings.Builder
+	fmt.Fprintf(&b, "Tensor{%v %v %v}\n", d.Layout, dtype, d.Dims)
+	p.write(&b, 0, make([]int, len(p.dims)))
+	b.WriteString("\n")
+	if opts.Stats {
+		stats, err := ChannelStats(d, data)
+		if err != nil {
+			return "", err
+		}
+		for i := range stats {
+			fmt.Fprintf(&b, "Channel[%d]: %v\n", i, stats[i])
+		}
+	}
+	return b.String(), nil
+}
+
+type printer struct {
+	vals      []float64
+	ix        indexer
+	dims      []int
+	edge      int
+	summarize bool
+	isfloat   bool
+	precision int
+	width     int
+}
+
+//shown returns the indexes of a dim that are printed. -1 is where the ellipsis goes.
+func (p *printer) shown(n int) []int {
+	if !p.summarize || n <= 2*p.edge {
+		idx := make([]int, n)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+	idx := make([]int, 0, 2*p.edge+1)
+	for i := 0; i < p.edge; i++ {
+		idx = append(idx, i)
+	}
+	idx = append(idx, -1)
+	for i := n - p.edge; i < n; i++ {
+		idx = append(idx, i)
+	}
+	return idx
+}
+
+func (p *printer) walk(level int, coord []int, fn func(off int)) {
+	for _, i := range p.shown(p.dims[level]) {
+		if i < 0 {
+			continue
+		}
+		coord[level] = i
+		if level == len(p.dims)-1 {
+			fn(p.ix.offset(coord))
+			continue
+		}
+		p.walk(level+1, coord, fn)
+	}
+}
+
+func (p *printer) write(b *strings.Builder, level int, coord []int) {
+	b.WriteString("[")
+	shown := p.shown(p.dims[level])
+	last := level == len(p.dims)-1
+	for j, i := range shown {
+		if j > 0 {
+			if last {
+				b.WriteString(" ")
+			} else {
+				b.WriteString(strings.Repeat("\n", len(p.dims)-level-1))
+				b.WriteString(strings.Repeat(" ", level+1))
+			}
+		}
+		if i < 0 {
+			b.WriteString("...")
+			continue
+		}
+		coord[level] = i
+		if last {
+			v := p.value(p.ix.offset(coord))
+			b.WriteString(strings.Repeat(" ", p.width-len(v)))
+			b.WriteString(v)
+			continue
+		}
+		p.write(b, level+1, coord)
+	}
+	b.WriteString("]")
+}
+
+func (p *printer) value(off int) string {
+	v := p.vals[off]
+	switch {
+	case math.IsNaN(v):
+		return "nan"
+	case math.IsInf(v, 1):
+		return "inf"
+	case math.IsInf(v, -1):
+		return "-inf"
+	}
+	if p.isfloat {
+		return strconv.FormatFloat(v, 'f', p.precision, 64)
+	}
+	return strconv.FormatInt(int64(v), 10)
+}
+
+//Stats are summary statistics of part of a tensor.  NaN and Inf values are counted but left out of the rest.
+type Stats struct {
+	Count int
+	Min   float64
+	Max   float64
+	Mean  float64
+	Std   float64
+	NaN   int
+	Inf   int
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("min %.6g max %.6g mean %.6g std %.6g NaN %d Inf %d", s.Min, s.Max, s.Mean, s.Std, s.NaN, s.Inf)
+}
+
+//ChannelStats returns the Stats of each channel of the tensor.
+func ChannelStats(d Desc, data interface{}) ([]Stats, error) {
+	if err := checkbuffer(d, data); err != nil {
+		return nil, err
+	}
+	vals, _ := Float64s(data)
+	stats := make([]Stats, d.Channels())
+	sums := make([]float64, len(stats))
+	sqsums := make([]float64, len(stats))
+	d.Each(func(coord []int, off int) {
+		c := 0
+		if len(coord) > 1 {
+			c = coord[1]
+		}
+		v := vals[off]
+		s := &stats[c]
+		switch {
+		case math.IsNaN(v):
+			s.NaN++
+			return
+		case math.IsInf(v, 0):
+			s.Inf++
+			return
+		}
+		if s.Count == 0 || v < s.Min {
+			s.Min = v
+		}
+		if s.Count == 0 || v > s.Max {
+			s.Max = v
+		}
+		s.Count++
+		sums[c] += v
+		sqsums[c] += v * v
+	})
+	for i := range stats {
+		if stats[i].Count == 0 {
+			continue
+		}
+		n := float64(stats[i].Count)
+		stats[i].Mean = sums[i] / n
+		variance := sqsums[i]/n - stats[i].Mean*stats[i].Mean
+		if variance > 0 {
+			stats[i].Std = math.Sqrt(variance)
+		}
+	}
+	return stats, nil
+}
@@ -0,0 +1,71 @@
+package tensorutil
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/dereklstinson/half"
+)
+
+func TestFormat(t *testing.T) {
+	d := Desc{Layout: NCHW, Dims: []int32{1, 2, 2, 2}}
+	s, err := Format(d, []float32{1, -2, 3, 4, 5, 6, 7, float32(math.NaN())}, PrintOptions{Precision: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Tensor{NCHW Float [1 2 2 2]}\n" +
+		"[[[[ 1.0 -2.0]\n" +
+		"   [ 3.0  4.0]]\n" +
+		"\n" +
+		"  [[ 5.0  6.0]\n" +
+		"   [ 7.0  nan]]]]\n"
+	if s != want {
+		t.Errorf("got\n%s\nwant\n%s", s, want)
+	}
+}
+
+func TestFormatSummarizeAndTypes(t *testing.T) {
+	d := Desc{Layout: NCHW, Dims: []int32{20}}
+	data := make([]int32, 20)
+	for i := range data {
+		data[i] = int32(i)
+	}
+	s, err := Format(d, data, PrintOptions{Threshold: 10, EdgeItems: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s, "[ 0  1 ... 18 19]") {
+		t.Errorf("got\n%s", s)
+	}
+	nhwc := Desc{Layout: NHWC, Dims: []int32{1, 1, 2, 3}}
+	s, err = Format(nhwc, half.NewFloat16Array([]float32{1, 2, 3, 4, 5, 6}), PrintOptions{Precision: 2, Stats: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s, "[1.00 2.00 3.00]") || !strings.Contains(s, "Channel[2]: min 3 max 6 mean 4.5 std 1.5") {
+		t.Errorf("got\n%s", s)
+	}
+	vect := Desc{Layout: NCHWVectC, Dims: []int32{1, 2, 1, 2}, Vect: 4}
+	s, err = Format(vect, []int8{1, 2, 0, 0, 3, 4, 0, 0}, PrintOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(s, "[1 3]") || !strings.Contains(s, "[2 4]") {
+		t.Errorf("got\n%s", s)
+	}
+}
+
+func TestChannelStats(t *testing.T) {
+	d := Desc{Layout: NCHW, Dims: []int32{2, 2, 1, 1}}
+	stats, err := ChannelStats(d, []float64{1, math.NaN(), 3, math.Inf(-1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats[0].Count != 2 || stats[0].Mean != 2 || stats[0].Std != 1 {
+		t.Error(stats[0])
+	}
+	if stats[1].Count != 0 || stats[1].NaN != 1 || stats[1].Inf != 1 {
+		t.Error(stats[1])
+	}
+}
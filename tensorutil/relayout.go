@@ -0,0 +1,54 @@
+package tensorutil
+
+import (
+	"fmt"
+
+	"github.com/dereklstinson/half"
+)
+
+//Relayout copies data described by src into a new buffer described by dst.
+//The logical dims (n,c,h,w,...) of src and dst have to be the same.
+func Relayout(src Desc, data interface{}, dst Desc) (interface{}, error) {
+	if err := checkbuffer(src, data); err != nil {
+		return nil, err
+	}
+	if err := dst.Validate(); err != nil {
+		return nil, err
+	}
+	sd, dd := src.LogicalDims(), dst.LogicalDims()
+	if len(sd) != len(dd) {
+		return nil, fmt.Errorf("tensorutil: Relayout dims %v and %v", src.Dims, dst.Dims)
+	}
+	for i := range sd {
+		if sd[i] != dd[i] {
+			return nil, fmt.Errorf("tensorutil: Relayout dims %v and %v", src.Dims, dst.Dims)
+		}
+	}
+	dtype, _ := TypeOf(data)
+	out, err := MakeSlice(dtype, dst.Span())
+	if err != nil {
+		return nil, err
+	}
+	dix := dst.indexer()
+	switch x := data.(type) {
+	case []float32:
+		relayout(src, dix, x, out.([]float32))
+	case []float64:
+		relayout(src, dix, x, out.([]float64))
+	case []half.Float16:
+		relayout(src, dix, x, out.([]half.Float16))
+	case []int8:
+		relayout(src, dix, x, out.([]int8))
+	case []int32:
+		relayout(src, dix, x, out.([]int32))
+	case []uint8:
+		relayout(src, dix, x, out.([]uint8))
+	}
+	return out, nil
+}
+
+func relayout[T any](src Desc, dst indexer, x, y []T) {
+	src.Each(func(coord []int, off int) {
+		y[dst.offset(coord)] = x[off]
+	})
+}
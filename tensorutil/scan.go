@@ -0,0 +1,74 @@
+package tensorutil
+
+import (
+	"fmt"
+	"math"
+)
+
+//NonFinite is the result of ScanNonFinite.
+type NonFinite struct {
+	Count  int     //Number of elements scanned
+	NaN    int     //Number of NaN elements
+	PosInf int     //Number of +Inf elements
+	NegInf int     //Number of -Inf elements
+	Coords [][]int //n,c,h,w,... coords of the first bad elements
+}
+
+//OK returns true if no NaN or Inf was found.
+func (n NonFinite) OK() bool { return n.NaN == 0 && n.PosInf == 0 && n.NegInf == 0 }
+
+func (n NonFinite) String() string {
+	return fmt.Sprintf("%d of %d elements are not finite (NaN %d, +Inf %d, -Inf %d) first at %v", n.NaN+n.PosInf+n.NegInf, n.Count, n.NaN, n.PosInf, n.NegInf, n.Coords)
+}
+
+//ScanNonFinite looks for NaN and Inf values in data.  The coords of up to max bad values are kept.
+//Integer data is always finite.
+//
+//This is the cpu path that the nan/inf check mode of gocudnn and xtra uses after it copies an output to the host.
+func ScanNonFinite(d Desc, data interface{}, max int) (NonFinite, error) {
+	var r NonFinite
+	if err := checkbuffer(d, data); err != nil {
+		return r, err
+	}
+	vals, err := floatvalues(data)
+	if err != nil {
+		return r, err
+	}
+	if vals == nil {
+		r.Count = d.Volume()
+		return r, nil
+	}
+	d.Each(func(coord []int, off int) {
+		r.Count++
+		v := vals(off)
+		switch {
+		case math.IsNaN(v):
+			r.NaN++
+		case math.IsInf(v, 1):
+			r.PosInf++
+		case math.IsInf(v, -1):
+			r.NegInf++
+		default:
+			return
+		}
+		if len(r.Coords) < max {
+			r.Coords = append(r.Coords, append([]int(nil), coord...))
+		}
+	})
+	return r, nil
+}
+
+//floatvalues returns nil for integer types since they can't hold NaN or Inf.
+func floatvalues(data interface{}) (func(i int) float64, error) {
+	switch dtype, err := TypeOf(data); {
+	case err != nil:
+		return nil, err
+	case dtype == Float || dtype == Double || dtype == Half:
+		vals, err := Float64s(data)
+		if err != nil {
+			return nil, err
+		}
+		return func(i int) float64 { return vals[i] }, nil
+	}
+	return nil, nil
+}
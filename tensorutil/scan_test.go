@@ -0,0 +1,32 @@
+package tensorutil
+
+import (
+	"math"
+	"testing"
+
+	"github.com/dereklstinson/half"
+)
+
+func TestScanNonFinite(t *testing.T) {
+	d := Desc{Layout: NHWC, Dims: []int32{1, 2, 1, 2}}
+	data := []float32{0, float32(math.NaN()), 2, float32(math.Inf(-1))}
+	r, err := ScanNonFinite(d, data, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.OK() || r.NaN != 1 || r.NegInf != 1 || r.Count != 4 {
+		t.Error(r)
+	}
+	//n,c,h,w of the NaN is [0 1 0 0]
+	if len(r.Coords) != 1 || r.Coords[0][1] != 1 || r.Coords[0][2] != 0 {
+		t.Error(r.Coords)
+	}
+	hdata := half.NewFloat16Array([]float32{1, 2, 3, 4})
+	hdata[3] = half.Float16(0x7c00) //+Inf
+	if r, _ = ScanNonFinite(d, hdata, 4); r.PosInf != 1 {
+		t.Error(r)
+	}
+	if r, _ = ScanNonFinite(d, []int8{1, 2, 3, 4}, 4); !r.OK() || r.Count != 4 {
+		t.Error(r)
+	}
+}
@@ -0,0 +1,74 @@
+//Command tensorcmp compares two tensor files saved with tensorutil.SaveTensor.
+//
+//	tensorcmp [-abs 1e-5] [-rel 1e-3] [-ulp 0] [-worst 10] got.tensor want.tensor
+//
+//It prints the comparison report and exits with status 1 if any element is over the tolerance.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+func main() {
+	abs := flag.Float64("abs", 1e-5, "absolute tolerance")
+	rel := flag.Float64("rel", 1e-3, "relative tolerance")
+	ulp := flag.Int64("ulp", 0, "ulp tolerance for float, double and half. 0 disables it")
+	worst := flag.Int("worst", 10, "number of worst elements to print")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: tensorcmp [flags] got.tensor want.tensor")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	r, err := compare(flag.Arg(0), flag.Arg(1), tensorutil.Tolerance{Abs: *abs, Rel: *rel, ULP: *ulp, Worst: *worst})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	fmt.Print(r)
+	if !r.OK() {
+		os.Exit(1)
+	}
+}
+
+func compare(gotfile, wantfile string, tol tensorutil.Tolerance) (*tensorutil.Report, error) {
+	gd, got, err := tensorutil.LoadTensor(gotfile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", gotfile, err)
+	}
+	wd, want, err := tensorutil.LoadTensor(wantfile)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", wantfile, err)
+	}
+	if !samelogicaldims(gd, wd) {
+		return nil, fmt.Errorf("dims don't match %v %v and %v %v", gd.Layout, gd.Dims, wd.Layout, wd.Dims)
+	}
+	if gd.Layout != wd.Layout || gd.Layout == tensorutil.Strided {
+		//bring want into the layout of got so they can share a Desc.
+		want, err = tensorutil.Relayout(wd, want, gd)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tensorutil.Compare(gd, got, want, tol)
+}
+
+func samelogicaldims(a, b tensorutil.Desc) bool {
+	x, y := a.LogicalDims(), b.LogicalDims()
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,369 @@
+//Package tensorutil holds host side helpers for tensors that have been copied off of the device.
+//
+//It doesn't use cuda so everything in here can be used and tested without a gpu.  gocudnn.TensorD
+//has a HostDesc method that will make a Desc for the tensor it describes.
+package tensorutil
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/dereklstinson/half"
+)
+
+//Layout is how the elements of a tensor are placed in memory.
+type Layout int32
+
+//Layouts that can be used in a Desc.
+const (
+	NCHW      Layout = iota //Dims are n,c,h,w,... and are packed in that order.
+	NHWC                    //Dims are n,h,w,...,c like they are for gocudnn.TensorD set with NHWC.
+	NCHWVectC               //Dims are n,c,h,w,... but c is split into blocks of Vect that are innermost.
+	Strided                 //Dims are n,c,h,w,... and Strides say where they are.
+)
+
+func (l Layout) String() string {
+	switch l {
+	case NCHW:
+		return "NCHW"
+	case NHWC:
+		return "NHWC"
+	case NCHWVectC:
+		return "NCHWVectC"
+	case Strided:
+		return "Strided"
+	}
+	return fmt.Sprintf("Layout(%d)", int32(l))
+}
+
+//DataType is the host type of a tensor's elements.
+type DataType int32
+
+//DataTypes that are supported. They match the go slices that are passed as data.
+const (
+	Float  DataType = iota //[]float32
+	Double                 //[]float64
+	Half                   //[]half.Float16
+	Int8                   //[]int8
+	Int32                  //[]int32
+	UInt8                  //[]uint8
+)
+
+func (d DataType) String() string {
+	switch d {
+	case Float:
+		return "Float"
+	case Double:
+		return "Double"
+	case Half:
+		return "Half"
+	case Int8:
+		return "Int8"
+	case Int32:
+		return "Int32"
+	case UInt8:
+		return "UInt8"
+	}
+	return fmt.Sprintf("DataType(%d)", int32(d))
+}
+
+//SizeOf returns the number of bytes of a single element.
+func (d DataType) SizeOf() int {
+	switch d {
+	case Double:
+		return 8
+	case Float, Int32:
+		return 4
+	case Half:
+		return 2
+	case Int8, UInt8:
+		return 1
+	}
+	return 0
+}
+
+//Desc describes a tensor held in a host buffer.
+type Desc struct {
+	Layout  Layout
+	Dims    []int32
+	Strides []int32 //Only used when Layout is Strided.
+	Vect    int32   //Only used when Layout is NCHWVectC. Usually 4 or 32.
+}
+
+//NDims returns the number of dims
+func (d Desc) NDims() int { return len(d.Dims) }
+
+//Volume returns the number of logical elements in the tensor.
+func (d Desc) Volume() int {
+	v := 1
+	for _, x := range d.Dims {
+		v *= int(x)
+	}
+	return v
+}
+
+//Channels returns the size of the channel dim.
+func (d Desc) Channels() int {
+	if len(d.Dims) < 2 {
+		return 1
+	}
+	if d.Layout == NHWC {
+		return int(d.Dims[len(d.Dims)-1])
+	}
+	return int(d.Dims[1])
+}
+
+//Validate checks that the Desc can index a buffer.
+func (d Desc) Validate() error {
+	if len(d.Dims) == 0 {
+		return errors.New("tensorutil: Desc has no dims")
+	}
+	for _, x := range d.Dims {
+		if x < 1 {
+			return fmt.Errorf("tensorutil: bad dims %v", d.Dims)
+		}
+	}
+	switch d.Layout {
+	case NCHW, NHWC:
+	case Strided:
+		if len(d.Strides) != len(d.Dims) {
+			return fmt.Errorf("tensorutil: strided Desc has %d dims and %d strides", len(d.Dims), len(d.Strides))
+		}
+		//The last element has to be at an offset an int32 can hold, so Span and Offset can't overflow.
+		var last int64
+		for i, x := range d.Strides {
+			if x < 1 {
+				return fmt.Errorf("tensorutil: bad strides %v", d.Strides)
+			}
+			last += int64(d.Dims[i]-1) * int64(x)
+			if last > math.MaxInt32 {
+				return fmt.Errorf("tensorutil: strides %v with dims %v are out of range", d.Strides, d.Dims)
+			}
+		}
+	case NCHWVectC:
+		if len(d.Dims) < 2 {
+			return errors.New("tensorutil: NCHWVectC needs at least 2 dims")
+		}
+		if d.Vect < 1 {
+			return errors.New("tensorutil: NCHWVectC needs Vect > 0")
+		}
+	default:
+		return fmt.Errorf("tensorutil: unsupported %v", d.Layout)
+	}
+	return nil
+}
+
+//Span returns the number of elements a buffer needs to hold the tensor.
+func (d Desc) Span() int {
+	ix := d.indexer()
+	last := make([]int, len(ix.dims))
+	for i := range last {
+		last[i] = ix.dims[i] - 1
+	}
+	return ix.offset(last) + 1
+}
+
+//Offset returns where in the buffer the element at coord is.  coord is in n,c,h,w,... order for every layout.
+func (d Desc) Offset(coord []int) int {
+	return d.indexer().offset(coord)
+}
+
+//LogicalDims returns the dims in n,c,h,w,... order.
+func (d Desc) LogicalDims() []int {
+	return d.indexer().dims
+}
+
+//Each calls fn for every element of the tensor in n,c,h,w,... order. coord is reused between calls.
+func (d Desc) Each(fn func(coord []int, offset int)) {
+	ix := d.indexer()
+	coord := make([]int, len(ix.dims))
+	n := d.Volume()
+	for i := 0; i < n; i++ {
+		fn(coord, ix.offset(coord))
+		for j := len(coord) - 1; j >= 0; j-- {
+			coord[j]++
+			if coord[j] < ix.dims[j] {
+				break
+			}
+			coord[j] = 0
+		}
+	}
+}
+
+//indexer holds the dims and element strides in n,c,h,w order.
+type indexer struct {
+	dims    []int
+	strides []int
+	vect    int
+}
+
+func (d Desc) indexer() indexer {
+	n := len(d.Dims)
+	ix := indexer{dims: make([]int, n), strides: make([]int, n)}
+	switch d.Layout {
+	case NHWC:
+		//memory is n,h,w,...,c logical is n,c,h,w,...
+		packed := packedstrides(d.Dims)
+		ix.dims[0], ix.strides[0] = int(d.Dims[0]), packed[0]
+		if n > 1 {
+			ix.dims[1], ix.strides[1] = int(d.Dims[n-1]), packed[n-1]
+		}
+		for i := 2; i < n; i++ {
+			ix.dims[i], ix.strides[i] = int(d.Dims[i-1]), packed[i-1]
+		}
+	case Strided:
+		for i := range d.Dims {
+			ix.dims[i], ix.strides[i] = int(d.Dims[i]), int(d.Strides[i])
+		}
+	case NCHWVectC:
+		//memory is n,c/v,h,w,...,v
+		v := int(d.Vect)
+		blocks := make([]int32, n)
+		copy(blocks, d.Dims)
+		blocks[1] = int32((int(d.Dims[1]) + v - 1) / v)
+		packed := packedstrides(blocks)
+		for i := range d.Dims {
+			ix.dims[i], ix.strides[i] = int(d.Dims[i]), packed[i]*v
+		}
+		ix.vect = v
+	default:
+		packed := packedstrides(d.Dims)
+		for i := range d.Dims {
+			ix.dims[i], ix.strides[i] = int(d.Dims[i]), packed[i]
+		}
+	}
+	return ix
+}
+
+func (ix indexer) offset(coord []int) int {
+	off := 0
+	for i := range coord {
+		if i == 1 && ix.vect > 0 {
+			off += (coord[1]/ix.vect)*ix.strides[1] + coord[1]%ix.vect
+			continue
+		}
+		off += coord[i] * ix.strides[i]
+	}
+	return off
+}
+
+func packedstrides(dims []int32) []int {
+	s := make([]int, len(dims))
+	stride := 1
+	for i := len(dims) - 1; i >= 0; i-- {
+		s[i] = stride
+		stride *= int(dims[i])
+	}
+	return s
+}
+
+//TypeOf returns the DataType of a supported slice.
+func TypeOf(data interface{}) (DataType, error) {
+	switch data.(type) {
+	case []float32:
+		return Float, nil
+	case []float64:
+		return Double, nil
+	case []half.Float16:
+		return Half, nil
+	case []int8:
+		return Int8, nil
+	case []int32:
+		return Int32, nil
+	case []uint8:
+		return UInt8, nil
+	}
+	return 0, fmt.Errorf("tensorutil: unsupported data %T", data)
+}
+
+//Float64s converts a supported slice to float64.
+func Float64s(data interface{}) ([]float64, error) {
+	switch x := data.(type) {
+	case []float32:
+		y := make([]float64, len(x))
+		for i := range x {
+			y[i] = float64(x[i])
+		}
+		return y, nil
+	case []float64:
+		return x, nil
+	case []half.Float16:
+		y := make([]float64, len(x))
+		for i := range x {
+			y[i] = float64(x[i].Float32())
+		}
+		return y, nil
+	case []int8:
+		y := make([]float64, len(x))
+		for i := range x {
+			y[i] = float64(x[i])
+		}
+		return y, nil
+	case []int32:
+		y := make([]float64, len(x))
+		for i := range x {
+			y[i] = float64(x[i])
+		}
+		return y, nil
+	case []uint8:
+		y := make([]float64, len(x))
+		for i := range x {
+			y[i] = float64(x[i])
+		}
+		return y, nil
+	}
+	return nil, fmt.Errorf("tensorutil: unsupported data %T", data)
+}
+
+//Len returns the length of a supported slice.  It returns -1 if data isn't supported.
+func Len(data interface{}) int {
+	switch x := data.(type) {
+	case []float32:
+		return len(x)
+	case []float64:
+		return len(x)
+	case []half.Float16:
+		return len(x)
+	case []int8:
+		return len(x)
+	case []int32:
+		return len(x)
+	case []uint8:
+		return len(x)
+	}
+	return -1
+}
+
+//MakeSlice makes a slice of dtype that has n elements.
+func MakeSlice(dtype DataType, n int) (interface{}, error) {
+	switch dtype {
+	case Float:
+		return make([]float32, n), nil
+	case Double:
+		return make([]float64, n), nil
+	case Half:
+		return make([]half.Float16, n), nil
+	case Int8:
+		return make([]int8, n), nil
+	case Int32:
+		return make([]int32, n), nil
+	case UInt8:
+		return make([]uint8, n), nil
+	}
+	return nil, fmt.Errorf("tensorutil: unsupported %v", dtype)
+}
+
+func checkbuffer(d Desc, data interface{}) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	n := Len(data)
+	if n < 0 {
+		return fmt.Errorf("tensorutil: unsupported data %T", data)
+	}
+	if n < d.Span() {
+		return fmt.Errorf("tensorutil: buffer has %d elements Desc needs %d", n, d.Span())
+	}
+	return nil
+}
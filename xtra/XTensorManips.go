@@ -20,7 +20,7 @@ type XTransposeD struct {
 //CreateTransposeDesc creates a struct that holds the kernel for Transpos operation.  Might get rid of it
 func CreateTransposeDesc(handle *Handle) (*XTransposeD, error) {
 
-	kern, err := cuda.MakeKernel("Transpose", handle.mod)
+	kern, err := handle.kernel("Transpose")
 	return &XTransposeD{kern: kern}, err
 }
 
@@ -205,35 +205,35 @@ func CreateResizeDesc(handle *Handle, aligncorners bool) (*XResizeD, error) {
 
 //CreateResizeDesc creates a descriptor that holds the reshpaes
 func createResizeDesc(handle *Handle, aligncorners bool) (*XResizeD, error) {
-	nearestfwdnhwc, err := cuda.MakeKernel("NearestNeighborNHWC", handle.mod)
+	nearestfwdnhwc, err := handle.kernel("NearestNeighborNHWC")
 	if err != nil {
 		return nil, err
 	}
-	nearestbwdnhwc, err := cuda.MakeKernel("NearestNeighborNHWCBack", handle.mod)
+	nearestbwdnhwc, err := handle.kernel("NearestNeighborNHWCBack")
 	if err != nil {
 		return nil, err
 	}
-	nearestfwdnchw, err := cuda.MakeKernel("NearestNeighborNCHW", handle.mod)
+	nearestfwdnchw, err := handle.kernel("NearestNeighborNCHW")
 	if err != nil {
 		return nil, err
 	}
-	nearestbwdnchw, err := cuda.MakeKernel("NearestNeighborNCHWBack", handle.mod)
+	nearestbwdnchw, err := handle.kernel("NearestNeighborNCHWBack")
 	if err != nil {
 		return nil, err
 	}
-	nearestfwdnhwcfp16, err := cuda.MakeKernel("NearestNeighborNHWCFP16", handle.mod)
+	nearestfwdnhwcfp16, err := handle.kernel("NearestNeighborNHWCFP16")
 	if err != nil {
 		return nil, err
 	}
-	nearestbwdnhwcfp16, err := cuda.MakeKernel("NearestNeighborNHWCBackFP16", handle.mod)
+	nearestbwdnhwcfp16, err := handle.kernel("NearestNeighborNHWCBackFP16")
 	if err != nil {
 		return nil, err
 	}
-	nearestfwdnchwfp16, err := cuda.MakeKernel("NearestNeighborNCHWFP16", handle.mod)
+	nearestfwdnchwfp16, err := handle.kernel("NearestNeighborNCHWFP16")
 	if err != nil {
 		return nil, err
 	}
-	nearestbwdnchwfp16, err := cuda.MakeKernel("NearestNeighborNCHWBackFP16", handle.mod)
+	nearestbwdnchwfp16, err := handle.kernel("NearestNeighborNCHWBackFP16")
 	if err != nil {
 		return nil, err
 	}
@@ -418,19 +418,19 @@ func CreateShapetoBatchDesc(handle *Handle) (*XShapetoBatchD, error) {
 	return xstbd, err
 }
 func createShapetoBatchDesc(handle *Handle) (*XShapetoBatchD, error) {
-	nhwc, err := cuda.MakeKernel("ShapetoBatch4DNHWC", handle.mod)
+	nhwc, err := handle.kernel("ShapetoBatch4DNHWC")
 	if err != nil {
 		return nil, err
 	}
-	nhwcfp16, err := cuda.MakeKernel("ShapetoBatch4DNHWCFP16", handle.mod)
+	nhwcfp16, err := handle.kernel("ShapetoBatch4DNHWCFP16")
 	if err != nil {
 		return nil, err
 	}
-	nchw, err := cuda.MakeKernel("ShapetoBatch4DNCHW", handle.mod)
+	nchw, err := handle.kernel("ShapetoBatch4DNCHW")
 	if err != nil {
 		return nil, err
 	}
-	nchwfp16, err := cuda.MakeKernel("ShapetoBatch4DNCHWFP16", handle.mod)
+	nchwfp16, err := handle.kernel("ShapetoBatch4DNCHWFP16")
 	return &XShapetoBatchD{nhwc: nhwc,
 		nhwcfp16: nhwcfp16,
 		nchw:     nchw,
@@ -0,0 +1,93 @@
+//Package accumulate keeps track of gradient accumulation over micro-batches.
+//
+//The gradient buffers are zeroed before the first micro-batch and every backward pass adds to them with beta = 1.
+//After Steps micro-batches the gradients are scaled by 1/Samples, the optimizer step is taken, and the buffers are zeroed again.
+//
+//	s, _ := accumulate.New(4)
+//	for each micro-batch {
+//		backward with beta = s.Beta()
+//		if s.Add(batchsize) {
+//			scale gradients by s.Scale()
+//			optimizer step
+//			zero gradients
+//			s.Reset()
+//		}
+//	}
+//
+//Schedule doesn't touch the device.  xtra.Accumulator uses it for the device side.
+package accumulate
+
+import "errors"
+
+//ErrSteps is returned when the number of micro-batches per step isn't at least 1.
+var ErrSteps = errors.New("accumulate: steps needs to be at least 1")
+
+//Beta is the beta that backward calls use to add to the gradient buffers instead of writing over them.
+const Beta = 1.0
+
+//Schedule counts micro-batches until it is time for an optimizer step.
+type Schedule struct {
+	steps   int
+	count   int
+	samples int
+	total   int
+}
+
+//New makes a Schedule that takes an optimizer step every steps micro-batches.
+func New(steps int) (*Schedule, error) {
+	if steps < 1 {
+		return nil, ErrSteps
+	}
+	return &Schedule{steps: steps}, nil
+}
+
+//Steps returns the number of micro-batches per optimizer step.
+func (s *Schedule) Steps() int { return s.steps }
+
+//SetSteps changes the number of micro-batches per optimizer step. If the micro-batches already added are steps or more, Ready will be true.
+func (s *Schedule) SetSteps(steps int) error {
+	if steps < 1 {
+		return ErrSteps
+	}
+	s.steps = steps
+	return nil
+}
+
+//Beta returns the beta for the backward calls of the next micro-batch.  It is always Beta because the buffers are zeroed after each step.
+func (s *Schedule) Beta() float64 { return Beta }
+
+//Add records a backward pass over a micro-batch of batchsize samples. It returns true when it is time for an optimizer step.
+func (s *Schedule) Add(batchsize int) bool {
+	s.count++
+	s.samples += batchsize
+	return s.Ready()
+}
+
+//Ready returns true when enough micro-batches have been added for an optimizer step.
+func (s *Schedule) Ready() bool { return s.count >= s.steps }
+
+//Count returns the number of micro-batches added since the last Reset.
+func (s *Schedule) Count() int { return s.count }
+
+//Samples returns the effective batch, the number of samples added since the last Reset.
+func (s *Schedule) Samples() int { return s.samples }
+
+//Scale returns what the accumulated gradients are multiplied by to be the mean over the effective batch.
+//It returns 0 if nothing was added.
+func (s *Schedule) Scale() float32 {
+	if s.samples == 0 {
+		return 0
+	}
+	return 1 / float32(s.samples)
+}
+
+//Reset starts the next accumulation. Call it after the gradients are zeroed.
+func (s *Schedule) Reset() {
+	s.count = 0
+	s.samples = 0
+	s.total++
+}
+
+//Updates returns the number of times Reset was called, which is the number of optimizer steps taken.
+//Use it as the counter for TrainValues.
+func (s *Schedule) Updates() int { return s.total }
@@ -0,0 +1,74 @@
+package accumulate
+
+import "testing"
+
+func TestSchedule(t *testing.T) {
+	if _, err := New(0); err != ErrSteps {
+		t.Error(err)
+	}
+	s, err := New(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Scale() != 0 {
+		t.Error("nothing added should scale by 0", s.Scale())
+	}
+	sizes := []int{8, 8, 4}
+	for i, n := range sizes {
+		if s.Beta() != 1 {
+			t.Error(s.Beta())
+		}
+		ready := s.Add(n)
+		if ready != (i == len(sizes)-1) {
+			t.Error(i, ready)
+		}
+	}
+	if s.Count() != 3 || s.Samples() != 20 || s.Scale() != 1/float32(20) {
+		t.Error(s.Count(), s.Samples(), s.Scale())
+	}
+	s.Reset()
+	if s.Ready() || s.Count() != 0 || s.Samples() != 0 || s.Updates() != 1 {
+		t.Error(s.Ready(), s.Count(), s.Samples(), s.Updates())
+	}
+}
+
+func TestSetSteps(t *testing.T) {
+	s, _ := New(4)
+	s.Add(2)
+	s.Add(2)
+	if s.Ready() {
+		t.Error("should not be ready")
+	}
+	if err := s.SetSteps(2); err != nil {
+		t.Fatal(err)
+	}
+	if !s.Ready() {
+		t.Error("should be ready after lowering steps")
+	}
+	if err := s.SetSteps(-1); err != ErrSteps || s.Steps() != 2 {
+		t.Error(err, s.Steps())
+	}
+}
+
+//the accumulated mean over micro-batches is the same as the mean over one big batch
+func TestMatchesBigBatch(t *testing.T) {
+	x := []float32{1, 2, 3, 4, 5, 6, 7}
+	var big float32
+	for _, v := range x {
+		big += v
+	}
+	big /= float32(len(x))
+	s, _ := New(3)
+	var acc float32
+	for _, mb := range [][]float32{x[:3], x[3:5], x[5:]} {
+		var g float32
+		for _, v := range mb {
+			g += v
+		}
+		acc = acc*float32(s.Beta()) + g
+		s.Add(len(mb))
+	}
+	if got := acc * s.Scale(); got != big {
+		t.Error(got, big)
+	}
+}
@@ -0,0 +1,357 @@
+//Package bundle holds the precompiled xtra kernels and the manifest that describes them.
+//
+//The kernels are embedded with go:embed.  manifest.json lists every bundle with its file, format (ptx or cubin),
+//architecture, data types and the names of the kernels in it.  A Registry picks the bundle that best fits a device's
+//compute capability and finds the bundles that hold a kernel by name.
+//
+//A cubin only runs on the major version it was built for, and on minors that are the same or newer.
+//PTX is jit compiled by the driver, so it runs on its own architecture and everything newer.
+//A fatbin has both, so it runs like PTX.
+//Selection prefers native code for the device, then the newest PTX that the device can jit.
+//
+//	r, _ := bundle.Default()
+//	b, _ := r.Select(7, 5, bundle.Float, bundle.Half)
+//	image, _ := r.Data(b)
+//
+//The package doesn't touch the device.  xtra loads the image into a module.
+package bundle
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/negativeOne1/gocudnn/cuda/ptx"
+)
+
+//go:embed manifest.json ptx
+var embedded embed.FS
+
+//ManifestFile is the name of the manifest in the root of a bundle file system.
+const ManifestFile = "manifest.json"
+
+//ErrNoBundle is returned when no bundle can be used on the device.
+var ErrNoBundle = errors.New("bundle: no bundle for device")
+
+//ErrNoKernel is returned when no bundle holds the kernel.
+var ErrNoKernel = errors.New("bundle: kernel not found")
+
+//Format is the format of a bundle's file.
+type Format string
+
+//Formats that a bundle can have.
+const (
+	PTX    Format = "ptx"
+	Cubin  Format = "cubin"
+	Fatbin Format = "fatbin" //A fatbin with a cubin and ptx for each architecture from Arch up.
+)
+
+//DataType is a data type the kernels in a bundle work on.
+type DataType string
+
+//Data types that a bundle can have.
+const (
+	Float DataType = "float"
+	Half  DataType = "half"
+)
+
+//Manifest lists the bundles.
+type Manifest struct {
+	Bundles []Bundle `json:"bundles"`
+}
+
+//Bundle is a file of compiled kernels.
+type Bundle struct {
+	Name      string     `json:"name"`
+	File      string     `json:"file"`
+	Format    Format     `json:"format"`
+	Arch      string     `json:"arch"`
+	DataTypes []DataType `json:"dtypes"`
+	Kernels   []string   `json:"kernels"`
+	major     int
+	minor     int
+	kernels   map[string]bool
+}
+
+//Compute returns the compute capability the bundle was built for.
+func (b *Bundle) Compute() (major, minor int) { return b.major, b.minor }
+
+//Has returns true if the bundle holds the kernel.
+func (b *Bundle) Has(kernel string) bool { return b.kernels[kernel] }
+
+//HasDataType returns true if the bundle has kernels for dtype.
+func (b *Bundle) HasDataType(dtype DataType) bool {
+	for _, d := range b.DataTypes {
+		if d == dtype {
+			return true
+		}
+	}
+	return false
+}
+
+//Runs returns true if the bundle can be loaded on a device with the compute capability.
+func (b *Bundle) Runs(major, minor int) bool {
+	switch b.Format {
+	case Cubin:
+		return b.major == major && b.minor <= minor
+	case PTX, Fatbin:
+		return b.major < major || (b.major == major && b.minor <= minor)
+	}
+	return false
+}
+
+//Registry finds bundles in a file system.
+type Registry struct {
+	fsys    fs.FS
+	bundles []*Bundle
+	byname  map[string][]*Bundle
+}
+
+var (
+	defaultonce sync.Once
+	defaultreg  *Registry
+	defaulterr  error
+)
+
+//Default returns the registry of the embedded bundles.
+func Default() (*Registry, error) {
+	defaultonce.Do(func() {
+		defaultreg, defaulterr = New(embedded)
+	})
+	return defaultreg, defaulterr
+}
+
+//New makes a registry from a file system that has a manifest.json in its root, like os.DirFS of a kernel directory.
+func New(fsys fs.FS) (*Registry, error) {
+	b, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var m Manifest
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("bundle: %s: %v", ManifestFile, err)
+	}
+	return NewManifest(fsys, m)
+}
+
+//NewManifest makes a registry from a manifest whose files are in fsys.
+func NewManifest(fsys fs.FS, m Manifest) (*Registry, error) {
+	r := &Registry{
+		fsys:   fsys,
+		byname: make(map[string][]*Bundle),
+	}
+	names := make(map[string]bool)
+	for i := range m.Bundles {
+		b := m.Bundles[i]
+		if b.Name == "" {
+			return nil, fmt.Errorf("bundle: bundle %d has no name", i)
+		}
+		if names[b.Name] {
+			return nil, fmt.Errorf("bundle: %s is in the manifest more than once", b.Name)
+		}
+		names[b.Name] = true
+		if b.Format != PTX && b.Format != Cubin && b.Format != Fatbin {
+			return nil, fmt.Errorf("bundle: %s: unknown format %q", b.Name, b.Format)
+		}
+		var err error
+		b.major, b.minor, err = ParseArch(b.Arch)
+		if err != nil {
+			return nil, fmt.Errorf("bundle: %s: %v", b.Name, err)
+		}
+		if b.Format == Cubin && strings.HasPrefix(b.Arch, "compute_") {
+			return nil, fmt.Errorf("bundle: %s: cubin needs a real architecture, not %s", b.Name, b.Arch)
+		}
+		if _, err = fs.Stat(fsys, b.File); err != nil {
+			return nil, fmt.Errorf("bundle: %s: %v", b.Name, err)
+		}
+		b.kernels = make(map[string]bool, len(b.Kernels))
+		for _, k := range b.Kernels {
+			b.kernels[k] = true
+		}
+		bp := &b
+		r.bundles = append(r.bundles, bp)
+		for _, k := range b.Kernels {
+			r.byname[k] = append(r.byname[k], bp)
+		}
+	}
+	return r, nil
+}
+
+//ParseArch parses an architecture like sm_75 or compute_61.
+func ParseArch(arch string) (major, minor int, err error) {
+	s := strings.TrimPrefix(strings.TrimPrefix(arch, "sm_"), "compute_")
+	if s == arch || len(s) < 2 {
+		return 0, 0, fmt.Errorf("bad architecture %q", arch)
+	}
+	mm, err := strconv.Atoi(s)
+	if err != nil || mm < 10 {
+		return 0, 0, fmt.Errorf("bad architecture %q", arch)
+	}
+	return mm / 10, mm % 10, nil
+}
+
+//Bundles returns the bundles in manifest order.
+func (r *Registry) Bundles() []*Bundle {
+	return append([]*Bundle(nil), r.bundles...)
+}
+
+//Bundle returns the bundle with name.
+func (r *Registry) Bundle(name string) (*Bundle, bool) {
+	for _, b := range r.bundles {
+		if b.Name == name {
+			return b, true
+		}
+	}
+	return nil, false
+}
+
+//Data returns the contents of the bundle's file.
+func (r *Registry) Data(b *Bundle) ([]byte, error) {
+	return fs.ReadFile(r.fsys, b.File)
+}
+
+//Select returns the bundle that best fits a device with the compute capability and has kernels for all of dtypes.
+//A cubin or fatbin with code for the device is picked over PTX, newer architectures over older, and then bundles with more kernels.
+func (r *Registry) Select(major, minor int, dtypes ...DataType) (*Bundle, error) {
+	b := r.best(r.bundles, major, minor, func(b *Bundle) bool {
+		for _, d := range dtypes {
+			if !b.HasDataType(d) {
+				return false
+			}
+		}
+		return true
+	})
+	if b == nil {
+		return nil, fmt.Errorf("%w: sm_%d%d %v", ErrNoBundle, major, minor, dtypes)
+	}
+	return b, nil
+}
+
+//Lookup returns the bundle that best fits a device with the compute capability and holds the kernel.
+//It returns ErrNoKernel if no bundle has it, and ErrNoBundle if the bundles that have it can't run on the device.
+func (r *Registry) Lookup(kernel string, major, minor int) (*Bundle, error) {
+	bs, ok := r.byname[kernel]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoKernel, kernel)
+	}
+	b := r.best(bs, major, minor, nil)
+	if b == nil {
+		return nil, fmt.Errorf("%w: %s on sm_%d%d", ErrNoBundle, kernel, major, minor)
+	}
+	return b, nil
+}
+
+//Kernels returns the names of all the kernels in the registry sorted.
+func (r *Registry) Kernels() []string {
+	names := make([]string, 0, len(r.byname))
+	for k := range r.byname {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *Registry) best(bs []*Bundle, major, minor int, keep func(*Bundle) bool) *Bundle {
+	var best *Bundle
+	for _, b := range bs {
+		if !b.Runs(major, minor) || (keep != nil && !keep(b)) {
+			continue
+		}
+		if best == nil || better(b, best, major) {
+			best = b
+		}
+	}
+	return best
+}
+
+//better returns true if a should be picked over b when both run on a device with major.
+//Native code for the device comes first, then newer architectures, then cubins over fatbins over ptx, then more kernels.
+func better(a, b *Bundle, major int) bool {
+	if an, bn := a.native(major), b.native(major); an != bn {
+		return an
+	}
+	if a.major != b.major {
+		return a.major > b.major
+	}
+	if a.minor != b.minor {
+		return a.minor > b.minor
+	}
+	if a.Format != b.Format {
+		return formatrank(a.Format) > formatrank(b.Format)
+	}
+	return len(a.Kernels) > len(b.Kernels)
+}
+
+//native returns true if the bundle has code that a device with major runs without a jit.
+func (b *Bundle) native(major int) bool {
+	return b.Format != PTX && b.major == major
+}
+
+func formatrank(f Format) int {
+	switch f {
+	case Cubin:
+		return 2
+	case Fatbin:
+		return 1
+	}
+	return 0
+}
+
+//DataTypes returns the data types of kernels.  Kernels whose names end in FP16 or half are Half and the rest are Float.
+func DataTypes(kernels []string) []DataType {
+	var float, half bool
+	for _, k := range kernels {
+		if strings.HasSuffix(k, "FP16") || strings.HasSuffix(strings.ToLower(k), "half") {
+			half = true
+		} else {
+			float = true
+		}
+	}
+	var dtypes []DataType
+	if float {
+		dtypes = append(dtypes, Float)
+	}
+	if half {
+		dtypes = append(dtypes, Half)
+	}
+	return dtypes
+}
+
+//PTXBundle describes the ptx src that is in file.  The name is the file's base name and the target, like gocudnnxtra_sm_75.
+func PTXBundle(file string, src []byte) (Bundle, error) {
+	meta, err := ptx.Parse(string(src))
+	if err != nil {
+		return Bundle{}, fmt.Errorf("bundle: %s: %v", file, err)
+	}
+	if len(meta.Target) == 0 {
+		return Bundle{}, fmt.Errorf("bundle: %s: no .target", file)
+	}
+	b := Bundle{
+		Name:   strings.TrimSuffix(path.Base(file), path.Ext(file)) + "_" + meta.Target[0],
+		File:   file,
+		Format: PTX,
+		Arch:   meta.Target[0],
+	}
+	for _, e := range meta.Entries {
+		b.Kernels = append(b.Kernels, e.Name)
+	}
+	sort.Strings(b.Kernels)
+	b.DataTypes = DataTypes(b.Kernels)
+	return b, nil
+}
+
+//Marshal returns the json of the manifest the way it is written in manifest.json.
+func (m Manifest) Marshal() ([]byte, error) {
+	b, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,269 @@
+package bundle
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/negativeOne1/gocudnn/cuda/ptx"
+)
+
+var update = flag.Bool("update", false, "rewrite manifest.json from the ptx files")
+
+//manifestfromptx makes the manifest of the ptx files under dir.
+func manifestfromptx(fsys fs.FS, dir string) (Manifest, error) {
+	var m Manifest
+	err := fs.WalkDir(fsys, dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(p) != ".ptx" {
+			return err
+		}
+		src, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		b, err := PTXBundle(p, src)
+		if err != nil {
+			return err
+		}
+		m.Bundles = append(m.Bundles, b)
+		return nil
+	})
+	return m, err
+}
+
+func TestManifest(t *testing.T) {
+	want, err := manifestfromptx(embedded, "ptx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantb, err := want.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *update {
+		if err = os.WriteFile(ManifestFile, wantb, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	got, err := embedded.ReadFile(ManifestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(wantb) {
+		t.Error("manifest.json doesn't match the ptx files. Run go test -update")
+	}
+}
+
+func TestDefault(t *testing.T) {
+	r, err := Default()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Bundles()) == 0 {
+		t.Fatal("no bundles")
+	}
+	for _, b := range r.Bundles() {
+		data, err := r.Data(b)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		meta, err := ptx.Parse(string(data))
+		if err != nil {
+			t.Error(b.Name, err)
+			continue
+		}
+		for _, k := range b.Kernels {
+			if _, ok := meta.Entry(k); !ok {
+				t.Error(b.Name, "doesn't have", k)
+			}
+		}
+	}
+	for _, cc := range [][2]int{{6, 1}, {7, 5}} {
+		b, err := r.Select(cc[0], cc[1], Float, Half)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		if major, minor := b.Compute(); major != cc[0] || minor != cc[1] {
+			t.Error("sm", cc, "selected", b.Name)
+		}
+		for _, k := range []string{"Transpose", "ConcatNHWCEXHalf", "SoftMaxAverageLoss", "MSELossFP16"} {
+			if !b.Has(k) {
+				t.Error(b.Name, "doesn't have", k)
+			}
+		}
+	}
+}
+
+func testfs() fstest.MapFS {
+	return fstest.MapFS{
+		"a.ptx":    {Data: []byte("a")},
+		"b.ptx":    {Data: []byte("b")},
+		"c.cubin":  {Data: []byte("c")},
+		"d.ptx":    {Data: []byte("d")},
+		"e.fatbin": {Data: []byte("e")},
+	}
+}
+
+func testmanifest() Manifest {
+	return Manifest{Bundles: []Bundle{
+		{Name: "a", File: "a.ptx", Format: PTX, Arch: "sm_61", DataTypes: []DataType{Float}, Kernels: []string{"K", "Old"}},
+		{Name: "b", File: "b.ptx", Format: PTX, Arch: "sm_75", DataTypes: []DataType{Float, Half}, Kernels: []string{"K", "KFP16"}},
+		{Name: "c", File: "c.cubin", Format: Cubin, Arch: "sm_70", DataTypes: []DataType{Float}, Kernels: []string{"K"}},
+		{Name: "d", File: "d.ptx", Format: PTX, Arch: "compute_75", DataTypes: []DataType{Float, Half}, Kernels: []string{"K", "KFP16", "L"}},
+		{Name: "e", File: "e.fatbin", Format: Fatbin, Arch: "sm_72", DataTypes: []DataType{Half}, Kernels: []string{"KFP16"}},
+	}}
+}
+
+func TestSelect(t *testing.T) {
+	r, err := NewManifest(testfs(), testmanifest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		major, minor int
+		dtypes       []DataType
+		want         string
+	}{
+		{6, 1, nil, "a"},
+		{6, 2, []DataType{Float}, "a"},
+		{7, 0, []DataType{Float}, "c"},
+		{7, 2, nil, "e"},
+		{7, 2, []DataType{Float}, "c"},
+		{7, 0, []DataType{Half}, ""},
+		{7, 5, []DataType{Float, Half}, "d"},
+		{8, 6, []DataType{Half}, "d"},
+		{8, 6, []DataType{Float}, "d"},
+		{5, 2, nil, ""},
+		{7, 5, []DataType{Half}, "e"},
+	}
+	for _, tt := range tests {
+		b, err := r.Select(tt.major, tt.minor, tt.dtypes...)
+		if tt.want == "" {
+			if !errors.Is(err, ErrNoBundle) {
+				t.Error(tt.major, tt.minor, tt.dtypes, "want ErrNoBundle got", b, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(tt.major, tt.minor, tt.dtypes, err)
+			continue
+		}
+		if b.Name != tt.want {
+			t.Error(tt.major, tt.minor, tt.dtypes, "got", b.Name, "want", tt.want)
+		}
+	}
+	b, _ := r.Bundle("c")
+	data, err := r.Data(b)
+	if err != nil || string(data) != "c" {
+		t.Error(string(data), err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	r, err := NewManifest(testfs(), testmanifest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		kernel       string
+		major, minor int
+		want         string
+		err          error
+	}{
+		{"Old", 7, 5, "a", nil},
+		{"Old", 6, 0, "", ErrNoBundle},
+		{"K", 7, 0, "c", nil},
+		{"K", 8, 0, "d", nil},
+		{"KFP16", 7, 0, "", ErrNoBundle},
+		{"KFP16", 7, 5, "e", nil},
+		{"KFP16", 7, 1, "", ErrNoBundle},
+		{"L", 7, 5, "d", nil},
+		{"Missing", 7, 5, "", ErrNoKernel},
+	}
+	for _, tt := range tests {
+		b, err := r.Lookup(tt.kernel, tt.major, tt.minor)
+		if tt.err != nil {
+			if !errors.Is(err, tt.err) {
+				t.Error(tt.kernel, tt.major, tt.minor, "want", tt.err, "got", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(tt.kernel, err)
+			continue
+		}
+		if b.Name != tt.want {
+			t.Error(tt.kernel, tt.major, tt.minor, "got", b.Name, "want", tt.want)
+		}
+	}
+	if got := r.Kernels(); strings.Join(got, ",") != "K,KFP16,L,Old" {
+		t.Error(got)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	fsys := testfs()
+	bad := []Bundle{
+		{Name: "", File: "a.ptx", Format: PTX, Arch: "sm_61"},
+		{Name: "x", File: "a.ptx", Format: "fatbinary", Arch: "sm_61"},
+		{Name: "x", File: "a.ptx", Format: PTX, Arch: "61"},
+		{Name: "x", File: "a.ptx", Format: PTX, Arch: "sm_x"},
+		{Name: "x", File: "c.cubin", Format: Cubin, Arch: "compute_70"},
+		{Name: "x", File: "missing.ptx", Format: PTX, Arch: "sm_61"},
+	}
+	for _, b := range bad {
+		if _, err := NewManifest(fsys, Manifest{Bundles: []Bundle{b}}); err == nil {
+			t.Error("no error for", b)
+		}
+	}
+	dup := Manifest{Bundles: []Bundle{
+		{Name: "x", File: "a.ptx", Format: PTX, Arch: "sm_61"},
+		{Name: "x", File: "b.ptx", Format: PTX, Arch: "sm_75"},
+	}}
+	if _, err := NewManifest(fsys, dup); err == nil {
+		t.Error("no error for duplicate names")
+	}
+	fsys[ManifestFile] = &fstest.MapFile{Data: []byte(`{"bundles":[{"name":"a","file":"a.ptx","format":"ptx","arch":"sm_61","kernels":["K"]}]}`)}
+	r, err := New(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, err := r.Lookup("K", 6, 1); err != nil || b.Name != "a" {
+		t.Error(b, err)
+	}
+}
+
+func TestDataTypes(t *testing.T) {
+	tests := []struct {
+		kernels []string
+		want    string
+	}{
+		{[]string{"Adam"}, "[float]"},
+		{[]string{"AdamFP16", "ConcatNHWCEXHalf"}, "[half]"},
+		{[]string{"Adam", "ConcatForwardNCHWhalf"}, "[float half]"},
+		{nil, "[]"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprint(DataTypes(tt.kernels)); got != tt.want {
+			t.Error(tt.kernels, got, tt.want)
+		}
+	}
+}
+
+func TestParseArch(t *testing.T) {
+	for s, want := range map[string][2]int{"sm_61": {6, 1}, "sm_75": {7, 5}, "compute_86": {8, 6}, "sm_100": {10, 0}} {
+		major, minor, err := ParseArch(s)
+		if err != nil || major != want[0] || minor != want[1] {
+			t.Error(s, major, minor, err)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+//Package checkpoint is the file format for saving and resuming the state of the xtra trainers.
+//
+//A Checkpoint holds, for each parameter tensor, the weights, the optimizer accumulators, the step counter and the
+//trainer params, along with the scheduler state and anything else the caller wants to keep.
+//xtra.SaveCheckpoint and xtra.LoadCheckpoint move it to and from the device.  This package is pure go.
+//
+//The file is little endian.
+//
+//	magic   [4]byte "GCCK"
+//	version uint32
+//	hlen    uint32
+//	header  [hlen]byte json of the Checkpoint
+//	tensors every Tensor of every Param in order, each written with tensorutil.WriteTensor
+package checkpoint
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+//Version is the version of the file format written by Write.
+const Version = uint32(1)
+
+const magic = "GCCK"
+
+//maxheader is the largest header Read will take.
+const maxheader = 1 << 26
+
+//ErrIncompatible is wrapped by the errors of Compatible.
+var ErrIncompatible = errors.New("checkpoint: incompatible tensor")
+
+//Tensor is a host copy of a tensor.  Data is a slice of the element type, such as []float32 or []half.Float16.
+type Tensor struct {
+	Name string          `json:"name"`
+	Desc tensorutil.Desc `json:"-"`
+	Data interface{}     `json:"-"`
+}
+
+//Compatible returns an error wrapping ErrIncompatible if the tensor can't be restored to a tensor with desc and dtype.
+func (t Tensor) Compatible(desc tensorutil.Desc, dtype tensorutil.DataType) error {
+	got, err := tensorutil.TypeOf(t.Data)
+	if err != nil {
+		return fmt.Errorf("%w %s: %v", ErrIncompatible, t.Name, err)
+	}
+	if got != dtype {
+		return fmt.Errorf("%w %s: data type is %v, want %v", ErrIncompatible, t.Name, got, dtype)
+	}
+	if t.Desc.Layout != desc.Layout {
+		return fmt.Errorf("%w %s: layout is %v, want %v", ErrIncompatible, t.Name, t.Desc.Layout, desc.Layout)
+	}
+	if !equal(t.Desc.Dims, desc.Dims) || !equal(t.Desc.Strides, desc.Strides) || t.Desc.Vect != desc.Vect {
+		return fmt.Errorf("%w %s: dims are %v, want %v", ErrIncompatible, t.Name, t.Desc.Dims, desc.Dims)
+	}
+	return nil
+}
+
+func equal(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//Param is the state of one parameter tensor.
+type Param struct {
+	Name     string             `json:"name"`
+	Mode     int32              `json:"mode"` //xtra.TrainingMode
+	Counter  int32              `json:"counter"`
+	Training map[string]float32 `json:"training,omitempty"` //Values of xtra.TrainingParams by name, and of the params of the other modes as "mode.name"
+	Reg      map[string]float32 `json:"reg,omitempty"`      //Values of xtra.RegParams by name
+	EMAStep  int                `json:"emastep,omitempty"`  //Step of the xtra.EMA of the weights, if they have one
+	Tensors  []Tensor           `json:"tensors"`            //Weights first, then the accumulators, then the EMA shadow weights
+}
+
+//Tensor returns the tensor called name.
+func (p *Param) Tensor(name string) (*Tensor, bool) {
+	for i := range p.Tensors {
+		if p.Tensors[i].Name == name {
+			return &p.Tensors[i], true
+		}
+	}
+	return nil, false
+}
+
+//Checkpoint is the whole training state.
+type Checkpoint struct {
+	Version   uint32                     `json:"version"`
+	Params    []Param                    `json:"params"`
+	Scheduler json.RawMessage            `json:"scheduler,omitempty"` //From schedule.Marshal
+	Extra     map[string]json.RawMessage `json:"extra,omitempty"`     //Anything else, such as a lossscale.Policy or the epoch
+}
+
+//Param returns the param called name.
+func (c *Checkpoint) Param(name string) (*Param, bool) {
+	for i := range c.Params {
+		if c.Params[i].Name == name {
+			return &c.Params[i], true
+		}
+	}
+	return nil, false
+}
+
+//Write writes c to w.  Version is set to the version being written.
+func Write(w io.Writer, c *Checkpoint) error {
+	names := make(map[string]bool, len(c.Params))
+	for _, p := range c.Params {
+		if names[p.Name] {
+			return fmt.Errorf("checkpoint: param %q is in the checkpoint more than once", p.Name)
+		}
+		names[p.Name] = true
+	}
+	c.Version = Version
+	header, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if _, err = bw.WriteString(magic); err != nil {
+		return err
+	}
+	for _, x := range []interface{}{Version, uint32(len(header)), header} {
+		if err = binary.Write(bw, binary.LittleEndian, x); err != nil {
+			return err
+		}
+	}
+	for _, p := range c.Params {
+		for _, t := range p.Tensors {
+			if err = tensorutil.WriteTensor(bw, t.Desc, t.Data); err != nil {
+				return fmt.Errorf("checkpoint: param %s tensor %s: %v", p.Name, t.Name, err)
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+//Read reads a Checkpoint written by Write.
+func Read(r io.Reader) (*Checkpoint, error) {
+	br := bufio.NewReader(r)
+	m := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, m); err != nil {
+		return nil, err
+	}
+	if string(m) != magic {
+		return nil, errors.New("checkpoint: not a checkpoint file")
+	}
+	var version, hlen uint32
+	if err := binary.Read(br, binary.LittleEndian, &version); err != nil {
+		return nil, err
+	}
+	if version != Version {
+		return nil, fmt.Errorf("checkpoint: unsupported version %d", version)
+	}
+	if err := binary.Read(br, binary.LittleEndian, &hlen); err != nil {
+		return nil, err
+	}
+	if hlen > maxheader {
+		return nil, fmt.Errorf("checkpoint: header of %d bytes is too big", hlen)
+	}
+	header := make([]byte, hlen)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, err
+	}
+	c := new(Checkpoint)
+	if err := json.Unmarshal(header, c); err != nil {
+		return nil, err
+	}
+	for i := range c.Params {
+		p := &c.Params[i]
+		for j := range p.Tensors {
+			//br is passed on so tensorutil doesn't buffer past the tensor
+			d, data, err := tensorutil.ReadTensor(br)
+			if err != nil {
+				return nil, fmt.Errorf("checkpoint: param %s tensor %s: %v", p.Name, p.Tensors[j].Name, err)
+			}
+			p.Tensors[j].Desc, p.Tensors[j].Data = d, data
+		}
+	}
+	return c, nil
+}
+
+//Save writes c to a file
+func Save(filename string, c *Checkpoint) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	err = Write(f, c)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+//Load reads a Checkpoint from a file written by Save
+func Load(filename string) (*Checkpoint, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
@@ -0,0 +1,122 @@
+package checkpoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dereklstinson/half"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+func testcheckpoint() *Checkpoint {
+	d := tensorutil.Desc{Layout: tensorutil.NCHW, Dims: []int32{1, 2, 1, 2}}
+	return &Checkpoint{
+		Params: []Param{
+			{
+				Name:     "conv1",
+				Mode:     4,
+				Counter:  7,
+				Training: map[string]float32{"rate": .001, "beta1": .9, "adamw.decay": .01},
+				Reg:      map[string]float32{"decay2": 1e-4},
+				EMAStep:  6,
+				Tensors: []Tensor{
+					{Name: "w", Desc: d, Data: []float32{1, 2, 3, 4}},
+					{Name: "gsum", Desc: d, Data: []float32{.1, .2, .3, .4}},
+					{Name: "ema", Desc: d, Data: []float32{.9, 1.9, 2.9, 3.9}},
+				},
+			},
+			{
+				Name:    "fc",
+				Tensors: []Tensor{{Name: "w", Desc: d, Data: half.NewFloat16Array([]float32{-1, 0, 1, 2})}},
+			},
+		},
+		Scheduler: json.RawMessage(`{"kind":"exponential","scheduler":{"base":1,"gamma":0.5,"steps":3}}`),
+		Extra:     map[string]json.RawMessage{"epoch": json.RawMessage(`12`)},
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	c := testcheckpoint()
+	var b bytes.Buffer
+	if err := Write(&b, c); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Read(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Version != Version || !reflect.DeepEqual(r, c) {
+		t.Errorf("got %+v\nwant %+v", r, c)
+	}
+	p, ok := r.Param("conv1")
+	if !ok || p.Counter != 7 || p.EMAStep != 6 {
+		t.Fatal(p)
+	}
+	if g, ok := p.Tensor("gsum"); !ok || g.Data.([]float32)[3] != .4 {
+		t.Error(g)
+	}
+	if _, ok = r.Param("nope"); ok {
+		t.Error("found a param that isn't there")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "ck")
+	c := testcheckpoint()
+	if err := Save(name, c); err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r, c) {
+		t.Error(r)
+	}
+}
+
+func TestBadFiles(t *testing.T) {
+	if _, err := Read(bytes.NewReader([]byte("GCDT0000"))); err == nil {
+		t.Error("expected an error for the wrong magic")
+	}
+	var b bytes.Buffer
+	Write(&b, testcheckpoint())
+	raw := b.Bytes()
+	raw[4] = 99
+	if _, err := Read(bytes.NewReader(raw)); err == nil {
+		t.Error("expected an error for the wrong version")
+	}
+	b.Reset()
+	Write(&b, testcheckpoint())
+	if _, err := Read(bytes.NewReader(b.Bytes()[:b.Len()-3])); err == nil {
+		t.Error("expected an error for a short file")
+	}
+	c := testcheckpoint()
+	c.Params[1].Name = "conv1"
+	if err := Write(&b, c); err == nil {
+		t.Error("expected an error for a repeated param")
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	tn := testcheckpoint().Params[0].Tensors[0]
+	d := tn.Desc
+	if err := tn.Compatible(d, tensorutil.Float); err != nil {
+		t.Error(err)
+	}
+	if err := tn.Compatible(d, tensorutil.Half); !errors.Is(err, ErrIncompatible) {
+		t.Error(err)
+	}
+	d2 := tensorutil.Desc{Layout: tensorutil.NCHW, Dims: []int32{1, 4, 1, 1}}
+	if err := tn.Compatible(d2, tensorutil.Float); !errors.Is(err, ErrIncompatible) {
+		t.Error(err)
+	}
+	d2 = tensorutil.Desc{Layout: tensorutil.NHWC, Dims: d.Dims}
+	if err := tn.Compatible(d2, tensorutil.Float); !errors.Is(err, ErrIncompatible) {
+		t.Error(err)
+	}
+}
@@ -35,20 +35,20 @@ func CreateConcatEx(h *Handle) (c *ConcatEx, err error) {
 	var ktf kernels.XtraKerns
 	if h.w != nil {
 		err = h.w.Work(func() error {
-			c.fp32.nhwc, err = cuda.MakeKernel(ktf.ConcatNHWCEX(), h.mod)
+			c.fp32.nhwc, err = h.kernel(ktf.ConcatNHWCEX())
 			if err != nil {
 				return err
 			}
-			c.fp32.nchw, err = cuda.MakeKernel(ktf.ConcatNCHWEX(), h.mod)
+			c.fp32.nchw, err = h.kernel(ktf.ConcatNCHWEX())
 			if err != nil {
 				return err
 			}
 
-			c.fp16.nhwc, err = cuda.MakeKernel(ktf.ConcatNHWCEXHalf(), h.mod)
+			c.fp16.nhwc, err = h.kernel(ktf.ConcatNHWCEXHalf())
 			if err != nil {
 				return err
 			}
-			c.fp16.nchw, err = cuda.MakeKernel(ktf.ConcatNCHWEXHalf(), h.mod)
+			c.fp16.nchw, err = h.kernel(ktf.ConcatNCHWEXHalf())
 			if err != nil {
 				return err
 			}
@@ -56,20 +56,20 @@ func CreateConcatEx(h *Handle) (c *ConcatEx, err error) {
 		})
 
 	} else {
-		c.fp32.nhwc, err = cuda.MakeKernel(ktf.ConcatNHWCEX(), h.mod)
+		c.fp32.nhwc, err = h.kernel(ktf.ConcatNHWCEX())
 		if err != nil {
 			return nil, err
 		}
-		c.fp32.nchw, err = cuda.MakeKernel(ktf.ConcatNCHWEX(), h.mod)
+		c.fp32.nchw, err = h.kernel(ktf.ConcatNCHWEX())
 		if err != nil {
 			return nil, err
 		}
 
-		c.fp16.nhwc, err = cuda.MakeKernel(ktf.ConcatNHWCEXHalf(), h.mod)
+		c.fp16.nhwc, err = h.kernel(ktf.ConcatNHWCEXHalf())
 		if err != nil {
 			return nil, err
 		}
-		c.fp16.nchw, err = cuda.MakeKernel(ktf.ConcatNCHWEXHalf(), h.mod)
+		c.fp16.nchw, err = h.kernel(ktf.ConcatNCHWEXHalf())
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,77 @@
+//Package lossscale is the policy of dynamic loss scaling for half precision training.
+//
+//The loss gradient is multiplied by Scale before the backward pass so that small gradients don't underflow in half.
+//After the backward pass the gradients are divided by Scale and checked for Inf and NaN.
+//Update is then called with the result of the check.  If there was an overflow the step is skipped and the scale is cut by BackoffFactor.
+//After GrowthInterval clean steps in a row the scale is multiplied by GrowthFactor.
+//
+//xtra.LossScaler does the device side.  This package doesn't need a gpu.
+package lossscale
+
+import "math"
+
+//Defaults used by New
+const (
+	DefaultScale          = 65536
+	DefaultGrowthFactor   = 2
+	DefaultBackoffFactor  = .5
+	DefaultGrowthInterval = 2000
+)
+
+//Policy is the loss scale state machine.  The exported fields are its config and state, so it can be saved with encoding/json.
+type Policy struct {
+	Scale          float32 `json:"scale"`
+	GrowthFactor   float32 `json:"growth_factor"`
+	BackoffFactor  float32 `json:"backoff_factor"`
+	GrowthInterval int     `json:"growth_interval"`
+	MinScale       float32 `json:"min_scale"` //The scale won't be cut below MinScale
+	MaxScale       float32 `json:"max_scale"` //The scale won't grow above MaxScale. Zero is no limit.
+
+	CleanSteps int `json:"clean_steps"` //Clean steps since the last overflow or growth
+	Steps      int `json:"steps"`       //Steps taken
+	Skipped    int `json:"skipped"`     //Steps skipped because of an overflow
+}
+
+//New returns a Policy with the defaults.  MinScale is 1 and MaxScale is 2^24.
+func New() *Policy {
+	return &Policy{
+		Scale:          DefaultScale,
+		GrowthFactor:   DefaultGrowthFactor,
+		BackoffFactor:  DefaultBackoffFactor,
+		GrowthInterval: DefaultGrowthInterval,
+		MinScale:       1,
+		MaxScale:       1 << 24,
+	}
+}
+
+//Unscale returns 1/Scale. It is what the gradients are multiplied by after the backward pass.
+func (p *Policy) Unscale() float32 {
+	return 1 / p.Scale
+}
+
+//Update moves the policy with the result of the overflow check of a step.
+//It returns true if the optimizer should take the step, and false if it should be skipped.
+func (p *Policy) Update(overflow bool) (step bool) {
+	if overflow {
+		p.Skipped++
+		p.CleanSteps = 0
+		p.Scale *= p.BackoffFactor
+		if p.Scale < p.MinScale {
+			p.Scale = p.MinScale
+		}
+		return false
+	}
+	p.Steps++
+	p.CleanSteps++
+	if p.GrowthInterval > 0 && p.CleanSteps >= p.GrowthInterval {
+		p.CleanSteps = 0
+		scale := p.Scale * p.GrowthFactor
+		if p.MaxScale > 0 && scale > p.MaxScale {
+			scale = p.MaxScale
+		}
+		if !math.IsInf(float64(scale), 0) {
+			p.Scale = scale
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package lossscale
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	p := &Policy{Scale: 8, GrowthFactor: 2, BackoffFactor: .5, GrowthInterval: 3, MinScale: 1, MaxScale: 32}
+	if p.Update(true) || p.Scale != 4 || p.Skipped != 1 {
+		t.Error("an overflow should skip and halve", p)
+	}
+	for i := 0; i < 2; i++ {
+		if !p.Update(false) || p.Scale != 4 {
+			t.Error(i, p)
+		}
+	}
+	if !p.Update(false) || p.Scale != 8 || p.CleanSteps != 0 {
+		t.Error("the scale should grow after 3 clean steps", p)
+	}
+	p.Update(false)
+	p.Update(false)
+	p.Update(true)
+	if p.Scale != 4 || p.CleanSteps != 0 {
+		t.Error("an overflow should reset the clean steps", p)
+	}
+	for i := 0; i < 20; i++ {
+		p.Update(false)
+	}
+	if p.Scale != 32 {
+		t.Error("the scale should stop at MaxScale", p.Scale)
+	}
+	for i := 0; i < 20; i++ {
+		p.Update(true)
+	}
+	if p.Scale != 1 || p.Unscale() != 1 {
+		t.Error("the scale should stop at MinScale", p.Scale)
+	}
+	if p.Steps != 25 || p.Skipped != 22 {
+		t.Error(p.Steps, p.Skipped)
+	}
+}
+
+func TestNew(t *testing.T) {
+	p := New()
+	if p.Scale != DefaultScale || p.Unscale() != 1.0/DefaultScale {
+		t.Error(p)
+	}
+	p.Update(true)
+	b, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r Policy
+	if err = json.Unmarshal(b, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r != *p {
+		t.Error(r, *p)
+	}
+}
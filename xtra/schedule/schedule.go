@@ -0,0 +1,389 @@
+//Package schedule has learning rate schedulers for the xtra trainers.
+//
+//A Scheduler gives the Values for the current step, and Step moves it to the next one.
+//Each scheduler is a struct of its config and its state, so it can be saved with Marshal and restored with Unmarshal
+//along with the rest of the training state.
+//
+//	s := &schedule.Warmup{Steps: 500, Start: .01, After: &schedule.CosineRestarts{Base: .1, Min: 1e-4, Period: 1000, Mult: 2}}
+//	for i := 0; i < n; i++ {
+//		params.SetScheduled(s.Values())
+//		...
+//		s.Step()
+//	}
+//
+//It is all host math and doesn't need a gpu.
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+//Values are what a scheduler gives for a step.
+//Momentum is the momentum of the Momentum and Nesterov modes, or beta1 of the adam modes.
+//It is only used if HasMomentum is true.
+type Values struct {
+	Rate        float32
+	Momentum    float32
+	HasMomentum bool
+}
+
+//Scheduler gives the values for each step.
+type Scheduler interface {
+	Values() Values //Values for the current step
+	Step()          //Moves to the next step
+	Kind() string   //Name used by Marshal
+}
+
+//Observer is a Scheduler that changes with a metric such as the validation loss. Plateau is an Observer.
+type Observer interface {
+	Scheduler
+	Observe(metric float64) bool
+}
+
+var kinds = map[string]func() Scheduler{
+	"step":            func() Scheduler { return new(StepDecay) },
+	"exponential":     func() Scheduler { return new(Exponential) },
+	"cosine_restarts": func() Scheduler { return new(CosineRestarts) },
+	"warmup":          func() Scheduler { return new(Warmup) },
+	"one_cycle":       func() Scheduler { return new(OneCycle) },
+	"plateau":         func() Scheduler { return new(Plateau) },
+}
+
+type envelope struct {
+	Kind      string          `json:"kind"`
+	Scheduler json.RawMessage `json:"scheduler"`
+}
+
+//Marshal returns the json of s with its kind, so it can be restored with Unmarshal.
+func Marshal(s Scheduler) ([]byte, error) {
+	if s == nil {
+		return nil, fmt.Errorf("schedule.Marshal: nil Scheduler")
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(envelope{Kind: s.Kind(), Scheduler: b})
+}
+
+//Unmarshal restores a Scheduler that was saved with Marshal.
+func Unmarshal(b []byte) (Scheduler, error) {
+	var e envelope
+	if err := json.Unmarshal(b, &e); err != nil {
+		return nil, err
+	}
+	mk, ok := kinds[e.Kind]
+	if !ok {
+		return nil, fmt.Errorf("schedule.Unmarshal: unknown kind %q", e.Kind)
+	}
+	s := mk()
+	if err := json.Unmarshal(e.Scheduler, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+//StepDecay multiplies Base by Gamma every StepSize steps.
+type StepDecay struct {
+	Base     float32 `json:"base"`
+	Gamma    float32 `json:"gamma"`
+	StepSize int     `json:"step_size"`
+	Steps    int     `json:"steps"` //Steps taken
+}
+
+//Values satisfies Scheduler
+func (s *StepDecay) Values() Values {
+	size := s.StepSize
+	if size < 1 {
+		size = 1
+	}
+	return Values{Rate: s.Base * pow32(s.Gamma, s.Steps/size)}
+}
+
+//Step satisfies Scheduler
+func (s *StepDecay) Step() { s.Steps++ }
+
+//Kind satisfies Scheduler
+func (s *StepDecay) Kind() string { return "step" }
+
+//Exponential multiplies Base by Gamma every step.
+type Exponential struct {
+	Base  float32 `json:"base"`
+	Gamma float32 `json:"gamma"`
+	Steps int     `json:"steps"`
+}
+
+//Values satisfies Scheduler
+func (s *Exponential) Values() Values {
+	return Values{Rate: s.Base * pow32(s.Gamma, s.Steps)}
+}
+
+//Step satisfies Scheduler
+func (s *Exponential) Step() { s.Steps++ }
+
+//Kind satisfies Scheduler
+func (s *Exponential) Kind() string { return "exponential" }
+
+//CosineRestarts is cosine annealing with warm restarts (SGDR).  The rate goes from Base to Min over Period steps and then restarts.
+//Each period is Mult times longer than the one before it.  A Mult less than 1 is 1.
+type CosineRestarts struct {
+	Base   float32 `json:"base"`
+	Min    float32 `json:"min"`
+	Period int     `json:"period"`
+	Mult   int     `json:"mult"`
+	Steps  int     `json:"steps"`
+}
+
+//Values satisfies Scheduler
+func (s *CosineRestarts) Values() Values {
+	cur, period := s.Position()
+	return Values{Rate: cosine(s.Base, s.Min, float64(cur)/float64(period))}
+}
+
+//Position returns the step within the current period and the length of the period.
+func (s *CosineRestarts) Position() (cur, period int) {
+	period = s.Period
+	if period < 1 {
+		period = 1
+	}
+	mult := s.Mult
+	if mult < 1 {
+		mult = 1
+	}
+	cur = s.Steps
+	if mult == 1 {
+		return cur % period, period
+	}
+	for cur >= period {
+		cur -= period
+		period *= mult
+	}
+	return cur, period
+}
+
+//Step satisfies Scheduler
+func (s *CosineRestarts) Step() { s.Steps++ }
+
+//Kind satisfies Scheduler
+func (s *CosineRestarts) Kind() string { return "cosine_restarts" }
+
+//Warmup scales the rate of After linearly from Start*rate to rate over Steps steps. After doesn't step until the warmup is done.
+type Warmup struct {
+	Steps int       `json:"steps"`
+	Start float32   `json:"start"` //Fraction of the rate that the warmup starts at
+	After Scheduler `json:"-"`
+	Taken int       `json:"taken"` //Warmup steps taken
+}
+
+//Values satisfies Scheduler
+func (s *Warmup) Values() Values {
+	var v Values
+	if s.After != nil {
+		v = s.After.Values()
+	}
+	if s.Taken < s.Steps {
+		frac := float32(s.Taken) / float32(s.Steps)
+		v.Rate *= s.Start + (1-s.Start)*frac
+	}
+	return v
+}
+
+//Step satisfies Scheduler
+func (s *Warmup) Step() {
+	if s.Taken < s.Steps {
+		s.Taken++
+		return
+	}
+	if s.After != nil {
+		s.After.Step()
+	}
+}
+
+//Kind satisfies Scheduler
+func (s *Warmup) Kind() string { return "warmup" }
+
+type warmupjson struct {
+	Steps int             `json:"steps"`
+	Start float32         `json:"start"`
+	Taken int             `json:"taken"`
+	After json.RawMessage `json:"after,omitempty"`
+}
+
+//MarshalJSON saves After with its kind
+func (s *Warmup) MarshalJSON() ([]byte, error) {
+	w := warmupjson{Steps: s.Steps, Start: s.Start, Taken: s.Taken}
+	if s.After != nil {
+		b, err := Marshal(s.After)
+		if err != nil {
+			return nil, err
+		}
+		w.After = b
+	}
+	return json.Marshal(w)
+}
+
+//UnmarshalJSON restores After
+func (s *Warmup) UnmarshalJSON(b []byte) error {
+	var w warmupjson
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	s.Steps, s.Start, s.Taken, s.After = w.Steps, w.Start, w.Taken, nil
+	if len(w.After) > 0 && string(w.After) != "null" {
+		after, err := Unmarshal(w.After)
+		if err != nil {
+			return err
+		}
+		s.After = after
+	}
+	return nil
+}
+
+//OneCycle is the one cycle policy.  The rate goes from Max/DivFactor up to Max over the first PctStart of Total steps,
+//and then down to Max/(DivFactor*FinalDivFactor).  If CycleMomentum is true the momentum goes the other way between MaxMomentum and MinMomentum.
+//Steps past Total stay at the last value.  Zero values of DivFactor, FinalDivFactor and PctStart are 25, 1e4 and .3.
+type OneCycle struct {
+	Max            float32 `json:"max"`
+	Total          int     `json:"total"`
+	PctStart       float32 `json:"pct_start"`
+	DivFactor      float32 `json:"div_factor"`
+	FinalDivFactor float32 `json:"final_div_factor"`
+	Linear         bool    `json:"linear"` //Anneal linearly instead of with cosine
+	CycleMomentum  bool    `json:"cycle_momentum"`
+	MinMomentum    float32 `json:"min_momentum"`
+	MaxMomentum    float32 `json:"max_momentum"`
+	Steps          int     `json:"steps"`
+}
+
+//Values satisfies Scheduler
+func (s *OneCycle) Values() Values {
+	div, final, pct := s.DivFactor, s.FinalDivFactor, s.PctStart
+	if div == 0 {
+		div = 25
+	}
+	if final == 0 {
+		final = 1e4
+	}
+	if pct == 0 {
+		pct = .3
+	}
+	initial := s.Max / div
+	min := initial / final
+	up := float64(pct)*float64(s.Total) - 1
+	end := float64(s.Total) - 1
+	step := float64(s.Steps)
+	if step > end {
+		step = end
+	}
+	anneal := cosine
+	if s.Linear {
+		anneal = linear
+	}
+	var v Values
+	v.HasMomentum = s.CycleMomentum
+	if up > 0 && step <= up {
+		frac := step / up
+		v.Rate = anneal(initial, s.Max, frac)
+		if s.CycleMomentum {
+			v.Momentum = anneal(s.MaxMomentum, s.MinMomentum, frac)
+		}
+		return v
+	}
+	frac := 1.0
+	if end > up {
+		frac = (step - up) / (end - up)
+	}
+	v.Rate = anneal(s.Max, min, frac)
+	if s.CycleMomentum {
+		v.Momentum = anneal(s.MinMomentum, s.MaxMomentum, frac)
+	}
+	return v
+}
+
+//Step satisfies Scheduler
+func (s *OneCycle) Step() { s.Steps++ }
+
+//Kind satisfies Scheduler
+func (s *OneCycle) Kind() string { return "one_cycle" }
+
+//Plateau multiplies the rate by Factor when the metric passed to Observe hasn't gotten better for more than Patience observations.
+//A metric is better if it is lower than the best by more than Threshold of the best, or higher if Maximize is true.
+//After the rate is cut, Cooldown observations are ignored.  The rate won't go below MinRate.
+type Plateau struct {
+	Rate      float32 `json:"rate"`
+	Factor    float32 `json:"factor"`
+	Patience  int     `json:"patience"`
+	Threshold float64 `json:"threshold"`
+	Cooldown  int     `json:"cooldown"`
+	MinRate   float32 `json:"min_rate"`
+	Maximize  bool    `json:"maximize"`
+
+	Best         float64 `json:"best"`
+	Started      bool    `json:"started"` //Best is set
+	Bad          int     `json:"bad"`     //Observations since the last better one
+	CooldownLeft int     `json:"cooldown_left"`
+	Steps        int     `json:"steps"`
+}
+
+//Values satisfies Scheduler
+func (s *Plateau) Values() Values { return Values{Rate: s.Rate} }
+
+//Step satisfies Scheduler.  It only counts steps, since the rate only changes with Observe.
+func (s *Plateau) Step() { s.Steps++ }
+
+//Kind satisfies Scheduler
+func (s *Plateau) Kind() string { return "plateau" }
+
+//Observe passes the metric for an epoch.  It returns true if the rate was cut.
+func (s *Plateau) Observe(metric float64) bool {
+	if s.better(metric) {
+		s.Best, s.Started, s.Bad = metric, true, 0
+	} else {
+		s.Bad++
+	}
+	if s.CooldownLeft > 0 {
+		s.CooldownLeft--
+		s.Bad = 0
+	}
+	if s.Bad <= s.Patience {
+		return false
+	}
+	s.Bad = 0
+	s.CooldownLeft = s.Cooldown
+	rate := s.Rate * s.Factor
+	if rate < s.MinRate {
+		rate = s.MinRate
+	}
+	cut := rate != s.Rate
+	s.Rate = rate
+	return cut
+}
+
+func (s *Plateau) better(metric float64) bool {
+	if math.IsNaN(metric) {
+		return false
+	}
+	if !s.Started {
+		return true
+	}
+	if s.Maximize {
+		return metric > s.Best+math.Abs(s.Best)*s.Threshold
+	}
+	return metric < s.Best-math.Abs(s.Best)*s.Threshold
+}
+
+//cosine goes from start to end as frac goes from 0 to 1
+func cosine(start, end float32, frac float64) float32 {
+	return end + (start-end)*float32((1+math.Cos(math.Pi*frac))/2)
+}
+
+//linear goes from start to end as frac goes from 0 to 1
+func linear(start, end float32, frac float64) float32 {
+	return start + (end-start)*float32(frac)
+}
+
+func pow32(x float32, n int) float32 {
+	return float32(math.Pow(float64(x), float64(n)))
+}
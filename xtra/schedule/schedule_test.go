@@ -0,0 +1,165 @@
+package schedule
+
+import (
+	"math"
+	"testing"
+)
+
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) <= 1e-6*math.Max(1, math.Abs(float64(b)))
+}
+
+func rates(s Scheduler, n int) []float32 {
+	r := make([]float32, n)
+	for i := range r {
+		r[i] = s.Values().Rate
+		s.Step()
+	}
+	return r
+}
+
+func TestStepDecay(t *testing.T) {
+	r := rates(&StepDecay{Base: 1, Gamma: .5, StepSize: 2}, 5)
+	want := []float32{1, 1, .5, .5, .25}
+	for i := range want {
+		if !near(r[i], want[i]) {
+			t.Error(i, r[i], want[i])
+		}
+	}
+}
+
+func TestExponential(t *testing.T) {
+	r := rates(&Exponential{Base: 2, Gamma: .5}, 3)
+	if !near(r[0], 2) || !near(r[1], 1) || !near(r[2], .5) {
+		t.Error(r)
+	}
+}
+
+func TestCosineRestarts(t *testing.T) {
+	s := &CosineRestarts{Base: 1, Min: 0, Period: 4, Mult: 2}
+	r := rates(s, 13)
+	//first period is 4 steps, the second is 8
+	want := map[int]float32{0: 1, 2: .5, 4: 1, 8: .5, 12: 1}
+	for i, w := range want {
+		if !near(r[i], w) {
+			t.Error(i, r[i], w)
+		}
+	}
+	if r[3] >= r[2] || r[5] >= r[4] {
+		t.Error("rate should go down within a period", r)
+	}
+	s = &CosineRestarts{Base: 1, Min: .5, Period: 2, Steps: 3}
+	if cur, period := s.Position(); cur != 1 || period != 2 {
+		t.Error(cur, period)
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	s := &Warmup{Steps: 4, Start: 0, After: &Exponential{Base: 1, Gamma: .5}}
+	r := rates(s, 6)
+	want := []float32{0, .25, .5, .75, 1, .5}
+	for i := range want {
+		if !near(r[i], want[i]) {
+			t.Error(i, r[i], want[i])
+		}
+	}
+}
+
+func TestOneCycle(t *testing.T) {
+	s := &OneCycle{Max: 1, Total: 11, PctStart: .5, DivFactor: 10, FinalDivFactor: 100, Linear: true, CycleMomentum: true, MinMomentum: .85, MaxMomentum: .95}
+	v := s.Values()
+	if !near(v.Rate, .1) || !near(v.Momentum, .95) || !v.HasMomentum {
+		t.Error(v)
+	}
+	//peak is at step 4.5 so step 4 is still going up and step 5 is coming down
+	s.Steps = 10
+	v = s.Values()
+	if !near(v.Rate, .001) || !near(v.Momentum, .95) {
+		t.Error(v)
+	}
+	s.Steps = 100
+	if v2 := s.Values(); v2 != v {
+		t.Error("steps past Total should stay at the last value", v2)
+	}
+	c := &OneCycle{Max: 1, Total: 101}
+	r := rates(c, 101)
+	peak := 0
+	for i := range r {
+		if r[i] > r[peak] {
+			peak = i
+		}
+	}
+	if peak != 29 && peak != 30 || !near(r[0], .04) || r[100] > 1e-5 {
+		t.Error(peak, r[0], r[100])
+	}
+	if c.Values().HasMomentum {
+		t.Error("momentum should not be set")
+	}
+}
+
+func TestPlateau(t *testing.T) {
+	s := &Plateau{Rate: 1, Factor: .5, Patience: 1, Cooldown: 1, MinRate: .2}
+	metrics := []float64{1, .5, .6, .7, .8, .9, 1, 1.1, 1.2}
+	var got []float32
+	for _, m := range metrics {
+		s.Observe(m)
+		got = append(got, s.Values().Rate)
+	}
+	want := []float32{1, 1, 1, .5, .5, .5, .25, .25, .25}
+	for i := range want {
+		if !near(got[i], want[i]) {
+			t.Error(i, got, want)
+			break
+		}
+	}
+	for i := 0; i < 10; i++ {
+		s.Observe(2)
+	}
+	if s.Rate != .2 {
+		t.Error("rate should stop at MinRate", s.Rate)
+	}
+	m := &Plateau{Rate: 1, Factor: .1, Maximize: true}
+	m.Observe(1)
+	if m.Observe(2) || !m.Observe(1) {
+		t.Error(m)
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	scheds := []Scheduler{
+		&StepDecay{Base: 1, Gamma: .5, StepSize: 3},
+		&Exponential{Base: 1, Gamma: .9},
+		&CosineRestarts{Base: 1, Min: .1, Period: 5, Mult: 2},
+		&Warmup{Steps: 3, Start: .1, After: &CosineRestarts{Base: 1, Period: 5}},
+		&OneCycle{Max: 1, Total: 20, CycleMomentum: true, MinMomentum: .85, MaxMomentum: .95},
+		&Plateau{Rate: 1, Factor: .5},
+	}
+	for _, s := range scheds {
+		for i := 0; i < 7; i++ {
+			s.Step()
+		}
+		if o, ok := s.(Observer); ok {
+			o.Observe(1)
+			o.Observe(2)
+			o.Observe(3)
+		}
+		b, err := Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r, err := Unmarshal(b)
+		if err != nil {
+			t.Fatal(s.Kind(), err)
+		}
+		for i := 0; i < 5; i++ {
+			if r.Values() != s.Values() {
+				t.Error(s.Kind(), i, r.Values(), s.Values())
+			}
+			r.Step()
+			s.Step()
+		}
+	}
+	if _, err := Unmarshal([]byte(`{"kind":"nope","scheduler":{}}`)); err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+}
@@ -0,0 +1,180 @@
+package xtra
+
+import (
+	"errors"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cuda"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/xtra/accumulate"
+	"github.com/negativeOne1/gocudnn/xtra/xtrakerns"
+)
+
+//Accumulator adds up gradients over several micro-batches before one optimizer step.
+//
+//Register the gradient buffers, and do the backward passes with ConvBackwardFilter, ConvBackwardBias and BatchNormBackward,
+//which add to the buffers. Call Add after each micro-batch and when it returns true call Step.
+//Step scales the gradients by 1/(effective batch), runs the optimizer, zeroes the gradients, and starts the next accumulation.
+//The counting is done by an accumulate.Schedule.
+type Accumulator struct {
+	sched    *accumulate.Schedule
+	grads    []Gradient
+	kscale   *cuda.Kernel
+	kscale16 *cuda.Kernel
+}
+
+//NewAccumulator makes an Accumulator that takes an optimizer step every steps micro-batches.
+func NewAccumulator(h *Handle, steps int) (*Accumulator, error) {
+	sched, err := accumulate.New(steps)
+	if err != nil {
+		return nil, err
+	}
+	a := &Accumulator{sched: sched}
+	run := func() error {
+		ks, err := h.makekernels(xtrakerns.LossScale(), xtrakerns.LossScaleFP16())
+		if err != nil {
+			return err
+		}
+		a.kscale, a.kscale16 = ks[0], ks[1]
+		return nil
+	}
+	if h.w != nil {
+		return a, h.w.Work(run)
+	}
+	return a, run()
+}
+
+//Schedule returns the schedule that a uses.
+func (a *Accumulator) Schedule() *accumulate.Schedule {
+	return a.sched
+}
+
+//Register adds a gradient buffer to a and zeroes it.  The desc of a filter gradient is the TensorD that is passed to TrainValues.
+func (a *Accumulator) Register(h *Handle, g Gradient) error {
+	if g.Desc == nil || g.Mem == nil {
+		return errors.New("(a *Accumulator) Register: nil Gradient")
+	}
+	if a.sched.Count() != 0 {
+		return errors.New("(a *Accumulator) Register: can't register in the middle of an accumulation")
+	}
+	run := func() error {
+		return zerogradient(h, g)
+	}
+	var err error
+	if h.w != nil {
+		err = h.w.Work(run)
+	} else {
+		err = run()
+	}
+	if err != nil {
+		return err
+	}
+	a.grads = append(a.grads, g)
+	return nil
+}
+
+//ConvBackwardFilter is c.BackwardFilter with beta set to add to dw.
+func (a *Accumulator) ConvBackwardFilter(handle *gocudnn.Handle, c *gocudnn.ConvolutionD,
+	alpha float64,
+	xD *gocudnn.TensorD, x cutil.Mem,
+	dyD *gocudnn.TensorD, dy cutil.Mem,
+	algo gocudnn.ConvBwdFiltAlgo,
+	wspace cutil.Mem, wspacesize uint,
+	dwD *gocudnn.FilterD, dw cutil.Mem) error {
+	return c.BackwardFilter(handle, alpha, xD, x, dyD, dy, algo, wspace, wspacesize, a.sched.Beta(), dwD, dw)
+}
+
+//ConvBackwardBias is c.BackwardBias with beta set to add to db.
+func (a *Accumulator) ConvBackwardBias(handle *gocudnn.Handle, c *gocudnn.ConvolutionD,
+	alpha float64,
+	dyD *gocudnn.TensorD, dy cutil.Mem,
+	dbD *gocudnn.TensorD, db cutil.Mem) error {
+	return c.BackwardBias(handle, alpha, dyD, dy, a.sched.Beta(), dbD, db)
+}
+
+//BatchNormBackward is b.Backward with betaparam set to add to dscale and dbias. dx is written over like normal.
+func (a *Accumulator) BatchNormBackward(handle *gocudnn.Handle, b *gocudnn.BatchNormD,
+	alphadata, betadata, alphaparam float64,
+	xD *gocudnn.TensorD, x cutil.Mem,
+	dyD *gocudnn.TensorD, dy cutil.Mem,
+	dxD *gocudnn.TensorD, dx cutil.Mem,
+	dBnScaleBiasDesc *gocudnn.TensorD, scale, dscale, dbias cutil.Mem,
+	epsilon float64,
+	savedMean, savedInvVariance cutil.Mem) error {
+	return b.Backward(handle, alphadata, betadata, alphaparam, a.sched.Beta(), xD, x, dyD, dy, dxD, dx, dBnScaleBiasDesc, scale, dscale, dbias, epsilon, savedMean, savedInvVariance)
+}
+
+//Add records that the backward pass of a micro-batch of batchsize samples is done. It returns true when it is time to call Step.
+func (a *Accumulator) Add(batchsize int) bool {
+	return a.sched.Add(batchsize)
+}
+
+//Step does an optimizer step if enough micro-batches were added. It returns false if it wasn't time for one.
+//
+//The gradients are scaled by 1/(effective batch) and then train is called. train would call TrainValues on each parameter
+//with the counter it is given, which is the number of steps taken before this one.  Afterwards the gradients are zeroed.
+func (a *Accumulator) Step(h *Handle, train func(counter int32) error) (bool, error) {
+	if !a.sched.Ready() {
+		return false, nil
+	}
+	if err := a.Normalize(h); err != nil {
+		return false, err
+	}
+	if err := train(int32(a.sched.Updates())); err != nil {
+		return false, err
+	}
+	if err := a.Zero(h); err != nil {
+		return false, err
+	}
+	a.sched.Reset()
+	return true, nil
+}
+
+//Normalize scales the gradients by 1/(effective batch). Step calls it.
+func (a *Accumulator) Normalize(h *Handle) error {
+	scale := a.sched.Scale()
+	run := func() error {
+		for i := range a.grads {
+			err := launchgradient(h, a.grads[i], a.kscale, a.kscale16, func(k *cuda.Kernel, config Config) error {
+				return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, a.grads[i].Mem, scale)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if h.w != nil {
+		return h.w.Work(run)
+	}
+	return run()
+}
+
+//Zero syncs the handle's stream and zeroes the gradients. Step calls it.
+func (a *Accumulator) Zero(h *Handle) error {
+	run := func() error {
+		if err := h.sync(); err != nil {
+			return err
+		}
+		for i := range a.grads {
+			if err := zerogradient(h, a.grads[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if h.w != nil {
+		return h.w.Work(run)
+	}
+	return run()
+}
+
+//zerogradient zeroes g on h.s so it is ordered with the backward passes and the LossScaler kernels.
+func zerogradient(h *Handle, g Gradient) error {
+	sib, err := g.Desc.GetSizeInBytes()
+	if err != nil {
+		return err
+	}
+	return cudart.MemsetAsync(g.Mem, 0, sib, h.s)
+}
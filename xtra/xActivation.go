@@ -153,11 +153,11 @@ func newXActivationDescriptor(h *Handle, amode XActivationMode, dtype gocudnn.Da
 	ctr := int32(1)
 	switch amode {
 	case xaflg.Threshhold():
-		fwdmode, err := cuda.MakeKernel(amode.tostringfwd(dtype), h.mod)
+		fwdmode, err := h.kernel(amode.tostringfwd(dtype))
 		if err != nil {
 			return nil, err
 		}
-		bwdmode, err := cuda.MakeKernel(amode.tostringbwd(dtype), h.mod)
+		bwdmode, err := h.kernel(amode.tostringbwd(dtype))
 		if err != nil {
 			return nil, err
 		}
@@ -172,11 +172,11 @@ func newXActivationDescriptor(h *Handle, amode XActivationMode, dtype gocudnn.Da
 		return act, nil
 	case xaflg.Prelu():
 
-		fwdmode, err := cuda.MakeKernel(amode.tostringfwd(dtype), h.mod)
+		fwdmode, err := h.kernel(amode.tostringfwd(dtype))
 		if err != nil {
 			return nil, err
 		}
-		bwdmode, err := cuda.MakeKernel(amode.tostringbwd(dtype), h.mod)
+		bwdmode, err := h.kernel(amode.tostringbwd(dtype))
 		if err != nil {
 			return nil, err
 		}
@@ -193,30 +193,30 @@ func newXActivationDescriptor(h *Handle, amode XActivationMode, dtype gocudnn.Da
 
 		return act, nil
 	case xaflg.Leaky():
-		fwdmode, err := cuda.MakeKernel(amode.tostringfwd(dtype), h.mod)
+		fwdmode, err := h.kernel(amode.tostringfwd(dtype))
 		if err != nil {
 			return nil, err
 		}
-		bwdmode, err := cuda.MakeKernel(amode.tostringbwd(dtype), h.mod)
+		bwdmode, err := h.kernel(amode.tostringbwd(dtype))
 		if err != nil {
 			return nil, err
 		}
 		var ktf kernels.XtraKerns
 		specials := new(leakyspecials)
 
-		specials.alphabwd, err = cuda.MakeKernel(ktf.LeakyBackwardAlpha(), h.mod)
+		specials.alphabwd, err = h.kernel(ktf.LeakyBackwardAlpha())
 		if err != nil {
 			return nil, err
 		}
-		specials.alphafwd, err = cuda.MakeKernel(ktf.LeakyForwardAlpha(), h.mod)
+		specials.alphafwd, err = h.kernel(ktf.LeakyForwardAlpha())
 		if err != nil {
 			return nil, err
 		}
-		specials.alphabetabwd, err = cuda.MakeKernel(ktf.LeakyBackwardAlphaBeta(), h.mod)
+		specials.alphabetabwd, err = h.kernel(ktf.LeakyBackwardAlphaBeta())
 		if err != nil {
 			return nil, err
 		}
-		specials.alphabetafwd, err = cuda.MakeKernel(ktf.LeakyForwardAlphaBeta(), h.mod)
+		specials.alphabetafwd, err = h.kernel(ktf.LeakyForwardAlphaBeta())
 		if err != nil {
 			return nil, err
 		}
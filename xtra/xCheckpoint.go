@@ -0,0 +1,331 @@
+package xtra
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+	"github.com/negativeOne1/gocudnn/xtra/checkpoint"
+	"github.com/negativeOne1/gocudnn/xtra/schedule"
+)
+
+//TrainerState is the state of one parameter tensor that is trained with a TrainerD. It is what SaveCheckpoint saves and LoadCheckpoint restores.
+//
+//GSum and XSum are the accumulators passed to TrainValues.  They can be nil if the mode doesn't use them.
+//For TrainMomentum and TrainRMSProp put vel or ms in GSum.  Desc is used for W, GSum and XSum.
+//Params is for the TrainValues modes, and Momentum, RMSProp, AdamW and LAMB are for the modes with their own Train method.
+//Only the ones that are used need to be set.
+//
+//If EMA is not nil, W needs to be registered with it, and the shadow copy of W and the step of the EMA are saved too.
+type TrainerState struct {
+	Name     string //Needs to be unique in a checkpoint
+	Trainer  *TrainerD
+	Desc     *gocudnn.TensorD
+	W        cutil.Mem
+	GSum     cutil.Mem
+	XSum     cutil.Mem
+	Counter  int32
+	Params   TrainingParams
+	Momentum MomentumParams
+	RMSProp  RMSPropParams
+	AdamW    AdamWParams
+	LAMB     LAMBParams
+	Reg      RegParams
+	EMA      *EMA
+}
+
+func (a TrainingParams) tomap() map[string]float32 {
+	return map[string]float32{"eps": a.eps, "rate": a.rate, "beta1": a.beta1, "beta2": a.beta2, "dwalpha": a.dwalpha, "ro": a.ro}
+}
+
+func (a *TrainingParams) frommap(m map[string]float32) {
+	a.eps, a.rate, a.beta1, a.beta2, a.dwalpha, a.ro = m["eps"], m["rate"], m["beta1"], m["beta2"], m["dwalpha"], m["ro"]
+}
+
+func (a MomentumParams) tomap() map[string]float32 {
+	return map[string]float32{"rate": a.rate, "momentum": a.momentum, "dwalpha": a.dwalpha}
+}
+
+func (a *MomentumParams) frommap(m map[string]float32) {
+	a.rate, a.momentum, a.dwalpha = m["rate"], m["momentum"], m["dwalpha"]
+}
+
+func (a RMSPropParams) tomap() map[string]float32 {
+	return map[string]float32{"rate": a.rate, "decay": a.decay, "eps": a.eps, "dwalpha": a.dwalpha}
+}
+
+func (a *RMSPropParams) frommap(m map[string]float32) {
+	a.rate, a.decay, a.eps, a.dwalpha = m["rate"], m["decay"], m["eps"], m["dwalpha"]
+}
+
+func (a AdamWParams) tomap() map[string]float32 {
+	return map[string]float32{"rate": a.rate, "beta1": a.beta1, "beta2": a.beta2, "eps": a.eps, "decay": a.decay, "dwalpha": a.dwalpha}
+}
+
+func (a *AdamWParams) frommap(m map[string]float32) {
+	a.rate, a.beta1, a.beta2, a.eps, a.decay, a.dwalpha = m["rate"], m["beta1"], m["beta2"], m["eps"], m["decay"], m["dwalpha"]
+}
+
+//trainingmap puts the params of every mode in one map.  The params of the modes with their own Train method are prefixed with the mode, like "adamw.rate".
+func (s *TrainerState) trainingmap() map[string]float32 {
+	m := s.Params.tomap()
+	for prefix, sub := range map[string]map[string]float32{
+		"momentum.": s.Momentum.tomap(),
+		"rmsprop.":  s.RMSProp.tomap(),
+		"adamw.":    s.AdamW.tomap(),
+		"lamb.":     s.LAMB.AdamWParams.tomap(),
+	} {
+		for k, v := range sub {
+			if v != 0 {
+				m[prefix+k] = v
+			}
+		}
+	}
+	return m
+}
+
+func (s *TrainerState) fromtrainingmap(m map[string]float32) {
+	s.Params.frommap(m)
+	s.Momentum.frommap(prefixed(m, "momentum."))
+	s.RMSProp.frommap(prefixed(m, "rmsprop."))
+	s.AdamW.frommap(prefixed(m, "adamw."))
+	s.LAMB.AdamWParams.frommap(prefixed(m, "lamb."))
+}
+
+//prefixed returns the values in m whose names start with prefix, with the prefix taken off.
+func prefixed(m map[string]float32, prefix string) map[string]float32 {
+	sub := make(map[string]float32)
+	for k, v := range m {
+		if strings.HasPrefix(k, prefix) {
+			sub[strings.TrimPrefix(k, prefix)] = v
+		}
+	}
+	return sub
+}
+
+func (a RegParams) tomap() map[string]float32 {
+	return map[string]float32{"decay1": a.decay1, "decay2": a.decay2, "batch": a.batch}
+}
+
+func (a *RegParams) frommap(m map[string]float32) {
+	a.decay1, a.decay2, a.batch = m["decay1"], m["decay2"], m["batch"]
+}
+
+//SaveCheckpoint copies the states to the host and writes them to w with the state of sched.  sched can be nil.
+//It syncs the handle's stream first so the trainers are done.  The EMAs of the states can't be swapped.
+func SaveCheckpoint(h *Handle, w io.Writer, states []*TrainerState, sched schedule.Scheduler) error {
+	var c checkpoint.Checkpoint
+	for _, s := range states {
+		if s.EMA != nil && s.EMA.Swapped() {
+			return fmt.Errorf("SaveCheckpoint: %s: EMA weights are swapped", s.Name)
+		}
+	}
+	run := func() error {
+		if err := h.sync(); err != nil {
+			return err
+		}
+		for _, s := range states {
+			p := checkpoint.Param{
+				Name:     s.Name,
+				Counter:  s.Counter,
+				Training: s.trainingmap(),
+				Reg:      s.Reg.tomap(),
+			}
+			if s.Trainer != nil {
+				p.Mode = int32(s.Trainer.mode)
+			}
+			if s.EMA != nil {
+				p.EMAStep = s.EMA.Step()
+			}
+			ms, err := s.mems()
+			if err != nil {
+				return fmt.Errorf("SaveCheckpoint: %v", err)
+			}
+			for _, m := range ms {
+				d, data, err := copytohost(s.Desc, m.mem)
+				if err != nil {
+					return fmt.Errorf("SaveCheckpoint: %s %s: %v", s.Name, m.name, err)
+				}
+				p.Tensors = append(p.Tensors, checkpoint.Tensor{Name: m.name, Desc: d, Data: data})
+			}
+			c.Params = append(c.Params, p)
+		}
+		return nil
+	}
+	var err error
+	if h.w != nil {
+		err = h.w.Work(run)
+	} else {
+		err = run()
+	}
+	if err != nil {
+		return err
+	}
+	if sched != nil {
+		if c.Scheduler, err = schedule.Marshal(sched); err != nil {
+			return err
+		}
+	}
+	return checkpoint.Write(w, &c)
+}
+
+//LoadCheckpoint reads a checkpoint written by SaveCheckpoint and restores it onto states.
+//The memory of the states needs to be allocated already. Each state is found in the checkpoint by Name, and the training mode,
+//the shape and the data type of each tensor need to match.  Counter, the params and Reg of each state are set from the checkpoint,
+//and so is the step of its EMA.  The EMAs can't be swapped.
+//It returns the scheduler that was saved, or nil if there wasn't one.
+func LoadCheckpoint(h *Handle, r io.Reader, states []*TrainerState) (schedule.Scheduler, error) {
+	c, err := checkpoint.Read(r)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range states {
+		p, ok := c.Param(s.Name)
+		if !ok {
+			return nil, fmt.Errorf("LoadCheckpoint: %s is not in the checkpoint", s.Name)
+		}
+		if s.Trainer != nil && p.Mode != int32(s.Trainer.mode) {
+			return nil, fmt.Errorf("LoadCheckpoint: %s was saved with training mode %d, trainer has %d", s.Name, p.Mode, s.Trainer.mode)
+		}
+		if s.EMA != nil && s.EMA.Swapped() {
+			return nil, fmt.Errorf("LoadCheckpoint: %s: EMA weights are swapped", s.Name)
+		}
+	}
+	run := func() error {
+		for _, s := range states {
+			p, _ := c.Param(s.Name)
+			d, dtype, _, _, err := hostsize(s.Desc)
+			if err != nil {
+				return err
+			}
+			ms, err := s.mems()
+			if err != nil {
+				return fmt.Errorf("LoadCheckpoint: %v", err)
+			}
+			for _, m := range ms {
+				t, ok := p.Tensor(m.name)
+				if !ok {
+					return fmt.Errorf("LoadCheckpoint: %s %s is not in the checkpoint", s.Name, m.name)
+				}
+				if err = t.Compatible(d, dtype); err != nil {
+					return fmt.Errorf("LoadCheckpoint: %s: %w", s.Name, err)
+				}
+				if err = copytodevice(s.Desc, m.mem, t.Data); err != nil {
+					return fmt.Errorf("LoadCheckpoint: %s %s: %v", s.Name, m.name, err)
+				}
+			}
+		}
+		return h.sync()
+	}
+	if h.w != nil {
+		err = h.w.Work(run)
+	} else {
+		err = run()
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range states {
+		p, _ := c.Param(s.Name)
+		s.Counter = p.Counter
+		s.fromtrainingmap(p.Training)
+		s.Reg.frommap(p.Reg)
+		if s.EMA != nil {
+			s.EMA.SetStep(p.EMAStep)
+		}
+	}
+	if len(c.Scheduler) == 0 {
+		return nil, nil
+	}
+	return schedule.Unmarshal(c.Scheduler)
+}
+
+type namedmem struct {
+	name string
+	mem  cutil.Mem
+}
+
+//mems returns the tensors of s that are not nil, and the shadow copy of W if s has an EMA.
+func (s *TrainerState) mems() ([]namedmem, error) {
+	var ms []namedmem
+	for _, m := range []namedmem{{"w", s.W}, {"gsum", s.GSum}, {"xsum", s.XSum}} {
+		if m.mem != nil {
+			ms = append(ms, m)
+		}
+	}
+	if s.EMA != nil {
+		shadow := s.EMA.shadow(s.W)
+		if shadow == nil {
+			return nil, fmt.Errorf("%s: W is not registered with the EMA", s.Name)
+		}
+		ms = append(ms, namedmem{"ema", shadow})
+	}
+	return ms, nil
+}
+
+//sync syncs the handle's stream.  It needs to be called on the thread that the handle uses.
+func (x *Handle) sync() error {
+	if x.s != nil {
+		return x.s.Sync()
+	}
+	return cudart.SyncNillStream()
+}
+
+//hostsize returns the host description of tD and the number of elements and bytes that a host copy needs.
+func hostsize(tD *gocudnn.TensorD) (d tensorutil.Desc, dtype tensorutil.DataType, length int, sib uint, err error) {
+	if d, err = tD.HostDesc(); err != nil {
+		return
+	}
+	if dtype, err = tD.HostDataType(); err != nil {
+		return
+	}
+	if sib, err = tD.GetSizeInBytes(); err != nil {
+		return
+	}
+	length = int(sib) / dtype.SizeOf()
+	if span := d.Span(); length < span {
+		length = span
+		sib = uint(span * dtype.SizeOf())
+	}
+	return
+}
+
+//copytohost copies the memory that tD describes into a go slice.
+func copytohost(tD *gocudnn.TensorD, t cutil.Mem) (tensorutil.Desc, interface{}, error) {
+	d, dtype, length, sib, err := hostsize(tD)
+	if err != nil {
+		return d, nil, err
+	}
+	data, err := tensorutil.MakeSlice(dtype, length)
+	if err != nil {
+		return d, nil, err
+	}
+	hptr, err := gocu.MakeGoMem(data)
+	if err != nil {
+		return d, nil, err
+	}
+	var kind cudart.MemcpyKind
+	return d, data, cudart.Memcpy(hptr, t, sib, kind.Default())
+}
+
+//copytodevice copies data into the memory that tD describes.
+func copytodevice(tD *gocudnn.TensorD, t cutil.Mem, data interface{}) error {
+	_, _, length, sib, err := hostsize(tD)
+	if err != nil {
+		return err
+	}
+	if n := tensorutil.Len(data); n != length {
+		return fmt.Errorf("copytodevice: got %d elements, tensor needs %d", n, length)
+	}
+	hptr, err := gocu.MakeGoMem(data)
+	if err != nil {
+		return err
+	}
+	var kind cudart.MemcpyKind
+	return cudart.Memcpy(t, hptr, sib, kind.Default())
+}
@@ -0,0 +1,230 @@
+package xtra
+
+import (
+	"errors"
+	"math"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cuda"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/xtra/xtrakerns"
+)
+
+//Gradient is a gradient tensor that is passed to GradientClipD.
+type Gradient struct {
+	Desc *gocudnn.TensorD
+	Mem  cutil.Mem
+}
+
+//ClipModeFlag passes ClipMode flags through methods.
+type ClipModeFlag struct {
+}
+
+//ClipMode is the mode of a GradientClipD
+type ClipMode int32
+
+//GlobalNorm scales all the gradients by maxnorm/norm when the l2 norm of all of them together is more than maxnorm.
+func (c ClipModeFlag) GlobalNorm() ClipMode {
+	return ClipMode(1)
+}
+
+//Value clips each value of the gradients to [min,max].
+func (c ClipModeFlag) Value() ClipMode {
+	return ClipMode(2)
+}
+
+//ClipParams are the params for gradient clipping.  MaxNorm is used by the GlobalNorm mode, and Min and Max by the Value mode.
+type ClipParams struct {
+	maxnorm float32
+	min     float32
+	max     float32
+}
+
+//CreateClipParamsFloat32 creates the ClipParams
+func CreateClipParamsFloat32(maxnorm, min, max float32) ClipParams {
+	return ClipParams{
+		maxnorm: maxnorm,
+		min:     min,
+		max:     max,
+	}
+}
+
+//SetMaxNorm sets maxnorm
+func (c *ClipParams) SetMaxNorm(maxnorm float32) {
+	c.maxnorm = maxnorm
+}
+
+//SetMin sets min
+func (c *ClipParams) SetMin(min float32) {
+	c.min = min
+}
+
+//SetMax sets max
+func (c *ClipParams) SetMax(max float32) {
+	c.max = max
+}
+
+//GradientClipD clips a list of gradients in place. The gradients can be float or half, and they don't need to be the same type.
+//
+//Unlike TrainValues and L1L2Regularization it works on all the gradients at once, so the GlobalNorm mode is the norm of all of them.
+//The norm is done on the device, so Clip doesn't sync the stream. Use Norm to get it.  The math is the same as in xtrahost.ClipByGlobalNorm and xtrahost.ClipByValue.
+type GradientClipD struct {
+	mode     ClipMode
+	ksum     *cuda.Kernel
+	ksum16   *cuda.Kernel
+	kscale   *cuda.Kernel
+	kscale16 *cuda.Kernel
+	kvalue   *cuda.Kernel
+	kvalue16 *cuda.Kernel
+	sumsq    *gocu.CudaPtr
+	cpusumsq []float32
+	cpuptr   cutil.Mem
+}
+
+//NewGradientClipD makes a GradientClipD
+func NewGradientClipD(h *Handle, mode ClipMode) (*GradientClipD, error) {
+	var c *GradientClipD
+	var err error
+	if h.w != nil {
+		err = h.w.Work(func() error {
+			c, err = newGradientClipD(h, mode)
+			return err
+		})
+		return c, err
+	}
+	return newGradientClipD(h, mode)
+}
+
+func newGradientClipD(h *Handle, mode ClipMode) (*GradientClipD, error) {
+	var f ClipModeFlag
+	c := &GradientClipD{mode: mode}
+	switch mode {
+	case f.GlobalNorm():
+		ks, err := h.makekernels(xtrakerns.SumSquares(), xtrakerns.SumSquaresFP16(), xtrakerns.ScaleByNorm(), xtrakerns.ScaleByNormFP16())
+		if err != nil {
+			return nil, err
+		}
+		c.ksum, c.ksum16, c.kscale, c.kscale16 = ks[0], ks[1], ks[2], ks[3]
+		c.sumsq = new(gocu.CudaPtr)
+		if err = cudart.MallocManagedGlobal(c.sumsq, 4); err != nil {
+			return nil, err
+		}
+		c.cpusumsq = make([]float32, 1)
+		c.cpuptr, err = gocu.MakeGoMem(c.cpusumsq)
+		if err != nil {
+			return nil, err
+		}
+	case f.Value():
+		ks, err := h.makekernels(xtrakerns.ClipByValue(), xtrakerns.ClipByValueFP16())
+		if err != nil {
+			return nil, err
+		}
+		c.kvalue, c.kvalue16 = ks[0], ks[1]
+	default:
+		return nil, errors.New("NewGradientClipD: unsupported ClipMode")
+	}
+	return c, nil
+}
+
+//Clip clips grads in place.
+func (c *GradientClipD) Clip(h *Handle, grads []Gradient, params ClipParams) error {
+	if h.w != nil {
+		return h.w.Work(func() error {
+			return c.clip(h, grads, params)
+		})
+	}
+	return c.clip(h, grads, params)
+}
+
+func (c *GradientClipD) clip(h *Handle, grads []Gradient, params ClipParams) error {
+	var f ClipModeFlag
+	switch c.mode {
+	case f.GlobalNorm():
+		//The sum is zeroed on h.s so it can't race with the kernels of the last Clip.
+		if err := cudart.MemsetAsync(c.sumsq, 0, 4, h.s); err != nil {
+			return err
+		}
+		for i := range grads {
+			err := launchgradient(h, grads[i], c.ksum, c.ksum16, func(k *cuda.Kernel, config Config) error {
+				return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, grads[i].Mem, c.sumsq)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for i := range grads {
+			err := launchgradient(h, grads[i], c.kscale, c.kscale16, func(k *cuda.Kernel, config Config) error {
+				return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, grads[i].Mem, c.sumsq, params.maxnorm)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	case f.Value():
+		if params.min > params.max {
+			return errors.New("(c *GradientClipD) Clip: min is more than max")
+		}
+		for i := range grads {
+			err := launchgradient(h, grads[i], c.kvalue, c.kvalue16, func(k *cuda.Kernel, config Config) error {
+				return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, grads[i].Mem, params.min, params.max)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//launchgradient picks the kernel for the data type of g and launches it with the config for the size of g.
+func launchgradient(h *Handle, g Gradient, float, fp16 *cuda.Kernel, launch func(k *cuda.Kernel, config Config) error) error {
+	if g.Desc == nil || g.Mem == nil {
+		return errors.New("launchgradient: nil Gradient")
+	}
+	_, dtype, _, _, err := g.Desc.Get()
+	if err != nil {
+		return err
+	}
+	sizeinbytes, err := g.Desc.GetSizeInBytes()
+	if err != nil {
+		return err
+	}
+	var dtflg gocudnn.DataType
+	switch dtype {
+	case dtflg.Float():
+		return launch(float, h.LaunchConfig(int32(sizeinbytes/4)))
+	case dtflg.Half():
+		return launch(fp16, h.LaunchConfig(int32(sizeinbytes/2)))
+	}
+	return errors.New("launchgradient: unsupported DataType")
+}
+
+//Norm syncs the handle's stream and returns the global norm of the gradients from the last Clip before they were scaled.
+//It is only for the GlobalNorm mode.
+func (c *GradientClipD) Norm(h *Handle) (float32, error) {
+	if c.mode != (ClipModeFlag{}).GlobalNorm() {
+		return 0, errors.New("(c *GradientClipD) Norm: mode is not GlobalNorm")
+	}
+	var norm float32
+	run := func() error {
+		if h.s != nil {
+			if err := h.s.Sync(); err != nil {
+				return err
+			}
+		} else if err := cudart.SyncNillStream(); err != nil {
+			return err
+		}
+		var kind cudart.MemcpyKind
+		if err := cudart.Memcpy(c.cpuptr, c.sumsq, 4, kind.Default()); err != nil {
+			return err
+		}
+		norm = float32(math.Sqrt(float64(c.cpusumsq[0])))
+		return nil
+	}
+	if h.w != nil {
+		return norm, h.w.Work(run)
+	}
+	return norm, run()
+}
@@ -0,0 +1,178 @@
+package xtra
+
+import (
+	"errors"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cuda"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/xtra/xtrahost"
+	"github.com/negativeOne1/gocudnn/xtra/xtrakerns"
+)
+
+//EMA keeps an exponential moving average of the weights that are registered with it in shadow copies on the device.
+//
+//Call Update after each training step. Swap puts the shadow weights in place of the live weights for evaluation,
+//and calling Swap again puts the live weights back.  The weights can be float or half.
+//The math is the same as in xtrahost.EMADecay, xtrahost.EMAUpdate and xtrahost.SwapValues.
+type EMA struct {
+	decay     float32
+	warmup    bool
+	step      int
+	swapped   bool
+	kupdate   *cuda.Kernel
+	kupdate16 *cuda.Kernel
+	kswap     *cuda.Kernel
+	kswap16   *cuda.Kernel
+	params    []emaparam
+}
+
+type emaparam struct {
+	w      Gradient
+	shadow *gocu.CudaPtr
+}
+
+//NewEMA makes an EMA. With warmup the decay used for each Update is min(decay,(1+step)/(10+step)).
+func NewEMA(h *Handle, decay float32, warmup bool) (*EMA, error) {
+	if decay < 0 || decay > 1 {
+		return nil, errors.New("NewEMA: decay needs to be in [0,1]")
+	}
+	e := &EMA{decay: decay, warmup: warmup}
+	run := func() error {
+		ks, err := h.makekernels(xtrakerns.EMAUpdate(), xtrakerns.EMAUpdateFP16(), xtrakerns.SwapValues(), xtrakerns.SwapValuesFP16())
+		if err != nil {
+			return err
+		}
+		e.kupdate, e.kupdate16, e.kswap, e.kswap16 = ks[0], ks[1], ks[2], ks[3]
+		return nil
+	}
+	if h.w != nil {
+		return e, h.w.Work(run)
+	}
+	return e, run()
+}
+
+//Register adds w to the weights that e keeps an average of. The shadow copy starts out as a copy of w.
+//It can't be called while e is swapped.
+func (e *EMA) Register(h *Handle, wD *gocudnn.TensorD, w cutil.Mem) error {
+	if e.swapped {
+		return errors.New("(e *EMA) Register: weights are swapped")
+	}
+	if wD == nil || w == nil {
+		return errors.New("(e *EMA) Register: nil weights")
+	}
+	run := func() error {
+		sib, err := wD.GetSizeInBytes()
+		if err != nil {
+			return err
+		}
+		shadow := new(gocu.CudaPtr)
+		if err = cudart.MallocManagedGlobal(shadow, sib); err != nil {
+			return err
+		}
+		if err = h.sync(); err != nil {
+			return err
+		}
+		var kind cudart.MemcpyKind
+		if err = cudart.Memcpy(shadow, w, sib, kind.Default()); err != nil {
+			return err
+		}
+		e.params = append(e.params, emaparam{w: Gradient{Desc: wD, Mem: w}, shadow: shadow})
+		return nil
+	}
+	if h.w != nil {
+		return h.w.Work(run)
+	}
+	return run()
+}
+
+//Update moves the shadow weights toward the live weights with the decay for the current step, and then adds one to the step.
+func (e *EMA) Update(h *Handle) error {
+	if e.swapped {
+		return errors.New("(e *EMA) Update: weights are swapped")
+	}
+	decay := e.Decay()
+	run := func() error {
+		for _, p := range e.params {
+			err := launchgradient(h, p.w, e.kupdate, e.kupdate16, func(k *cuda.Kernel, config Config) error {
+				return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, p.w.Mem, p.shadow, decay)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var err error
+	if h.w != nil {
+		err = h.w.Work(run)
+	} else {
+		err = run()
+	}
+	if err != nil {
+		return err
+	}
+	e.step++
+	return nil
+}
+
+//Swap swaps the shadow weights and the live weights.
+func (e *EMA) Swap(h *Handle) error {
+	run := func() error {
+		for _, p := range e.params {
+			err := launchgradient(h, p.w, e.kswap, e.kswap16, func(k *cuda.Kernel, config Config) error {
+				return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, p.w.Mem, p.shadow)
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	var err error
+	if h.w != nil {
+		err = h.w.Work(run)
+	} else {
+		err = run()
+	}
+	if err != nil {
+		return err
+	}
+	e.swapped = !e.swapped
+	return nil
+}
+
+//Swapped returns true if the live weights hold the shadow weights.
+func (e *EMA) Swapped() bool {
+	return e.swapped
+}
+
+//Decay returns the decay that the next Update will use.
+func (e *EMA) Decay() float32 {
+	return xtrahost.EMADecay(e.decay, e.step, e.warmup)
+}
+
+//Step returns the number of updates that have been done.
+func (e *EMA) Step() int {
+	return e.step
+}
+
+//shadow returns the shadow copy of w, or nil if w isn't registered.
+func (e *EMA) shadow(w cutil.Mem) *gocu.CudaPtr {
+	if w == nil {
+		return nil
+	}
+	for _, p := range e.params {
+		if p.w.Mem.Ptr() == w.Ptr() {
+			return p.shadow
+		}
+	}
+	return nil
+}
+
+//SetStep sets the step count. Use it when resuming training.
+func (e *EMA) SetStep(step int) {
+	e.step = step
+}
@@ -1,14 +1,15 @@
 package xtra
 
 import (
-	"errors"
 	"fmt"
-	"strings"
+	"os"
+	"sync"
 
 	"github.com/negativeOne1/gocudnn/cuda"
 	"github.com/negativeOne1/gocudnn/cudart"
 	"github.com/negativeOne1/gocudnn/gocu"
 	"github.com/negativeOne1/gocudnn/kernels"
+	"github.com/negativeOne1/gocudnn/xtra/bundle"
 )
 
 //Xtra is a holder for Xtra functions that are made by me, and not cuda or cudnn
@@ -17,17 +18,31 @@ type Xtra struct {
 	kernellocation           string
 }
 
-//KernelLocation will set the direct kernel location and make for it kernel location
+//KernelLocation will set the direct kernel location and make for it kernel location.
+//The directory needs a manifest.json that lists the bundles in it, like the one in xtra/bundle.
 func (xtra *Xtra) KernelLocation(kernalfilelocation string) {
 	xtra.notdefaultkernallocation = true
 	xtra.kernellocation = kernalfilelocation
 }
 
+//Registry returns the bundles in the kernel location, or the embedded bundles if KernelLocation wasn't called.
+func (xtra *Xtra) Registry() (*bundle.Registry, error) {
+	if xtra.notdefaultkernallocation {
+		return bundle.New(os.DirFS(xtra.kernellocation))
+	}
+	return bundle.Default()
+}
+
 //Handle is a handle for xtra functions. Right now all functions that use Handle are strictly float32.
 // Because I use gtx 1080ti(s) and there is basically no motivation to expand the capability.  Maybe if someone wants to get me
 //A RTX2080ti I will do something about that. heh heh heh
 type Handle struct {
 	mod                          *cuda.Module
+	reg                          *bundle.Registry
+	bundle                       *bundle.Bundle
+	major, minor                 int
+	bundlemu                     sync.Mutex
+	bundles                      map[string]*cuda.Module
 	w                            *gocu.Worker
 	ptx                          string
 	s                            gocu.Streamer
@@ -38,6 +53,8 @@ type Handle struct {
 	maxgriddimsxyz               []int32
 	unified                      bool
 	device                       cudart.Device
+	jitmu                        sync.Mutex
+	jit                          map[string]*cuda.Kernel
 }
 
 //SetStream sets a stream to be used by the handler
@@ -83,10 +100,28 @@ func MakeHandleEx(w *gocu.Worker, unified bool) (*Handle, error) {
 }
 
 //MakeHandle makes one of them there "Xtra" Handles used for the xtra functions I added to gocudnn.
+//The kernels are loaded from the embedded bundle that best fits dev.
 func MakeHandle(dev cudart.Device, unified bool) (*Handle, error) {
+	reg, err := bundle.Default()
+	if err != nil {
+		return nil, err
+	}
+	return MakeHandleRegistry(dev, unified, reg)
+}
 
-	//x := kernels.MakeMakeFile(trainingfloatdir, "gocudnnxtra", dev)
-	//kerncode := kernels.LoadPTXFile(trainingfloatdir, x)
+//MakeHandle makes a handle with the kernel location set by KernelLocation, or the embedded bundles if it wasn't set.
+func (xtra *Xtra) MakeHandle(dev cudart.Device, unified bool) (*Handle, error) {
+	reg, err := xtra.Registry()
+	if err != nil {
+		return nil, err
+	}
+	return MakeHandleRegistry(dev, unified, reg)
+}
+
+//MakeHandleRegistry makes a handle whose kernels come from the bundles in reg.
+//The bundle that has float and half kernels and best fits dev's compute capability is loaded.
+//If there isn't a cubin for dev the driver will jit the newest ptx that dev can run.
+func MakeHandleRegistry(dev cudart.Device, unified bool, reg *bundle.Registry) (*Handle, error) {
 	major, err := dev.Major()
 	if err != nil {
 		return nil, err
@@ -95,28 +130,18 @@ func MakeHandle(dev cudart.Device, unified bool) (*Handle, error) {
 	if err != nil {
 		return nil, err
 	}
-	majmin := major*10 + minor
-	var mod *cuda.Module
-	if majmin == 75 {
-		//	fmt.Println("going to 75")
-		mod, err = cuda.NewModuleData(strings.NewReader(both75))
-	} else if majmin == 61 {
-		//	fmt.Println("going to 61")
-		mod, err = cuda.NewModuleData(strings.NewReader(both61))
-	} else {
-		return nil, errors.New("Unsupported GPU")
+	b, err := reg.Select(major, minor, bundle.Float, bundle.Half)
+	if err != nil {
+		return nil, err
 	}
+	image, err := reg.Data(b)
 	if err != nil {
-		fmt.Println("Error in new module")
 		return nil, err
 	}
-	/*
-		mod, err := cuda.NewModule(trainingfloatdir + x)
-		if err != nil {
-			//fmt.Println(kerncode)
-			return nil, err
-		}
-	*/
+	mod, err := cuda.NewModuleImage(image)
+	if err != nil {
+		return nil, fmt.Errorf("MakeHandle: loading bundle %s: %v", b.Name, err)
+	}
 	mtpb, err := dev.MaxThreadsPerBlock()
 	if err != nil {
 
@@ -146,6 +171,10 @@ func MakeHandle(dev cudart.Device, unified bool) (*Handle, error) {
 	//	kern,err:=cu.MakeKernel()
 	return &Handle{
 		mod:                          mod,
+		reg:                          reg,
+		bundle:                       b,
+		major:                        major,
+		minor:                        minor,
 		maxblockthreads:              mtpb,
 		maxthreadspermultiproccessor: mmpt,
 		muliproccessorcount:          nummp,
@@ -178,6 +207,24 @@ func (x *Handle) LaunchConfig(elements int32) Config {
 	}
 }
 
+//LaunchConfigKernel returns a config for k that uses the block size the driver says gets the most occupancy,
+//so the registers and shared memory k uses are taken into account.  LaunchConfig only looks at thread counts.
+//The grid is no more blocks than can be active on the device at once.
+func (x *Handle) LaunchConfigKernel(k *cuda.Kernel, elements int32, dynamicshared uint) (Config, error) {
+	mingrid, blocksize, err := k.MaxPotentialBlockSize(dynamicshared, 0)
+	if err != nil {
+		return Config{}, err
+	}
+	if elements < 1 || blocksize < 1 {
+		return Config{Elements: elements}, nil
+	}
+	return Config{
+		Elements:       elements,
+		ThreadPerBlock: uint32(blocksize),
+		BlockCount:     uint32(min(kernels.DivUp(elements, blocksize), mingrid)),
+	}, nil
+}
+
 //Config2d are parameters for the kernel launch
 type Config2d struct {
 	Dimx            int32
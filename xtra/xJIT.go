@@ -0,0 +1,70 @@
+package xtra
+
+import (
+	"github.com/negativeOne1/gocudnn/cuda"
+	"github.com/negativeOne1/gocudnn/xtra/xtrakerns"
+)
+
+//makekernels returns the kernels of kerns.  Kernels that are not in the precompiled ptx are compiled with nvrtc the first time they are asked for,
+//and they are kept by the handle so they are only compiled once.  It needs to be called on the thread that the handle uses.
+func (x *Handle) makekernels(kerns ...xtrakerns.Kernel) ([]*cuda.Kernel, error) {
+	x.jitmu.Lock()
+	defer x.jitmu.Unlock()
+	if x.jit == nil {
+		x.jit = make(map[string]*cuda.Kernel)
+	}
+	var missing []xtrakerns.Kernel
+	for _, k := range kerns {
+		if _, ok := x.jit[k.Name]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	if len(missing) > 0 {
+		mod, err := xtrakerns.CreateModuleKernels(x.device, missing...)
+		if err != nil {
+			return nil, err
+		}
+		for _, k := range missing {
+			kern, err := cuda.MakeKernel(k.Name, mod)
+			if err != nil {
+				return nil, err
+			}
+			x.jit[k.Name] = kern
+		}
+	}
+	ks := make([]*cuda.Kernel, len(kerns))
+	for i, k := range kerns {
+		ks[i] = x.jit[k.Name]
+	}
+	return ks, nil
+}
+
+//kernel returns the kernel called name from the handle's bundle.  If the bundle doesn't have it, the best bundle in the registry that does is loaded,
+//and it is kept by the handle so it is only loaded once.  It needs to be called on the thread that the handle uses.
+func (x *Handle) kernel(name string) (*cuda.Kernel, error) {
+	if x.bundle == nil || x.bundle.Has(name) {
+		return cuda.MakeKernel(name, x.mod)
+	}
+	b, err := x.reg.Lookup(name, x.major, x.minor)
+	if err != nil {
+		return nil, err
+	}
+	x.bundlemu.Lock()
+	defer x.bundlemu.Unlock()
+	mod, ok := x.bundles[b.Name]
+	if !ok {
+		image, err := x.reg.Data(b)
+		if err != nil {
+			return nil, err
+		}
+		mod, err = cuda.NewModuleImage(image)
+		if err != nil {
+			return nil, err
+		}
+		if x.bundles == nil {
+			x.bundles = make(map[string]*cuda.Module)
+		}
+		x.bundles[b.Name] = mod
+	}
+	return cuda.MakeKernel(name, mod)
+}
@@ -29,6 +29,7 @@ type XLossD struct {
 	flg          XLossModeFlag
 	dflg         gocudnn.DataType
 	memcopykind  cudart.MemcpyKind
+	params       LossParams
 }
 
 //XLossModeFlag passes XLossMode flags through methods
@@ -76,7 +77,7 @@ func newsofmaxneglogloss(h *Handle) (smll *SofMaxLogLoss, err error) {
 		return nil, err
 	}
 
-	smll.smloss, err = cuda.MakeKernel(ktf.SoftMaxAverageLoss(), h.mod)
+	smll.smloss, err = h.kernel(ktf.SoftMaxAverageLoss())
 	if err != nil {
 		return nil, err
 	}
@@ -213,8 +214,8 @@ func newLossDescriptor(h *Handle, mode XLossMode) (*XLossD, error) {
 	var memflg cudart.MemcpyKind
 	switch mode {
 	case flg.MSE():
-		mse, err := cuda.MakeKernel(ktf.MSELoss(), h.mod)
-		msefp16, err := cuda.MakeKernel(ktf.MSELossFP16(), h.mod)
+		mse, err := h.kernel(ktf.MSELoss())
+		msefp16, err := h.kernel(ktf.MSELossFP16())
 		if err != nil {
 			return nil, err
 		}
@@ -256,6 +257,9 @@ func newLossDescriptor(h *Handle, mode XLossMode) (*XLossD, error) {
 		}, nil
 
 	}
+	if float, fp16, ok := lossmodekernels(mode); ok {
+		return newLossModeDescriptor(h, mode, float, fp16)
+	}
 	return nil, errors.New("Not a supported Loss Mode")
 }
 
@@ -275,13 +279,27 @@ func (l *XLossD) CalculateErrorAndLoss(h *Handle,
 	if h.w != nil {
 		err = h.w.Work(func() error {
 			loss, err = l.calculateErrorAndLoss(h, dxD, dx, yD, y, dyD, dy, alpha, beta)
-			return err
+			if err != nil {
+				return err
+			}
+			return l.checknonfinite(h, loss, dxD, dx, yD, dyD)
 		})
 	} else {
 		loss, err = l.calculateErrorAndLoss(h, dxD, dx, yD, y, dyD, dy, alpha, beta)
+		if err == nil {
+			err = l.checknonfinite(h, loss, dxD, dx, yD, dyD)
+		}
 	}
 	return loss, err
 }
+
+func (l *XLossD) checknonfinite(h *Handle, loss float32, dxD *gocudnn.TensorD, dx cutil.Mem, yD, dyD *gocudnn.TensorD) error {
+	const op = "(l *XLossD) CalculateErrorAndLoss"
+	if err := h.checknonfinite(op, "dx", dxD, dx, dxD, yD, dyD); err != nil {
+		return err
+	}
+	return checknonfiniteloss(op, loss, dxD, yD, dyD)
+}
 func (l *XLossD) calculateErrorAndLoss(h *Handle,
 	dxD *gocudnn.TensorD, //output -errors going back
 	dx cutil.Mem, // output -errors going back
@@ -291,6 +309,9 @@ func (l *XLossD) calculateErrorAndLoss(h *Handle,
 	dy cutil.Mem, //input network output values
 	alpha, beta float64,
 ) (float32, error) {
+	if l.mode != l.flg.MSE() {
+		return l.calculatelossmode(h, dxD, dx, yD, y, dyD, dy, alpha, beta)
+	}
 	_, dxdtype, dxdims, _, err := dxD.Get()
 	if err != nil {
 		return -1, err
@@ -0,0 +1,285 @@
+package xtra
+
+import (
+	"errors"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/xtra/xtrakerns"
+)
+
+/*
+The loss modes below are compiled with nvrtc the first time a XLossD is made for them.  They all work with float and half.
+With CalculateErrorAndLoss, dy is the network output (logits for all but Huber and SmoothL1), y is the target and dx is the gradient of the loss with respect to dy.
+dx = alpha*grad + beta*dx.  The loss that is returned is the sum over the tensor divided by the batch size.
+The math of each one is in xtrahost.
+
+SoftmaxCrossEntropy, SparseSoftmaxCrossEntropy and KLDiv work along the channel dim (dims[1] for NCHW, the last dim for NHWC).
+For SparseSoftmaxCrossEntropy y is an int32 tensor of class labels with a channel dim of 1.  Labels out of range are ignored.
+*/
+
+//SoftmaxCrossEntropy is the cross entropy of the softmax of the output against target probabilities. It uses the smoothing and class weights of LossParams.
+func (x XLossModeFlag) SoftmaxCrossEntropy() XLossMode {
+	return XLossMode(3)
+}
+
+//SparseSoftmaxCrossEntropy is SoftmaxCrossEntropy with int32 class labels as the target.
+func (x XLossModeFlag) SparseSoftmaxCrossEntropy() XLossMode {
+	return XLossMode(4)
+}
+
+//BCEWithLogits is the binary cross entropy of the sigmoid of the output.
+func (x XLossModeFlag) BCEWithLogits() XLossMode {
+	return XLossMode(5)
+}
+
+//Huber is the huber loss. It uses the delta of LossParams.
+func (x XLossModeFlag) Huber() XLossMode {
+	return XLossMode(6)
+}
+
+//SmoothL1 is the smooth l1 loss. It uses the delta of LossParams as beta.
+func (x XLossModeFlag) SmoothL1() XLossMode {
+	return XLossMode(7)
+}
+
+//Focal is the sigmoid focal loss. It uses the alpha and gamma of LossParams.
+func (x XLossModeFlag) Focal() XLossMode {
+	return XLossMode(8)
+}
+
+//KLDiv is the kl divergence of the softmax of the output from target probabilities.
+func (x XLossModeFlag) KLDiv() XLossMode {
+	return XLossMode(9)
+}
+
+//LossParams are the params of the loss modes.  Modes ignore the params they don't use.
+type LossParams struct {
+	smoothing float32
+	delta     float32
+	alpha     float32
+	gamma     float32
+	weights   cutil.Mem
+}
+
+//CreateLossParamsFloat32 creates the LossParams.
+//smoothing is the label smoothing, delta is the delta of Huber or the beta of SmoothL1, and alpha and gamma are for Focal.
+//A negative alpha turns off the alpha of Focal.
+func CreateLossParamsFloat32(smoothing, delta, alpha, gamma float32) LossParams {
+	return LossParams{
+		smoothing: smoothing,
+		delta:     delta,
+		alpha:     alpha,
+		gamma:     gamma,
+	}
+}
+
+//SetSmoothing sets the label smoothing
+func (p *LossParams) SetSmoothing(smoothing float32) {
+	p.smoothing = smoothing
+}
+
+//SetDelta sets delta
+func (p *LossParams) SetDelta(delta float32) {
+	p.delta = delta
+}
+
+//SetAlpha sets the alpha of Focal
+func (p *LossParams) SetAlpha(alpha float32) {
+	p.alpha = alpha
+}
+
+//SetGamma sets the gamma of Focal
+func (p *LossParams) SetGamma(gamma float32) {
+	p.gamma = gamma
+}
+
+//SetWeights sets the class weights.  weights is float device memory with one value per class.  nil turns them off.
+func (p *LossParams) SetWeights(weights cutil.Mem) {
+	p.weights = weights
+}
+
+//SetParams sets the params used by the loss modes. The default is no smoothing, a delta of 1, an alpha of .25 and a gamma of 2.
+func (l *XLossD) SetParams(p LossParams) {
+	l.params = p
+}
+
+func lossmodekernels(mode XLossMode) (float, fp16 xtrakerns.Kernel, ok bool) {
+	var flg XLossModeFlag
+	switch mode {
+	case flg.SoftmaxCrossEntropy():
+		return xtrakerns.SoftmaxCrossEntropyLoss(), xtrakerns.SoftmaxCrossEntropyLossFP16(), true
+	case flg.SparseSoftmaxCrossEntropy():
+		return xtrakerns.SparseSoftmaxCrossEntropyLoss(), xtrakerns.SparseSoftmaxCrossEntropyLossFP16(), true
+	case flg.BCEWithLogits():
+		return xtrakerns.BCEWithLogitsLoss(), xtrakerns.BCEWithLogitsLossFP16(), true
+	case flg.Huber(), flg.SmoothL1():
+		return xtrakerns.HuberLoss(), xtrakerns.HuberLossFP16(), true
+	case flg.Focal():
+		return xtrakerns.FocalLoss(), xtrakerns.FocalLossFP16(), true
+	case flg.KLDiv():
+		return xtrakerns.KLDivLoss(), xtrakerns.KLDivLossFP16(), true
+	}
+	return float, fp16, false
+}
+
+func newLossModeDescriptor(h *Handle, mode XLossMode, float, fp16 xtrakerns.Kernel) (*XLossD, error) {
+	ks, err := h.makekernels(float, fp16)
+	if err != nil {
+		return nil, err
+	}
+	gpu := new(gocu.CudaPtr)
+	if err = cudart.MallocManagedGlobal(gpu, 4); err != nil {
+		return nil, err
+	}
+	cpuloss := make([]float32, 1)
+	cpuptr, err := gocu.MakeGoMem(cpuloss)
+	if err != nil {
+		return nil, err
+	}
+	var memflg cudart.MemcpyKind
+	return &XLossD{
+		mode:         mode,
+		lossfunc:     ks[0],
+		lossfuncfp16: ks[1],
+		loss:         gpu,
+		cpuloss:      cpuloss,
+		cpuptr:       cpuptr,
+		memcopykind:  memflg.DeviceToHost(),
+		params:       CreateLossParamsFloat32(0, 1, .25, 2),
+	}, nil
+}
+
+//classlayout returns the outer, class and inner sizes of a tensor for the losses that work along the channel dim.
+func classlayout(frmt gocudnn.TensorFormat, dims []int32) (outer, classes, inner int32, err error) {
+	if len(dims) < 2 {
+		return 0, 0, 0, errors.New("classlayout: tensor needs at least 2 dims")
+	}
+	fflg := frmt
+	switch frmt {
+	case fflg.NCHW():
+		return dims[0], dims[1], findvolume(dims[2:]), nil
+	case fflg.NHWC():
+		return findvolume(dims[:len(dims)-1]), dims[len(dims)-1], 1, nil
+	}
+	return 0, 0, 0, errors.New("classlayout: unsupported format")
+}
+
+//calculatelossmode is calculateErrorAndLoss for the modes in this file.
+func (l *XLossD) calculatelossmode(h *Handle,
+	dxD *gocudnn.TensorD, dx cutil.Mem,
+	yD *gocudnn.TensorD, y cutil.Mem,
+	dyD *gocudnn.TensorD, dy cutil.Mem,
+	alpha, beta float64,
+) (float32, error) {
+	frmt, dxdtype, dxdims, _, err := dxD.Get()
+	if err != nil {
+		return -1, err
+	}
+	_, ydtype, ydims, _, err := yD.Get()
+	if err != nil {
+		return -1, err
+	}
+	_, dydtype, dydims, _, err := dyD.Get()
+	if err != nil {
+		return -1, err
+	}
+	if dxdtype != dydtype {
+		return -1, errors.New("descriptors datatype not matching")
+	}
+	if dxdtype != l.dflg.Float() && dxdtype != l.dflg.Half() {
+		return -1, errors.New("Only float and half are supported")
+	}
+	if !comparedims(dxdims, dydims) {
+		return -1, errors.New("Dims for tensors Don't Match")
+	}
+	sparse := l.mode == l.flg.SparseSoftmaxCrossEntropy()
+	if sparse {
+		var idt gocudnn.DataType
+		if ydtype != idt.Int32() {
+			return -1, errors.New("SparseSoftmaxCrossEntropy: target needs to be int32")
+		}
+		if findvolume(ydims)*classesof(frmt, dxdims) != findvolume(dxdims) {
+			return -1, errors.New("SparseSoftmaxCrossEntropy: target needs one label per position")
+		}
+	} else {
+		if ydtype != dxdtype {
+			return -1, errors.New("descriptors datatype not matching")
+		}
+		if !comparedims(dxdims, ydims) {
+			return -1, errors.New("Dims for tensors Don't Match")
+		}
+	}
+	kern := l.lossfunc
+	if dxdtype == l.dflg.Half() {
+		kern = l.lossfuncfp16
+	}
+	if err = cudart.MemsetAsync(l.loss, 0, 4, h.s); err != nil {
+		return -1, err
+	}
+	a, b := float32(alpha), float32(beta)
+	p := l.params
+	switch l.mode {
+	case l.flg.SoftmaxCrossEntropy(), l.flg.SparseSoftmaxCrossEntropy(), l.flg.KLDiv():
+		outer, classes, inner, err := classlayout(frmt, dxdims)
+		if err != nil {
+			return -1, err
+		}
+		config := h.LaunchConfig(outer * inner)
+		if l.mode == l.flg.KLDiv() {
+			err = kern.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, classes, inner, dy, y, dx, a, b, l.loss)
+		} else {
+			var hasweights int32
+			weights := l.loss
+			if p.weights != nil {
+				hasweights, weights = 1, p.weights
+			}
+			err = kern.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, classes, inner, dy, y, dx, weights, hasweights, p.smoothing, a, b, l.loss)
+		}
+		if err != nil {
+			return -1, err
+		}
+	default:
+		config := h.LaunchConfig(findvolume(dxdims))
+		switch l.mode {
+		case l.flg.BCEWithLogits():
+			err = kern.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, dy, y, dx, a, b, l.loss)
+		case l.flg.Huber():
+			err = kern.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, dy, y, dx, p.delta, float32(1), a, b, l.loss)
+		case l.flg.SmoothL1():
+			if p.delta <= 0 {
+				return -1, errors.New("SmoothL1: delta needs to be more than zero")
+			}
+			err = kern.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, dy, y, dx, p.delta, 1/p.delta, a, b, l.loss)
+		case l.flg.Focal():
+			err = kern.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, dy, y, dx, p.alpha, p.gamma, a, b, l.loss)
+		default:
+			return -1, errors.New("Unsupported Loss Function")
+		}
+		if err != nil {
+			return -1, err
+		}
+	}
+	if h.s != nil {
+		err = h.s.Sync()
+	} else {
+		err = cudart.SyncNillStream()
+	}
+	if err != nil {
+		return -1, err
+	}
+	if err = cudart.Memcpy(l.cpuptr, l.loss, 4, l.memcopykind); err != nil {
+		return -1, err
+	}
+	return l.cpuloss[0] / float32(dxdims[0]), nil
+}
+
+func classesof(frmt gocudnn.TensorFormat, dims []int32) int32 {
+	_, classes, _, err := classlayout(frmt, dims)
+	if err != nil {
+		return 0
+	}
+	return classes
+}
@@ -0,0 +1,140 @@
+package xtra
+
+import (
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cuda"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/xtra/lossscale"
+	"github.com/negativeOne1/gocudnn/xtra/xtrakerns"
+)
+
+//LossScaler does dynamic loss scaling for half training.  The policy is in lossscale.Policy.
+//
+//	err = scaler.ScaleLoss(h, dyD, dy) //after the loss, before the backward pass
+//	... backward pass ...
+//	step, err := scaler.Unscale(h, grads)
+//	if step {
+//		... TrainValues ...
+//	}
+type LossScaler struct {
+	policy  *lossscale.Policy
+	kscale  *cuda.Kernel
+	kscale6 *cuda.Kernel
+	kcheck  *cuda.Kernel
+	kcheck6 *cuda.Kernel
+	found   *gocu.CudaPtr
+	cpufnd  []int32
+	cpuptr  cutil.Mem
+}
+
+//NewLossScaler makes a LossScaler. If policy is nil lossscale.New() is used.
+func NewLossScaler(h *Handle, policy *lossscale.Policy) (*LossScaler, error) {
+	var l *LossScaler
+	var err error
+	if h.w != nil {
+		err = h.w.Work(func() error {
+			l, err = newLossScaler(h, policy)
+			return err
+		})
+		return l, err
+	}
+	return newLossScaler(h, policy)
+}
+
+func newLossScaler(h *Handle, policy *lossscale.Policy) (*LossScaler, error) {
+	if policy == nil {
+		policy = lossscale.New()
+	}
+	ks, err := h.makekernels(xtrakerns.LossScale(), xtrakerns.LossScaleFP16(), xtrakerns.UnscaleCheck(), xtrakerns.UnscaleCheckFP16())
+	if err != nil {
+		return nil, err
+	}
+	l := &LossScaler{
+		policy:  policy,
+		kscale:  ks[0],
+		kscale6: ks[1],
+		kcheck:  ks[2],
+		kcheck6: ks[3],
+		found:   new(gocu.CudaPtr),
+		cpufnd:  make([]int32, 1),
+	}
+	if err = cudart.MallocManagedGlobal(l.found, 4); err != nil {
+		return nil, err
+	}
+	l.cpuptr, err = gocu.MakeGoMem(l.cpufnd)
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+//Policy returns the policy.  It can be saved with encoding/json.
+func (l *LossScaler) Policy() *lossscale.Policy {
+	return l.policy
+}
+
+//Scale returns the current scale
+func (l *LossScaler) Scale() float32 {
+	return l.policy.Scale
+}
+
+//ScaleLoss multiplies the loss gradient dy by the scale in place.
+func (l *LossScaler) ScaleLoss(h *Handle, dyD *gocudnn.TensorD, dy cutil.Mem) error {
+	run := func() error {
+		return launchgradient(h, Gradient{Desc: dyD, Mem: dy}, l.kscale, l.kscale6, func(k *cuda.Kernel, config Config) error {
+			return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, dy, l.policy.Scale)
+		})
+	}
+	if h.w != nil {
+		return h.w.Work(run)
+	}
+	return run()
+}
+
+//Unscale divides the grads by the scale in place and checks them for Inf and NaN.  It syncs the stream to get the result of the check.
+//The policy is updated with the result.  It returns true if the optimizer step should be taken, and false if it should be skipped
+//because of an overflow.  When it is skipped the grads are left with the bad values, so they need to be zeroed before the next backward pass.
+func (l *LossScaler) Unscale(h *Handle, grads []Gradient) (step bool, err error) {
+	run := func() error {
+		overflow, err := l.unscale(h, grads)
+		if err != nil {
+			return err
+		}
+		step = l.policy.Update(overflow)
+		return nil
+	}
+	if h.w != nil {
+		return step, h.w.Work(run)
+	}
+	return step, run()
+}
+
+func (l *LossScaler) unscale(h *Handle, grads []Gradient) (overflow bool, err error) {
+	if err = cudart.MemsetAsync(l.found, 0, 4, h.s); err != nil {
+		return false, err
+	}
+	unscale := l.policy.Unscale()
+	for i := range grads {
+		err = launchgradient(h, grads[i], l.kcheck, l.kcheck6, func(k *cuda.Kernel, config Config) error {
+			return k.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s, config.Elements, grads[i].Mem, unscale, l.found)
+		})
+		if err != nil {
+			return false, err
+		}
+	}
+	if h.s != nil {
+		err = h.s.Sync()
+	} else {
+		err = cudart.SyncNillStream()
+	}
+	if err != nil {
+		return false, err
+	}
+	var kind cudart.MemcpyKind
+	if err = cudart.Memcpy(l.cpuptr, l.found, 4, kind.Default()); err != nil {
+		return false, err
+	}
+	return l.cpufnd[0] != 0, nil
+}
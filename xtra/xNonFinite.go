@@ -0,0 +1,44 @@
+package xtra
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+)
+
+//checknonfinite is used by the trainer and the loss when the NaN/Inf check mode of gocudnn is on. See gocudnn.SetNonFiniteCheck.
+//It needs to be called on the thread that the handle uses.
+func (h *Handle) checknonfinite(op, output string, tD *gocudnn.TensorD, t cutil.Pointer, descs ...fmt.Stringer) error {
+	if !gocudnn.NonFiniteCheck() {
+		return nil
+	}
+	return gocudnn.CheckNonFinite(h.s, op, output, tD, t, descs...)
+}
+
+//checknonfiniteloss checks a loss that was already copied to the host.
+func checknonfiniteloss(op string, loss float32, descs ...fmt.Stringer) error {
+	if !gocudnn.NonFiniteCheck() {
+		return nil
+	}
+	var r tensorutil.NonFinite
+	r.Count = 1
+	switch l := float64(loss); {
+	case math.IsNaN(l):
+		r.NaN++
+	case math.IsInf(l, 1):
+		r.PosInf++
+	case math.IsInf(l, -1):
+		r.NegInf++
+	default:
+		return nil
+	}
+	r.Coords = [][]int{{0}}
+	err := &gocudnn.NonFiniteError{Op: op, Output: "loss", NonFinite: r}
+	for i := range descs {
+		err.Descs = append(err.Descs, descs[i].String())
+	}
+	return err
+}
@@ -0,0 +1,298 @@
+package xtra
+
+import (
+	"errors"
+
+	"github.com/dereklstinson/cutil"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/xtra/xtrahost"
+	"github.com/negativeOne1/gocudnn/xtra/xtrakerns"
+)
+
+/*
+Momentum, Nesterov, RMSProp, AdamW and LAMB are not in the precompiled ptx. Their kernels are compiled with nvrtc by the handle
+the first time a TrainerD is made for them.  The half kernels keep w, dw and the state in half, but the math and the params are float.
+
+The math of each one is in xtrahost, which is what the kernels are tested against.
+*/
+
+//MomentumParams are the params for the Momentum and Nesterov training modes.
+type MomentumParams struct {
+	rate     float32
+	momentum float32
+	dwalpha  float32
+}
+
+//CreateMomentumParamsFloat32 creates the MomentumParams
+func CreateMomentumParamsFloat32(rate, momentum, dwalpha float32) MomentumParams {
+	return MomentumParams{
+		rate:     rate,
+		momentum: momentum,
+		dwalpha:  dwalpha,
+	}
+}
+
+//SetRate sets rate
+func (a *MomentumParams) SetRate(rate float32) {
+	a.rate = rate
+}
+
+//SetMomentum sets momentum
+func (a *MomentumParams) SetMomentum(momentum float32) {
+	a.momentum = momentum
+}
+
+//SetDWalpha sets the dwalpha which is a smoothing factor of dw.
+func (a *MomentumParams) SetDWalpha(dwalpha float32) {
+	a.dwalpha = dwalpha
+}
+
+//RMSPropParams are the params for the RMSProp training mode.
+type RMSPropParams struct {
+	rate    float32
+	decay   float32
+	eps     float32
+	dwalpha float32
+}
+
+//CreateRMSPropParamsFloat32 creates the RMSPropParams.  decay is the decay of the running mean of dw*dw.
+func CreateRMSPropParamsFloat32(rate, decay, eps, dwalpha float32) RMSPropParams {
+	return RMSPropParams{
+		rate:    rate,
+		decay:   decay,
+		eps:     eps,
+		dwalpha: dwalpha,
+	}
+}
+
+//SetRate sets rate
+func (a *RMSPropParams) SetRate(rate float32) {
+	a.rate = rate
+}
+
+//SetDecay sets decay
+func (a *RMSPropParams) SetDecay(decay float32) {
+	a.decay = decay
+}
+
+//SetEps sets eps
+func (a *RMSPropParams) SetEps(eps float32) {
+	a.eps = eps
+}
+
+//SetDWalpha sets the dwalpha which is a smoothing factor of dw.
+func (a *RMSPropParams) SetDWalpha(dwalpha float32) {
+	a.dwalpha = dwalpha
+}
+
+//AdamWParams are the params for the AdamW training mode.
+type AdamWParams struct {
+	rate    float32
+	beta1   float32
+	beta2   float32
+	eps     float32
+	decay   float32
+	dwalpha float32
+}
+
+//CreateAdamWParamsFloat32 creates the AdamWParams. decay is the weight decay.
+func CreateAdamWParamsFloat32(rate, beta1, beta2, eps, decay, dwalpha float32) AdamWParams {
+	return AdamWParams{
+		rate:    rate,
+		beta1:   beta1,
+		beta2:   beta2,
+		eps:     eps,
+		decay:   decay,
+		dwalpha: dwalpha,
+	}
+}
+
+//SetRate sets rate
+func (a *AdamWParams) SetRate(rate float32) {
+	a.rate = rate
+}
+
+//SetBeta1 sets beta1
+func (a *AdamWParams) SetBeta1(beta1 float32) {
+	a.beta1 = beta1
+}
+
+//SetBeta2 sets beta2
+func (a *AdamWParams) SetBeta2(beta2 float32) {
+	a.beta2 = beta2
+}
+
+//SetEps sets eps
+func (a *AdamWParams) SetEps(eps float32) {
+	a.eps = eps
+}
+
+//SetDecay sets the weight decay
+func (a *AdamWParams) SetDecay(decay float32) {
+	a.decay = decay
+}
+
+//SetDWalpha sets the dwalpha which is a smoothing factor of dw.
+func (a *AdamWParams) SetDWalpha(dwalpha float32) {
+	a.dwalpha = dwalpha
+}
+
+//LAMBParams are the params for the LAMB training mode.
+type LAMBParams struct {
+	AdamWParams
+}
+
+//CreateLAMBParamsFloat32 creates the LAMBParams. decay is the weight decay.
+func CreateLAMBParamsFloat32(rate, beta1, beta2, eps, decay, dwalpha float32) LAMBParams {
+	return LAMBParams{CreateAdamWParamsFloat32(rate, beta1, beta2, eps, decay, dwalpha)}
+}
+
+//jittrainerkernels returns the kernels for the training modes that are compiled with nvrtc.
+//ok is false if mode is not one of them.
+func jittrainerkernels(mode TrainingMode, data gocudnn.DataType) (kerns []xtrakerns.Kernel, ok bool, err error) {
+	var f TrainingModeFlag
+	var dt gocudnn.DataType
+	var float, fp16 []xtrakerns.Kernel
+	switch mode {
+	case f.Momentum():
+		float, fp16 = []xtrakerns.Kernel{xtrakerns.Momentum()}, []xtrakerns.Kernel{xtrakerns.MomentumFP16()}
+	case f.Nesterov():
+		float, fp16 = []xtrakerns.Kernel{xtrakerns.Nesterov()}, []xtrakerns.Kernel{xtrakerns.NesterovFP16()}
+	case f.RMSProp():
+		float, fp16 = []xtrakerns.Kernel{xtrakerns.RMSProp()}, []xtrakerns.Kernel{xtrakerns.RMSPropFP16()}
+	case f.AdamW():
+		float, fp16 = []xtrakerns.Kernel{xtrakerns.AdamW()}, []xtrakerns.Kernel{xtrakerns.AdamWFP16()}
+	case f.LAMB():
+		float = []xtrakerns.Kernel{xtrakerns.LAMBMoments(), xtrakerns.LAMBUpdate()}
+		fp16 = []xtrakerns.Kernel{xtrakerns.LAMBMomentsFP16(), xtrakerns.LAMBUpdateFP16()}
+	default:
+		return nil, false, nil
+	}
+	switch data {
+	case dt.Float():
+		return float, true, nil
+	case dt.Half():
+		return fp16, true, nil
+	}
+	return nil, true, errors.New("NewTrainingDescriptor: unsupported Datatype")
+}
+
+func newJITTrainingDescriptor(h *Handle, mode TrainingMode, data gocudnn.DataType, kerns []xtrakerns.Kernel, regname string) (*TrainerD, error) {
+	ks, err := h.makekernels(kerns...)
+	if err != nil {
+		return nil, err
+	}
+	kreg, err := h.kernel(regname)
+	if err != nil {
+		return nil, err
+	}
+	t := &TrainerD{
+		mode:  mode,
+		data:  data,
+		kmode: ks[0],
+		kreg:  kreg,
+	}
+	if len(ks) > 1 {
+		t.kupdate = ks[1]
+	}
+	return t, nil
+}
+
+//TrainMomentum does a step of the Momentum or Nesterov training modes. vel is the velocity. It needs to be the size of w and start at zero.
+func (d *TrainerD) TrainMomentum(h *Handle, desc *gocudnn.TensorD, dw, w, vel cutil.Mem, params MomentumParams) error {
+	var f TrainingModeFlag
+	if d.mode != f.Momentum() && d.mode != f.Nesterov() {
+		return errors.New("(d *TrainerD) TrainMomentum: TrainerD is not Momentum or Nesterov")
+	}
+	return d.train(h, "(d *TrainerD) TrainMomentum", desc, w, func(config Config) error {
+		return d.kmode.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s,
+			config.Elements, w, dw, vel, params.rate, params.momentum, params.dwalpha)
+	})
+}
+
+//TrainRMSProp does a step of the RMSProp training mode. ms is the running mean of dw*dw. It needs to be the size of w and start at zero.
+func (d *TrainerD) TrainRMSProp(h *Handle, desc *gocudnn.TensorD, dw, w, ms cutil.Mem, params RMSPropParams) error {
+	if d.mode != (TrainingModeFlag{}).RMSProp() {
+		return errors.New("(d *TrainerD) TrainRMSProp: TrainerD is not RMSProp")
+	}
+	return d.train(h, "(d *TrainerD) TrainRMSProp", desc, w, func(config Config) error {
+		return d.kmode.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s,
+			config.Elements, w, dw, ms, params.rate, params.decay, params.eps, params.dwalpha)
+	})
+}
+
+//TrainAdamW does a step of the AdamW training mode.  gsum and xsum are the first and second moments.
+//They need to be the size of w and start at zero.  Counter starts at zero and is used for the bias correction like in TrainValues.
+func (d *TrainerD) TrainAdamW(h *Handle, desc *gocudnn.TensorD, dw, w, gsum, xsum cutil.Mem, params AdamWParams, counter int32) error {
+	if d.mode != (TrainingModeFlag{}).AdamW() {
+		return errors.New("(d *TrainerD) TrainAdamW: TrainerD is not AdamW")
+	}
+	denombeta1, denombeta2, err := xtrahost.BiasCorrection(params.beta1, params.beta2, counter)
+	if err != nil {
+		return err
+	}
+	return d.train(h, "(d *TrainerD) TrainAdamW", desc, w, func(config Config) error {
+		return d.kmode.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s,
+			config.Elements, w, gsum, xsum, dw, params.rate, params.beta1, params.beta2, params.eps, params.decay, denombeta1, denombeta2, params.dwalpha)
+	})
+}
+
+//TrainLAMB does a step of the LAMB training mode.  It is AdamW where the update of the whole tensor is scaled by ||w||/||update||.
+//desc should be the tensor of a single layer. gsum and xsum need to be the size of w and start at zero.
+func (d *TrainerD) TrainLAMB(h *Handle, desc *gocudnn.TensorD, dw, w, gsum, xsum cutil.Mem, params LAMBParams, counter int32) error {
+	if d.mode != (TrainingModeFlag{}).LAMB() {
+		return errors.New("(d *TrainerD) TrainLAMB: TrainerD is not LAMB")
+	}
+	denombeta1, denombeta2, err := xtrahost.BiasCorrection(params.beta1, params.beta2, counter)
+	if err != nil {
+		return err
+	}
+	return d.train(h, "(d *TrainerD) TrainLAMB", desc, w, func(config Config) error {
+		if d.norms == nil {
+			norms := new(gocu.CudaPtr)
+			if err := cudart.MallocManagedGlobal(norms, 8); err != nil {
+				return err
+			}
+			d.norms = norms
+		}
+		if err := cudart.MemsetAsync(d.norms, 0, 8, h.s); err != nil {
+			return err
+		}
+		err := d.kmode.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s,
+			config.Elements, w, gsum, xsum, dw, params.beta1, params.beta2, params.eps, params.decay, denombeta1, denombeta2, d.norms)
+		if err != nil {
+			return err
+		}
+		return d.kupdate.Launch(config.BlockCount, 1, 1, config.ThreadPerBlock, 1, 1, 0, h.s,
+			config.Elements, w, gsum, xsum, dw, params.rate, params.eps, params.decay, denombeta1, denombeta2, params.dwalpha, d.norms)
+	})
+}
+
+//train gets the launch config for desc and runs launch on the handle's worker. The check mode is run on w after it.
+func (d *TrainerD) train(h *Handle, op string, desc *gocudnn.TensorD, w cutil.Mem, launch func(config Config) error) error {
+	run := func() error {
+		sizeinbytes, err := desc.GetSizeInBytes()
+		if err != nil {
+			return err
+		}
+		var size int32
+		switch d.data {
+		case d.dtflg.Float():
+			size = int32(sizeinbytes / 4)
+		case d.dtflg.Half():
+			size = int32(sizeinbytes / 2)
+		default:
+			return errors.New(op + " Unsupported Type")
+		}
+		if err = launch(h.LaunchConfig(size)); err != nil {
+			return err
+		}
+		return h.checknonfinite(op, "w", desc, w, desc)
+	}
+	if h.w != nil {
+		return h.w.Work(run)
+	}
+	return run()
+}
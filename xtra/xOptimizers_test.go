@@ -0,0 +1,171 @@
+package xtra
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/dereklstinson/cutil"
+	"github.com/dereklstinson/half"
+	gocudnn "github.com/negativeOne1/gocudnn"
+	"github.com/negativeOne1/gocudnn/cudart"
+	"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/tensorutil"
+	"github.com/negativeOne1/gocudnn/xtra/xtrahost"
+)
+
+//TestTrainersMatchHost runs a few steps of each nvrtc trainer on the device and of its xtrahost version on the host
+//with the same dw, and checks that w, dw and the state match.
+func TestTrainersMatchHost(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	check := func(err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	dev, err := cudart.GetDevice()
+	check(err)
+	check(dev.Set())
+	h, err := MakeHandle(dev, true)
+	check(err)
+
+	const rate, dwalpha = float32(.01), float32(.5)
+	mp := CreateMomentumParamsFloat32(rate, .9, dwalpha)
+	hmp := xtrahost.MomentumParams{Rate: rate, Momentum: .9, DWalpha: dwalpha}
+	rp := CreateRMSPropParamsFloat32(rate, .9, 1e-6, dwalpha)
+	hrp := xtrahost.RMSPropParams{Rate: rate, Decay: .9, Eps: 1e-6, DWalpha: dwalpha}
+	ap := CreateAdamWParamsFloat32(rate, .9, .999, 1e-6, .01, dwalpha)
+	hap := xtrahost.AdamWParams{Rate: rate, Beta1: .9, Beta2: .999, Eps: 1e-6, Decay: .01, DWalpha: dwalpha}
+	lp := CreateLAMBParamsFloat32(rate, .9, .999, 1e-6, .01, dwalpha)
+
+	var f TrainingModeFlag
+	trainers := []struct {
+		name   string
+		mode   TrainingMode
+		nstate int
+		train  func(d *TrainerD, desc *gocudnn.TensorD, dw, w cutil.Mem, s []cutil.Mem, counter int32) error
+		host   func(w, dw []float32, s [][]float32, counter int32) error
+	}{
+		{"momentum", f.Momentum(), 1,
+			func(d *TrainerD, desc *gocudnn.TensorD, dw, w cutil.Mem, s []cutil.Mem, counter int32) error {
+				return d.TrainMomentum(h, desc, dw, w, s[0], mp)
+			},
+			func(w, dw []float32, s [][]float32, counter int32) error { return xtrahost.Momentum(w, dw, s[0], hmp) }},
+		{"nesterov", f.Nesterov(), 1,
+			func(d *TrainerD, desc *gocudnn.TensorD, dw, w cutil.Mem, s []cutil.Mem, counter int32) error {
+				return d.TrainMomentum(h, desc, dw, w, s[0], mp)
+			},
+			func(w, dw []float32, s [][]float32, counter int32) error { return xtrahost.Nesterov(w, dw, s[0], hmp) }},
+		{"rmsprop", f.RMSProp(), 1,
+			func(d *TrainerD, desc *gocudnn.TensorD, dw, w cutil.Mem, s []cutil.Mem, counter int32) error {
+				return d.TrainRMSProp(h, desc, dw, w, s[0], rp)
+			},
+			func(w, dw []float32, s [][]float32, counter int32) error { return xtrahost.RMSProp(w, dw, s[0], hrp) }},
+		{"adamw", f.AdamW(), 2,
+			func(d *TrainerD, desc *gocudnn.TensorD, dw, w cutil.Mem, s []cutil.Mem, counter int32) error {
+				return d.TrainAdamW(h, desc, dw, w, s[0], s[1], ap, counter)
+			},
+			func(w, dw []float32, s [][]float32, counter int32) error {
+				return xtrahost.AdamW(w, dw, s[0], s[1], hap, counter)
+			}},
+		{"lamb", f.LAMB(), 2,
+			func(d *TrainerD, desc *gocudnn.TensorD, dw, w cutil.Mem, s []cutil.Mem, counter int32) error {
+				return d.TrainLAMB(h, desc, dw, w, s[0], s[1], lp, counter)
+			},
+			func(w, dw []float32, s [][]float32, counter int32) error {
+				return xtrahost.LAMB(w, dw, s[0], s[1], hap, counter)
+			}},
+	}
+
+	var dt gocudnn.DataType
+	dtypes := []struct {
+		name string
+		dt   gocudnn.DataType
+		tol  tensorutil.Tolerance
+	}{
+		{"float", dt.Float(), tensorutil.Tolerance{Abs: 1e-5, Rel: 1e-4}},
+		{"half", dt.Half(), tensorutil.Tolerance{Abs: 1e-2, Rel: 1e-2}},
+	}
+
+	const steps, n = 3, 2 * 4 * 4 * 4
+	dims := []int32{2, 4, 4, 4}
+	for _, dtype := range dtypes {
+		fp16 := dtype.dt == dt.Half()
+		//todevice puts x in the same precision as the device so the host starts from the same values.
+		todevice := func(x []float32) ([]float32, interface{}) {
+			if fp16 {
+				y := half.NewFloat16Array(x)
+				return half.ToFloat32(y), y
+			}
+			return x, x
+		}
+		for _, tr := range trainers {
+			name := tr.name + " " + dtype.name
+			var frmt gocudnn.TensorFormat
+			desc, err := gocudnn.CreateTensorDescriptor()
+			check(err)
+			check(desc.Set(frmt.NCHW(), dtype.dt, dims, nil))
+			sib, err := desc.GetSizeInBytes()
+			check(err)
+			d, err := NewTrainingDescriptor(h, tr.mode, dtype.dt)
+			check(err)
+
+			rng := rand.New(rand.NewSource(1))
+			random := func() []float32 {
+				x := make([]float32, n)
+				for i := range x {
+					x[i] = rng.Float32()*2 - 1
+				}
+				return x
+			}
+			alloc := func() cutil.Mem {
+				m := new(gocu.CudaPtr)
+				check(cudart.MallocManagedGlobal(m, sib))
+				return m
+			}
+			hw, wdata := todevice(random())
+			w, dw := alloc(), alloc()
+			check(copytodevice(desc, w, wdata))
+			hstate := make([][]float32, tr.nstate)
+			state := make([]cutil.Mem, tr.nstate)
+			for i := range state {
+				hstate[i] = make([]float32, n)
+				state[i] = alloc()
+			}
+			var hdw []float32
+			for counter := int32(0); counter < steps; counter++ {
+				var dwdata interface{}
+				hdw, dwdata = todevice(random())
+				check(copytodevice(desc, dw, dwdata))
+				check(tr.train(d, desc, dw, w, state, counter))
+				check(tr.host(hw, hdw, hstate, counter))
+			}
+			check(h.sync())
+
+			compare := func(what string, mem cutil.Mem, want []float32) {
+				t.Helper()
+				hd, data, err := copytohost(desc, mem)
+				check(err)
+				var got []float32
+				switch x := data.(type) {
+				case []float32:
+					got = x
+				case []half.Float16:
+					got = half.ToFloat32(x)
+				default:
+					t.Fatalf("%s %s: host copy is %T", name, what, data)
+				}
+				if !tensorutil.Check(t, hd, got, want, dtype.tol) {
+					t.Log(name, what)
+				}
+			}
+			compare("w", w, hw)
+			compare("dw", dw, hdw)
+			for i := range state {
+				compare("state", state[i], hstate[i])
+			}
+		}
+	}
+}
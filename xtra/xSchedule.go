@@ -0,0 +1,32 @@
+package xtra
+
+import "github.com/negativeOne1/gocudnn/xtra/schedule"
+
+//SetScheduled sets the rate, and beta1 if v has a momentum, from a schedule.Scheduler.
+func (a *TrainingParams) SetScheduled(v schedule.Values) {
+	a.rate = v.Rate
+	if v.HasMomentum {
+		a.beta1 = v.Momentum
+	}
+}
+
+//SetScheduled sets the rate and the momentum if v has one, from a schedule.Scheduler.
+func (a *MomentumParams) SetScheduled(v schedule.Values) {
+	a.rate = v.Rate
+	if v.HasMomentum {
+		a.momentum = v.Momentum
+	}
+}
+
+//SetScheduled sets the rate from a schedule.Scheduler.  RMSProp has no momentum so it is ignored.
+func (a *RMSPropParams) SetScheduled(v schedule.Values) {
+	a.rate = v.Rate
+}
+
+//SetScheduled sets the rate, and beta1 if v has a momentum, from a schedule.Scheduler.
+func (a *AdamWParams) SetScheduled(v schedule.Values) {
+	a.rate = v.Rate
+	if v.HasMomentum {
+		a.beta1 = v.Momentum
+	}
+}
@@ -9,7 +9,7 @@ import (
 	"github.com/negativeOne1/gocudnn/cuda"
 	"github.com/dereklstinson/half"
 
-	//"github.com/negativeOne1/gocudnn/gocu"
+	"github.com/negativeOne1/gocudnn/gocu"
 	"github.com/negativeOne1/gocudnn/kernels"
 	"github.com/dereklstinson/cutil"
 )
@@ -37,6 +37,9 @@ type TrainerD struct {
 	kmode *cuda.Kernel
 	kreg  *cuda.Kernel
 	dtflg gocudnn.DataType
+	//kupdate and norms are used by the second pass of LAMB
+	kupdate *cuda.Kernel
+	norms   *gocu.CudaPtr
 }
 
 //RegParams holds the regulator paramaters
@@ -147,6 +150,31 @@ func (t TrainingModeFlag) Adam() TrainingMode {
 	return TrainingMode(4)
 }
 
+//Momentum performs sgd with momentum.  Use TrainMomentum.
+func (t TrainingModeFlag) Momentum() TrainingMode {
+	return TrainingMode(5)
+}
+
+//Nesterov performs sgd with nesterov momentum. Use TrainMomentum.
+func (t TrainingModeFlag) Nesterov() TrainingMode {
+	return TrainingMode(6)
+}
+
+//RMSProp performs the rmsprop algo. Use TrainRMSProp.
+func (t TrainingModeFlag) RMSProp() TrainingMode {
+	return TrainingMode(7)
+}
+
+//AdamW performs adam with decoupled weight decay. Use TrainAdamW.
+func (t TrainingModeFlag) AdamW() TrainingMode {
+	return TrainingMode(8)
+}
+
+//LAMB performs adamw with a layer wise trust ratio. Use TrainLAMB.
+func (t TrainingModeFlag) LAMB() TrainingMode {
+	return TrainingMode(9)
+}
+
 func (t TrainingMode) tostring(datatype gocudnn.DataType) string {
 	f := TrainingModeFlag{}
 	var dtf gocudnn.DataType
@@ -172,6 +200,9 @@ func (t TrainingMode) tostring(datatype gocudnn.DataType) string {
 		}
 	}
 
+	if kerns, ok, err := jittrainerkernels(t, datatype); ok && err == nil {
+		return kerns[0].Name
+	}
 	return "Not Supported"
 }
 
@@ -195,6 +226,12 @@ func newTrainingDescriptor(h *Handle, mode TrainingMode, data gocudnn.DataType)
 	var ktf kernels.XtraKerns
 
 	regname := ktf.L1L2()
+	if kerns, ok, err := jittrainerkernels(mode, data); ok {
+		if err != nil {
+			return nil, err
+		}
+		return newJITTrainingDescriptor(h, mode, data, kerns, regname)
+	}
 
 	var mflg TrainingModeFlag
 	var mname string
@@ -226,11 +263,11 @@ func newTrainingDescriptor(h *Handle, mode TrainingMode, data gocudnn.DataType)
 		return nil, errors.New("NewTrainingDescriptor: unsupported Datatype")
 	}
 
-	kmode, err := cuda.MakeKernel(mname, h.mod)
+	kmode, err := h.kernel(mname)
 	if err != nil {
 		return nil, err
 	}
-	kreg, err := cuda.MakeKernel(regname, h.mod)
+	kreg, err := h.kernel(regname)
 	if err != nil {
 		return nil, err
 	}
@@ -310,10 +347,16 @@ func (d *TrainerD) l1L2Regularization(h *Handle, desc *gocudnn.TensorD, dw, w, l
 func (d *TrainerD) TrainValues(h *Handle, desc *gocudnn.TensorD, dw, w, gsum, xsum cutil.Mem, params TrainingParams, counter int32) error {
 	if h.w != nil {
 		return h.w.Work(func() error {
-			return d.trainValues(h, desc, dw, w, gsum, xsum, params, counter)
+			if err := d.trainValues(h, desc, dw, w, gsum, xsum, params, counter); err != nil {
+				return err
+			}
+			return h.checknonfinite("(d *TrainerD) TrainValues", "w", desc, w, desc)
 		})
 	}
-	return d.trainValues(h, desc, dw, w, gsum, xsum, params, counter)
+	if err := d.trainValues(h, desc, dw, w, gsum, xsum, params, counter); err != nil {
+		return err
+	}
+	return h.checknonfinite("(d *TrainerD) TrainValues", "w", desc, w, desc)
 }
 
 //TrainValues  Adagrad requires gsum, but not xsum.  If Adagrad is used then  nil can be passed for xsum.
@@ -148,23 +148,23 @@ func NewBatchSwapper(h *Handle) (*Swapper, error) {
 
 func newBatchSwapper(h *Handle) (*Swapper, error) {
 
-	swapeveryother, err := cuda.MakeKernel(kernels.XtraKerns{}.SwapEveryOther(), h.mod)
+	swapeveryother, err := h.kernel(kernels.XtraKerns{}.SwapEveryOther())
 	if err != nil {
 		fmt.Println("1")
 		return nil, err
 	}
-	swapeveryotherfp16, err := cuda.MakeKernel(kernels.XtraKerns{}.SwapEveryOtherFP16(), h.mod)
+	swapeveryotherfp16, err := h.kernel(kernels.XtraKerns{}.SwapEveryOtherFP16())
 	if err != nil {
 		fmt.Println("2")
 		return nil, err
 	}
 
-	swapupperlower, err := cuda.MakeKernel(kernels.XtraKerns{}.SwapUpperLower(), h.mod)
+	swapupperlower, err := h.kernel(kernels.XtraKerns{}.SwapUpperLower())
 	if err != nil {
 		fmt.Println("3")
 		return nil, err
 	}
-	swapupperlowerfp16, err := cuda.MakeKernel(kernels.XtraKerns{}.SwapUpperLowerFP16(), h.mod)
+	swapupperlowerfp16, err := h.kernel(kernels.XtraKerns{}.SwapUpperLowerFP16())
 	if err != nil {
 		fmt.Println("4")
 		return nil, err
@@ -2,3 +2,5 @@
 //to make functions that I use that are useful in deep learning.
 //This package can also be used as an example of how to write functions using the cuda subpackage
 package xtra
+
+//go:generate go run ../kernels/launchgen -pkg xtra -o zlaunch.go ../kernels/gocudnnxtra.cu ../kernels/gocudnnxtrafp16.cu xtrakerns/*.go
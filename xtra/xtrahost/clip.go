@@ -0,0 +1,43 @@
+package xtrahost
+
+import "math"
+
+//GlobalNorm returns the l2 norm of all of the grads as if they were one vector.  The sum is done in float64.
+func GlobalNorm(grads ...[]float32) float32 {
+	var sum float64
+	for _, g := range grads {
+		for _, x := range g {
+			sum += float64(x) * float64(x)
+		}
+	}
+	return float32(math.Sqrt(sum))
+}
+
+//ClipByGlobalNorm scales every grad by maxnorm/(norm+1e-6) if the global norm is more than maxnorm.
+//It returns the norm before the grads were scaled.
+func ClipByGlobalNorm(maxnorm float32, grads ...[]float32) float32 {
+	norm := GlobalNorm(grads...)
+	if !(norm > maxnorm) {
+		return norm
+	}
+	scale := maxnorm / (norm + 1e-6)
+	for _, g := range grads {
+		for i := range g {
+			g[i] *= scale
+		}
+	}
+	return norm
+}
+
+//ClipByValue clips every value of the grads to [min,max].
+func ClipByValue(min, max float32, grads ...[]float32) {
+	for _, g := range grads {
+		for i := range g {
+			if g[i] < min {
+				g[i] = min
+			} else if g[i] > max {
+				g[i] = max
+			}
+		}
+	}
+}
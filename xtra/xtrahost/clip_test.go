@@ -0,0 +1,37 @@
+package xtrahost
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClipByGlobalNorm(t *testing.T) {
+	a, b := []float32{3}, []float32{0, 4}
+	if n := GlobalNorm(a, b); n != 5 {
+		t.Error(n)
+	}
+	norm := ClipByGlobalNorm(1, a, b)
+	if norm != 5 || !near(a[0], .6) || !near(b[1], .8) {
+		t.Error(norm, a, b)
+	}
+	if n := GlobalNorm(a, b); !near(n, 1) {
+		t.Error(n)
+	}
+	//under the max nothing changes
+	ClipByGlobalNorm(10, a, b)
+	if !near(a[0], .6) {
+		t.Error(a)
+	}
+	c := []float32{float32(math.NaN()), 1}
+	if n := ClipByGlobalNorm(1, c); !math.IsNaN(float64(n)) || c[1] != 1 {
+		t.Error("a NaN norm should be returned without scaling", n, c)
+	}
+}
+
+func TestClipByValue(t *testing.T) {
+	a, b := []float32{-2, 0, 2}, []float32{5}
+	ClipByValue(-1, 1, a, b)
+	if a[0] != -1 || a[1] != 0 || a[2] != 1 || b[0] != 1 {
+		t.Error(a, b)
+	}
+}
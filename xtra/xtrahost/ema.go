@@ -0,0 +1,35 @@
+package xtrahost
+
+//EMADecay returns the decay used for an EMA update after step updates have already been done.
+//With warmup the decay is min(decay,(1+step)/(10+step)) so the shadow follows the weights more closely at the start of training.
+func EMADecay(decay float32, step int, warmup bool) float32 {
+	if !warmup {
+		return decay
+	}
+	if d := float32(1+step) / float32(10+step); d < decay {
+		return d
+	}
+	return decay
+}
+
+//EMAUpdate moves shadow toward w. shadow = decay*shadow + (1-decay)*w
+func EMAUpdate(decay float32, w, shadow []float32) error {
+	if err := checklengths("EMAUpdate", len(w), shadow); err != nil {
+		return err
+	}
+	for i := range w {
+		shadow[i] = decay*shadow[i] + (1-decay)*w[i]
+	}
+	return nil
+}
+
+//SwapValues swaps the values of x and y
+func SwapValues(x, y []float32) error {
+	if err := checklengths("SwapValues", len(x), y); err != nil {
+		return err
+	}
+	for i := range x {
+		x[i], y[i] = y[i], x[i]
+	}
+	return nil
+}
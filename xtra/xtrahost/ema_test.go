@@ -0,0 +1,56 @@
+package xtrahost
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEMADecay(t *testing.T) {
+	if d := EMADecay(.999, 0, false); d != .999 {
+		t.Error(d)
+	}
+	if d := EMADecay(.999, 0, true); !near(d, .1) {
+		t.Error(d)
+	}
+	if d := EMADecay(.5, 100, true); d != .5 {
+		t.Error("warmup should never go over decay", d)
+	}
+	prev := float32(0)
+	for step := 0; step < 1000; step += 100 {
+		d := EMADecay(.999, step, true)
+		if d < prev {
+			t.Error("warmup decay should grow", step, d, prev)
+		}
+		prev = d
+	}
+}
+
+func TestEMAUpdate(t *testing.T) {
+	w, shadow := []float32{1, 2}, []float32{0, 2}
+	if err := EMAUpdate(.75, w, shadow); err != nil {
+		t.Fatal(err)
+	}
+	if !near(shadow[0], .25) || shadow[1] != 2 {
+		t.Error(shadow)
+	}
+	//a decay of zero copies the weights
+	if err := EMAUpdate(0, []float32{3, 4}, shadow); err != nil || shadow[0] != 3 || shadow[1] != 4 {
+		t.Error(shadow, err)
+	}
+	if err := EMAUpdate(.5, w, []float32{1}); !errors.Is(err, ErrLength) {
+		t.Error(err)
+	}
+}
+
+func TestSwapValues(t *testing.T) {
+	x, y := []float32{1, 2}, []float32{3, 4}
+	if err := SwapValues(x, y); err != nil {
+		t.Fatal(err)
+	}
+	if x[0] != 3 || x[1] != 4 || y[0] != 1 || y[1] != 2 {
+		t.Error(x, y)
+	}
+	if err := SwapValues(x, nil); !errors.Is(err, ErrLength) {
+		t.Error(err)
+	}
+}
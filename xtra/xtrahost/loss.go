@@ -0,0 +1,237 @@
+package xtrahost
+
+import (
+	"fmt"
+	"math"
+)
+
+//The losses return the sum of the loss over every element or position, and write the gradient of that sum into dx.
+//xtra.XLossD divides the sum by the batch size.  The math is done in float64.
+
+//ClassShape is how a tensor is split up for the losses that work along a class dim.
+//The element for class c at position p is at (p/Inner)*Classes*Inner + c*Inner + p%Inner.
+//NCHW is {N, C, H*W} and NHWC is {N*H*W, C, 1}.
+type ClassShape struct {
+	Outer   int
+	Classes int
+	Inner   int
+}
+
+//Positions returns the number of positions, which is the number of elements divided by Classes.
+func (s ClassShape) Positions() int { return s.Outer * s.Inner }
+
+//Len returns the number of elements
+func (s ClassShape) Len() int { return s.Outer * s.Classes * s.Inner }
+
+func (s ClassShape) index(pos, c int) int {
+	return (pos/s.Inner)*s.Classes*s.Inner + c*s.Inner + pos%s.Inner
+}
+
+func (s ClassShape) check(op string, weights []float32, x ...[]float32) error {
+	if s.Outer < 0 || s.Classes < 1 || s.Inner < 1 {
+		return fmt.Errorf("%s: bad ClassShape %v", op, s)
+	}
+	if err := checklengths(op, s.Len(), x...); err != nil {
+		return err
+	}
+	if weights != nil {
+		return checklengths(op, s.Classes, weights)
+	}
+	return nil
+}
+
+//logsumexp returns log(sum(exp(x))) over the classes at pos
+func (s ClassShape) logsumexp(x []float32, pos int) float64 {
+	mx := math.Inf(-1)
+	for c := 0; c < s.Classes; c++ {
+		mx = math.Max(mx, float64(x[s.index(pos, c)]))
+	}
+	var se float64
+	for c := 0; c < s.Classes; c++ {
+		se += math.Exp(float64(x[s.index(pos, c)]) - mx)
+	}
+	return mx + math.Log(se)
+}
+
+func weight(weights []float32, c int) float64 {
+	if weights == nil {
+		return 1
+	}
+	return float64(weights[c])
+}
+
+//softmaxce does the cross entropy of softmax(x) against the targets given by target(pos,c).
+//The gradient is p_j*sum(w_c*t_c) - w_j*t_j.
+func softmaxce(s ClassShape, x, weights []float32, dx []float32, target func(pos, c int) (t float64, ok bool)) float32 {
+	var loss float64
+	for pos := 0; pos < s.Positions(); pos++ {
+		if _, ok := target(pos, 0); !ok {
+			for c := 0; c < s.Classes; c++ {
+				dx[s.index(pos, c)] = 0
+			}
+			continue
+		}
+		lse := s.logsumexp(x, pos)
+		var sum float64
+		for c := 0; c < s.Classes; c++ {
+			i := s.index(pos, c)
+			t, _ := target(pos, c)
+			wt := weight(weights, c) * t
+			sum += wt
+			loss += wt * (lse - float64(x[i]))
+		}
+		for c := 0; c < s.Classes; c++ {
+			i := s.index(pos, c)
+			t, _ := target(pos, c)
+			p := math.Exp(float64(x[i]) - lse)
+			dx[i] = float32(p*sum - weight(weights, c)*t)
+		}
+	}
+	return float32(loss)
+}
+
+//SoftmaxCrossEntropy is the cross entropy of softmax(x) along the class dim against the target probabilities t.
+//The targets are smoothed to (1-smoothing)*t + smoothing/Classes.  weights are the class weights. They can be nil.
+func SoftmaxCrossEntropy(s ClassShape, x, t, weights []float32, smoothing float32, dx []float32) (float32, error) {
+	if err := s.check("SoftmaxCrossEntropy", weights, x, t, dx); err != nil {
+		return 0, err
+	}
+	sm := float64(smoothing)
+	return softmaxce(s, x, weights, dx, func(pos, c int) (float64, bool) {
+		return (1-sm)*float64(t[s.index(pos, c)]) + sm/float64(s.Classes), true
+	}), nil
+}
+
+//SparseSoftmaxCrossEntropy is SoftmaxCrossEntropy where the targets are the class of each position.
+//labels has one value per position.  A label that is negative or not less than Classes is ignored and its gradient is zero.
+func SparseSoftmaxCrossEntropy(s ClassShape, x []float32, labels []int32, weights []float32, smoothing float32, dx []float32) (float32, error) {
+	if err := s.check("SparseSoftmaxCrossEntropy", weights, x, dx); err != nil {
+		return 0, err
+	}
+	if len(labels) != s.Positions() {
+		return 0, fmt.Errorf("SparseSoftmaxCrossEntropy: %w: %d labels for %d positions", ErrLength, len(labels), s.Positions())
+	}
+	sm := float64(smoothing)
+	return softmaxce(s, x, weights, dx, func(pos, c int) (float64, bool) {
+		label := int(labels[pos])
+		if label < 0 || label >= s.Classes {
+			return 0, false
+		}
+		t := sm / float64(s.Classes)
+		if c == label {
+			t += 1 - sm
+		}
+		return t, true
+	}), nil
+}
+
+//KLDiv is the kl divergence of softmax(x) along the class dim from the target probabilities t.
+//
+//	sum t*(log(t) - log(softmax(x)))
+//
+//Targets that are zero add nothing to the loss.
+func KLDiv(s ClassShape, x, t, dx []float32) (float32, error) {
+	if err := s.check("KLDiv", nil, x, t, dx); err != nil {
+		return 0, err
+	}
+	var entropy float64
+	for i := range t {
+		if t[i] > 0 {
+			entropy += float64(t[i]) * math.Log(float64(t[i]))
+		}
+	}
+	ce := softmaxce(s, x, nil, dx, func(pos, c int) (float64, bool) {
+		return float64(t[s.index(pos, c)]), true
+	})
+	return float32(float64(ce) + entropy), nil
+}
+
+//BCEWithLogits is the binary cross entropy of sigmoid(x) against t.
+//
+//	max(x,0) - x*t + log(1+exp(-|x|))
+func BCEWithLogits(x, t, dx []float32) (float32, error) {
+	if err := checklengths("BCEWithLogits", len(x), t, dx); err != nil {
+		return 0, err
+	}
+	var loss float64
+	for i := range x {
+		xi, ti := float64(x[i]), float64(t[i])
+		loss += bcelogits(xi, ti)
+		dx[i] = float32(sigmoid(xi) - ti)
+	}
+	return float32(loss), nil
+}
+
+//Huber is the huber loss of x-t.  It is quadratic when |x-t| <= delta and linear past it.
+func Huber(x, t, dx []float32, delta float32) (float32, error) {
+	if err := checklengths("Huber", len(x), t, dx); err != nil {
+		return 0, err
+	}
+	return huber(x, t, dx, float64(delta), 1), nil
+}
+
+//SmoothL1 is the smooth l1 loss of x-t.  It is Huber with delta = beta divided by beta.
+func SmoothL1(x, t, dx []float32, beta float32) (float32, error) {
+	if err := checklengths("SmoothL1", len(x), t, dx); err != nil {
+		return 0, err
+	}
+	if beta <= 0 {
+		return 0, fmt.Errorf("SmoothL1: beta needs to be more than zero")
+	}
+	return huber(x, t, dx, float64(beta), 1/float64(beta)), nil
+}
+
+func huber(x, t, dx []float32, delta, scale float64) float32 {
+	var loss float64
+	for i := range x {
+		r := float64(x[i]) - float64(t[i])
+		if math.Abs(r) <= delta {
+			loss += scale * .5 * r * r
+			dx[i] = float32(scale * r)
+			continue
+		}
+		loss += scale * delta * (math.Abs(r) - .5*delta)
+		dx[i] = float32(scale * delta * math.Copysign(1, r))
+	}
+	return float32(loss)
+}
+
+//Focal is the sigmoid focal loss of x against t.
+//
+//	alpha_t * (1-p_t)^gamma * BCEWithLogits(x,t)
+//
+//where p = sigmoid(x), p_t = p*t + (1-p)*(1-t) and alpha_t = alpha*t + (1-alpha)*(1-t).
+//A negative alpha turns off the alpha weighting.
+func Focal(x, t, dx []float32, alpha, gamma float32) (float32, error) {
+	if err := checklengths("Focal", len(x), t, dx); err != nil {
+		return 0, err
+	}
+	g := float64(gamma)
+	var loss float64
+	for i := range x {
+		xi, ti := float64(x[i]), float64(t[i])
+		p := sigmoid(xi)
+		ce := bcelogits(xi, ti)
+		m := 1 - (p*ti + (1-p)*(1-ti))
+		a := 1.0
+		if alpha >= 0 {
+			a = float64(alpha)*ti + (1-float64(alpha))*(1-ti)
+		}
+		mg := math.Pow(m, g)
+		loss += a * mg * ce
+		grad := mg * (p - ti)
+		if m > 0 && g != 0 {
+			grad -= g * math.Pow(m, g-1) * (2*ti - 1) * p * (1 - p) * ce
+		}
+		dx[i] = float32(a * grad)
+	}
+	return float32(loss), nil
+}
+
+func bcelogits(x, t float64) float64 {
+	return math.Max(x, 0) - x*t + math.Log1p(math.Exp(-math.Abs(x)))
+}
+
+func sigmoid(x float64) float64 {
+	return 1 / (1 + math.Exp(-x))
+}
@@ -0,0 +1,198 @@
+package xtrahost
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/negativeOne1/gocudnn/gradcheck"
+)
+
+//checkloss runs the gradient check on a loss with respect to x.
+func checkloss(t *testing.T, name string, x []float32, f func(x, dx []float32) (float32, error)) {
+	t.Helper()
+	in := []gradcheck.Tensor{{Name: name, Shape: gradcheck.Dims{int32(len(x))}, Data: make([]float64, len(x))}}
+	gradcheck.FromFloat32(in[0].Data, x)
+	out := []gradcheck.Tensor{{Name: "loss", Shape: gradcheck.Dims{1}}}
+	forward := func(x, y [][]float64) error {
+		l, err := f(gradcheck.ToFloat32(x[0]), make([]float32, len(x[0])))
+		y[0][0] = float64(l)
+		return err
+	}
+	backward := func(x, dy, dx [][]float64) error {
+		g := make([]float32, len(x[0]))
+		if _, err := f(gradcheck.ToFloat32(x[0]), g); err != nil {
+			return err
+		}
+		for i := range g {
+			dx[0][i] = dy[0][0] * float64(g[i])
+		}
+		return nil
+	}
+	r, err := gradcheck.Checker{AbsTol: 1e-3}.Check(in, out, forward, backward)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+func randslice(rng *rand.Rand, n int, scale float32) []float32 {
+	x := make([]float32, n)
+	for i := range x {
+		x[i] = (rng.Float32()*2 - 1) * scale
+	}
+	return x
+}
+
+func probs(rng *rand.Rand, s ClassShape) []float32 {
+	t := make([]float32, s.Len())
+	for pos := 0; pos < s.Positions(); pos++ {
+		var sum float32
+		for c := 0; c < s.Classes; c++ {
+			v := rng.Float32()
+			t[s.index(pos, c)] = v
+			sum += v
+		}
+		for c := 0; c < s.Classes; c++ {
+			t[s.index(pos, c)] /= sum
+		}
+	}
+	return t
+}
+
+func TestSoftmaxCrossEntropy(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, s := range []ClassShape{{2, 3, 4}, {6, 5, 1}} {
+		x := randslice(rng, s.Len(), 3)
+		tg := probs(rng, s)
+		w := randslice(rng, s.Classes, 1)
+		for i := range w {
+			w[i] += 1.5
+		}
+		checkloss(t, "x", x, func(x, dx []float32) (float32, error) {
+			return SoftmaxCrossEntropy(s, x, tg, w, .1, dx)
+		})
+	}
+	//Uniform logits against a one hot target is log(classes)
+	s := ClassShape{1, 4, 1}
+	l, err := SoftmaxCrossEntropy(s, make([]float32, 4), []float32{0, 1, 0, 0}, nil, 0, make([]float32, 4))
+	if err != nil || !near(l, float32(math.Log(4))) {
+		t.Error(l, err)
+	}
+}
+
+func TestSparseSoftmaxCrossEntropy(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	s := ClassShape{2, 3, 2}
+	x := randslice(rng, s.Len(), 3)
+	labels := []int32{0, 2, -1, 1}
+	w := []float32{1, 2, .5}
+	checkloss(t, "x", x, func(x, dx []float32) (float32, error) {
+		return SparseSoftmaxCrossEntropy(s, x, labels, w, .2, dx)
+	})
+	//it matches the dense loss with one hot targets
+	dense := make([]float32, s.Len())
+	for pos, l := range labels {
+		if l >= 0 {
+			dense[s.index(pos, int(l))] = 1
+		}
+	}
+	dxs, dxd := make([]float32, s.Len()), make([]float32, s.Len())
+	ls, _ := SparseSoftmaxCrossEntropy(s, x, labels, w, 0, dxs)
+	ld, _ := SoftmaxCrossEntropy(s, x, dense, w, 0, dxd)
+	//the ignored position gives the dense loss nothing too since its targets are all zero
+	if !near(ls, ld) {
+		t.Error(ls, ld)
+	}
+	for i := range dxs {
+		if !near(dxs[i], dxd[i]) {
+			t.Error(i, dxs[i], dxd[i])
+		}
+	}
+	if dxs[s.index(2, 0)] != 0 {
+		t.Error("ignored label should have no gradient")
+	}
+	if _, err := SparseSoftmaxCrossEntropy(s, x, labels[:2], nil, 0, dxs); !errors.Is(err, ErrLength) {
+		t.Error(err)
+	}
+}
+
+func TestKLDiv(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	s := ClassShape{3, 4, 1}
+	x := randslice(rng, s.Len(), 2)
+	tg := probs(rng, s)
+	tg[0] = 0
+	checkloss(t, "x", x, func(x, dx []float32) (float32, error) {
+		return KLDiv(s, x, tg, dx)
+	})
+	//the kl divergence of a distribution from itself is zero
+	tg = probs(rng, s)
+	logits := make([]float32, len(tg))
+	for i := range tg {
+		logits[i] = float32(math.Log(float64(tg[i])))
+	}
+	if l, _ := KLDiv(s, logits, tg, make([]float32, len(tg))); math.Abs(float64(l)) > 1e-5 {
+		t.Error(l)
+	}
+}
+
+func TestBCEWithLogits(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	x := randslice(rng, 10, 4)
+	tg := randslice(rng, 10, .5)
+	for i := range tg {
+		tg[i] += .5
+	}
+	checkloss(t, "x", x, func(x, dx []float32) (float32, error) {
+		return BCEWithLogits(x, tg, dx)
+	})
+	l, _ := BCEWithLogits([]float32{0, 100}, []float32{1, 1}, make([]float32, 2))
+	if !near(l, float32(math.Log(2))) {
+		t.Error(l)
+	}
+}
+
+func TestHuber(t *testing.T) {
+	x, tg := []float32{0, 3, -3, .5}, []float32{0, 0, 0, 0}
+	dx := make([]float32, 4)
+	l, err := Huber(x, tg, dx, 1)
+	if err != nil || !near(l, 2.5+2.5+.125) || dx[1] != 1 || dx[2] != -1 || dx[3] != .5 {
+		t.Error(l, dx, err)
+	}
+	l, _ = SmoothL1(x, tg, dx, 2)
+	//|3| is past beta so 3-1, .5 is under so .5*.25/2
+	if !near(l, 2+2+.0625) || dx[3] != .25 {
+		t.Error(l, dx)
+	}
+	if _, err = SmoothL1(x, tg, dx, 0); err == nil {
+		t.Error("expected an error for beta of 0")
+	}
+	rng := rand.New(rand.NewSource(5))
+	x = randslice(rng, 10, 3)
+	tg = randslice(rng, 10, 1)
+	checkloss(t, "x", x, func(x, dx []float32) (float32, error) {
+		return Huber(x, tg, dx, .7)
+	})
+}
+
+func TestFocal(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+	x := randslice(rng, 10, 3)
+	tg := []float32{0, 1, 1, 0, 1, 0, 0, 1, .3, .8}
+	for _, g := range []float32{0, 1, 2} {
+		checkloss(t, "x", x, func(x, dx []float32) (float32, error) {
+			return Focal(x, tg, dx, .25, g)
+		})
+	}
+	//gamma 0 and no alpha is bce
+	dx := make([]float32, len(x))
+	lf, _ := Focal(x, tg, dx, -1, 0)
+	lb, _ := BCEWithLogits(x, tg, make([]float32, len(x)))
+	if !near(lf, lb) {
+		t.Error(lf, lb)
+	}
+}
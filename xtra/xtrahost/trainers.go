@@ -0,0 +1,163 @@
+package xtrahost
+
+import (
+	"errors"
+	"math"
+)
+
+//The trainers update w in place and scale dw by DWalpha like the kernels do.
+//The state slices (vel, ms, gsum, xsum) start at zero and need to be kept between steps.
+
+//MomentumParams are the params for Momentum and Nesterov
+type MomentumParams struct {
+	Rate     float32
+	Momentum float32
+	DWalpha  float32
+}
+
+//Momentum is sgd with momentum.
+//
+//	vel = momentum*vel + dw
+//	w -= rate*vel
+func Momentum(w, dw, vel []float32, p MomentumParams) error {
+	if err := checklengths("Momentum", len(w), dw, vel); err != nil {
+		return err
+	}
+	for i := range w {
+		vel[i] = p.Momentum*vel[i] + dw[i]
+		w[i] -= p.Rate * vel[i]
+		dw[i] *= p.DWalpha
+	}
+	return nil
+}
+
+//Nesterov is sgd with nesterov momentum.
+//
+//	vel = momentum*vel + dw
+//	w -= rate*(dw + momentum*vel)
+func Nesterov(w, dw, vel []float32, p MomentumParams) error {
+	if err := checklengths("Nesterov", len(w), dw, vel); err != nil {
+		return err
+	}
+	for i := range w {
+		vel[i] = p.Momentum*vel[i] + dw[i]
+		w[i] -= p.Rate * (dw[i] + p.Momentum*vel[i])
+		dw[i] *= p.DWalpha
+	}
+	return nil
+}
+
+//RMSPropParams are the params for RMSProp
+type RMSPropParams struct {
+	Rate    float32
+	Decay   float32 //Decay of the running mean of the square of dw. Usually .9
+	Eps     float32
+	DWalpha float32
+}
+
+//RMSProp is the rmsprop updater.
+//
+//	ms = decay*ms + (1-decay)*dw*dw
+//	w -= rate*dw/(sqrt(ms)+eps)
+func RMSProp(w, dw, ms []float32, p RMSPropParams) error {
+	if err := checklengths("RMSProp", len(w), dw, ms); err != nil {
+		return err
+	}
+	for i := range w {
+		ms[i] = p.Decay*ms[i] + (1-p.Decay)*dw[i]*dw[i]
+		w[i] -= (p.Rate * dw[i]) / (sqrt32(ms[i]) + p.Eps)
+		dw[i] *= p.DWalpha
+	}
+	return nil
+}
+
+//AdamWParams are the params for AdamW and LAMB
+type AdamWParams struct {
+	Rate    float32
+	Beta1   float32
+	Beta2   float32
+	Eps     float32
+	Decay   float32 //Weight decay. It is not scaled by the moments.
+	DWalpha float32
+}
+
+//BiasCorrection returns the adam bias correction denominators 1-beta1^(counter+1) and 1-beta2^(counter+1).
+//Counter starts at zero.  It returns an error if either is zero.
+func BiasCorrection(beta1, beta2 float32, counter int32) (denombeta1, denombeta2 float32, err error) {
+	denombeta1 = 1.0 - float32(math.Pow(float64(beta1), float64(counter+1)))
+	denombeta2 = 1.0 - float32(math.Pow(float64(beta2), float64(counter+1)))
+	if denombeta1 == 0 || denombeta2 == 0 {
+		return denombeta1, denombeta2, errors.New("BiasCorrection: beta1 or beta2 gives a zero denominator")
+	}
+	return denombeta1, denombeta2, nil
+}
+
+//AdamW is adam with decoupled weight decay.
+//
+//	gsum = beta1*gsum + (1-beta1)*dw
+//	xsum = beta2*xsum + (1-beta2)*dw*dw
+//	w -= rate*((gsum/denombeta1)/(sqrt(xsum/denombeta2)+eps) + decay*w)
+func AdamW(w, dw, gsum, xsum []float32, p AdamWParams, counter int32) error {
+	if err := checklengths("AdamW", len(w), dw, gsum, xsum); err != nil {
+		return err
+	}
+	d1, d2, err := BiasCorrection(p.Beta1, p.Beta2, counter)
+	if err != nil {
+		return err
+	}
+	for i := range w {
+		gsum[i], xsum[i] = adammoments(gsum[i], xsum[i], dw[i], p)
+		w[i] -= p.Rate * adamwstep(w[i], gsum[i], xsum[i], d1, d2, p)
+		dw[i] *= p.DWalpha
+	}
+	return nil
+}
+
+//LAMBParams are the params for LAMB
+type LAMBParams = AdamWParams
+
+//LAMB is AdamW where the update of the whole tensor is scaled by the trust ratio ||w||/||r||, where r is the AdamW update.
+//If either norm is zero the trust ratio is 1.
+func LAMB(w, dw, gsum, xsum []float32, p LAMBParams, counter int32) error {
+	if err := checklengths("LAMB", len(w), dw, gsum, xsum); err != nil {
+		return err
+	}
+	d1, d2, err := BiasCorrection(p.Beta1, p.Beta2, counter)
+	if err != nil {
+		return err
+	}
+	var wsq, rsq float64
+	for i := range w {
+		gsum[i], xsum[i] = adammoments(gsum[i], xsum[i], dw[i], p)
+		r := adamwstep(w[i], gsum[i], xsum[i], d1, d2, p)
+		wsq += float64(w[i]) * float64(w[i])
+		rsq += float64(r) * float64(r)
+	}
+	trust := TrustRatio(float32(wsq), float32(rsq))
+	for i := range w {
+		w[i] -= p.Rate * trust * adamwstep(w[i], gsum[i], xsum[i], d1, d2, p)
+		dw[i] *= p.DWalpha
+	}
+	return nil
+}
+
+//TrustRatio returns the LAMB trust ratio from the sums of squares of the weights and of the update.
+func TrustRatio(wsq, rsq float32) float32 {
+	wnorm, rnorm := sqrt32(wsq), sqrt32(rsq)
+	if wnorm > 0 && rnorm > 0 {
+		return wnorm / rnorm
+	}
+	return 1
+}
+
+func adammoments(gsum, xsum, dw float32, p AdamWParams) (float32, float32) {
+	return p.Beta1*gsum + (1-p.Beta1)*dw, p.Beta2*xsum + (1-p.Beta2)*(dw*dw)
+}
+
+func adamwstep(w, gsum, xsum, denombeta1, denombeta2 float32, p AdamWParams) float32 {
+	return (gsum/denombeta1)/(sqrt32(xsum/denombeta2)+p.Eps) + p.Decay*w
+}
+
+func sqrt32(x float32) float32 {
+	return float32(math.Sqrt(float64(x)))
+}
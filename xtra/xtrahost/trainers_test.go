@@ -0,0 +1,117 @@
+package xtrahost
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) <= 1e-5*math.Max(1, math.Abs(float64(b)))
+}
+
+func TestMomentum(t *testing.T) {
+	w, dw, vel := []float32{1, -1}, []float32{.5, 2}, []float32{0, 0}
+	p := MomentumParams{Rate: .1, Momentum: .9, DWalpha: 0}
+	if err := Momentum(w, dw, vel, p); err != nil {
+		t.Fatal(err)
+	}
+	if !near(w[0], .95) || !near(w[1], -1.2) || dw[0] != 0 {
+		t.Error(w, dw)
+	}
+	dw[0], dw[1] = .5, 2
+	Momentum(w, dw, vel, p)
+	//vel = .9*.5+.5 = .95
+	if !near(vel[0], .95) || !near(w[0], .95-.095) {
+		t.Error(w, vel)
+	}
+}
+
+func TestNesterov(t *testing.T) {
+	w, dw, vel := []float32{1}, []float32{1}, []float32{1}
+	p := MomentumParams{Rate: .1, Momentum: .5, DWalpha: 1}
+	if err := Nesterov(w, dw, vel, p); err != nil {
+		t.Fatal(err)
+	}
+	//vel = 1.5, w = 1 - .1*(1+.75)
+	if !near(vel[0], 1.5) || !near(w[0], .825) || dw[0] != 1 {
+		t.Error(w, vel, dw)
+	}
+}
+
+func TestRMSProp(t *testing.T) {
+	w, dw, ms := []float32{0}, []float32{2}, []float32{0}
+	p := RMSPropParams{Rate: .01, Decay: .9, Eps: 0, DWalpha: 1}
+	if err := RMSProp(w, dw, ms, p); err != nil {
+		t.Fatal(err)
+	}
+	//ms = .4, w = -.01*2/sqrt(.4)
+	if !near(ms[0], .4) || !near(w[0], float32(-.02/math.Sqrt(.4))) {
+		t.Error(w, ms)
+	}
+}
+
+func TestAdamW(t *testing.T) {
+	w, dw, m, v := []float32{2}, []float32{1}, []float32{0}, []float32{0}
+	p := AdamWParams{Rate: .1, Beta1: .9, Beta2: .999, Eps: 0, Decay: .5, DWalpha: 1}
+	if err := AdamW(w, dw, m, v, p, 0); err != nil {
+		t.Fatal(err)
+	}
+	//The first step of adam with bias correction moves by rate*sign(dw). Decay adds rate*decay*w.
+	if !near(w[0], 2-.1-.1) {
+		t.Error(w)
+	}
+	p.Decay = 0
+	w2, dw2, m2, v2 := []float32{2}, []float32{1}, []float32{0}, []float32{0}
+	AdamW(w2, dw2, m2, v2, p, 0)
+	if !near(w2[0], 1.9) {
+		t.Error(w2)
+	}
+	p.Beta1 = 1
+	if err := AdamW(w2, dw2, m2, v2, p, 0); err == nil {
+		t.Error("expected an error for beta1 of 1")
+	}
+}
+
+func TestLAMB(t *testing.T) {
+	w, dw := []float32{3, 4}, []float32{1, -1}
+	m, v := make([]float32, 2), make([]float32, 2)
+	p := LAMBParams{Rate: .1, Beta1: .9, Beta2: .999, Eps: 0, DWalpha: .5}
+	if err := LAMB(w, dw, m, v, p, 0); err != nil {
+		t.Fatal(err)
+	}
+	//r is (1,-1) so the trust ratio is 5/sqrt(2)
+	trust := float32(5 / math.Sqrt(2))
+	if !near(w[0], 3-.1*trust) || !near(w[1], 4+.1*trust) || dw[0] != .5 {
+		t.Error(w, dw)
+	}
+	w, dw = []float32{0, 0}, []float32{1, 1}
+	m, v = make([]float32, 2), make([]float32, 2)
+	LAMB(w, dw, m, v, p, 0)
+	if !near(w[0], -.1) {
+		t.Error("zero weights should have a trust ratio of 1", w)
+	}
+}
+
+func TestTrustRatio(t *testing.T) {
+	if r := TrustRatio(16, 4); r != 2 {
+		t.Error(r)
+	}
+	if r := TrustRatio(0, 4); r != 1 {
+		t.Error(r)
+	}
+	if r := TrustRatio(4, 0); r != 1 {
+		t.Error(r)
+	}
+}
+
+func TestLengths(t *testing.T) {
+	err := Momentum(make([]float32, 2), make([]float32, 1), make([]float32, 2), MomentumParams{})
+	if !errors.Is(err, ErrLength) {
+		t.Error(err)
+	}
+	err = LAMB(make([]float32, 2), make([]float32, 2), make([]float32, 2), nil, LAMBParams{Beta1: .9, Beta2: .9}, 0)
+	if !errors.Is(err, ErrLength) {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,20 @@
+//Package xtrahost holds pure go versions of the math done by the xtra kernels.
+//They are the references that the kernels are tested against, and they can be tested without a gpu.
+package xtrahost
+
+import (
+	"errors"
+	"fmt"
+)
+
+//ErrLength is returned when the slices passed to a function don't have the same length.
+var ErrLength = errors.New("xtrahost: slices have different lengths")
+
+func checklengths(op string, n int, s ...[]float32) error {
+	for i := range s {
+		if len(s[i]) != n {
+			return fmt.Errorf("%s: %w: %d != %d", op, ErrLength, len(s[i]), n)
+		}
+	}
+	return nil
+}
@@ -81,6 +81,7 @@ func ThreshBackward() Kernel {
 			const float *negcoefs,
 			float *dnegcoefs,
 			const float *threshhold,
+			float *dthreshhold,
 			const float *poscoefs,
 			float *dposcoefs)
 {
@@ -101,6 +102,7 @@ else
 dx[stride+xIdx]=  negcoefs[xIdx]*dy[stride+xIdx];
 dnegcoefs[xIdx]+=dy[xIdx]*x[stride+xIdx];
 }
+dthreshhold[xIdx]+=dy[xIdx];
 }
 }
 }`,
@@ -0,0 +1,90 @@
+package xtrakerns
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/negativeOne1/gocudnn/nvrtc"
+	"github.com/negativeOne1/gocudnn/nvrtc/nvrtcbuild"
+	"github.com/negativeOne1/gocudnn/nvrtc/nvrtccache"
+)
+
+//DefaultCacheBytes is the disk limit of the default compile cache.
+const DefaultCacheBytes = 256 << 20
+
+var (
+	cachemu  sync.Mutex
+	cache    *nvrtccache.Cache
+	cacheset bool
+)
+
+//SetCache sets the compile cache CreateModuleKernels uses. A nil cache turns caching off.
+//If it isn't set, a cache in nvrtccache.DefaultDir is made the first time it is needed, or an in memory one if that dir can't be used.
+func SetCache(c *nvrtccache.Cache) {
+	cachemu.Lock()
+	cache, cacheset = c, true
+	cachemu.Unlock()
+}
+
+func compilecache() *nvrtccache.Cache {
+	cachemu.Lock()
+	defer cachemu.Unlock()
+	if cacheset {
+		return cache
+	}
+	cacheset = true
+	if dir, err := nvrtccache.DefaultDir(); err == nil {
+		cache, err = nvrtccache.New(dir, Compiler(), nvrtccache.Options{MaxDiskBytes: DefaultCacheBytes})
+		if err == nil {
+			return cache
+		}
+	}
+	cache, _ = nvrtccache.New("", Compiler(), nvrtccache.Options{})
+	return cache
+}
+
+//Compiler returns the nvrtccache.Compiler that compiles to ptx with nvrtc.
+func Compiler() nvrtccache.Compiler {
+	return nvrtccache.CompilerFunc(compile)
+}
+
+func compile(r nvrtccache.Request) ([]byte, error) {
+	if r.Output != "ptx" {
+		return nil, errors.New("xtrakerns: nvrtc can only make ptx")
+	}
+	headers := make([]nvrtc.Include, len(r.Headers))
+	for i := range r.Headers {
+		headers[i] = nvrtc.Include{Source: r.Headers[i].Source, Name: r.Headers[i].Name}
+	}
+	p, err := nvrtc.CreateProgram(r.Source, r.Name, headers...)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range r.Names {
+		if err = p.AddNameExpression(name); err != nil {
+			return nil, err
+		}
+	}
+	if err = p.Compile(r.Options...); err != nil {
+		log, lerr := p.GetLog()
+		if lerr != nil {
+			return nil, err
+		}
+		return nil, nvrtcbuild.NewBuildError(err, log)
+	}
+	ptx, err := p.PTX()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(ptx), nil
+}
+
+//nvrtcversion is part of the cache key, so a new nvrtc doesn't use ptx from an old one.
+func nvrtcversion() (string, error) {
+	major, minor, err := nvrtc.Version()
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(major) + "." + strconv.Itoa(minor), nil
+}
@@ -0,0 +1,145 @@
+package xtrakerns
+
+//The global norm clip is done with SumSquares launched on every tensor to add up the sum of squares in sumsq[0], and then
+//ScaleByNorm launched on every tensor.  sumsq needs to be zeroed before the first SumSquares.
+
+//sumsquaresreduce adds sum of every thread in the block to sumsq[0]. The block size needs to be a multiple of 32.
+const sumsquaresreduce = `
+__shared__ float partial[32];
+for (int offset = warpSize / 2; offset > 0; offset /= 2)
+{
+sum += __shfl_down_sync(0xffffffff, sum, offset);
+}
+const int lane = threadIdx.x % warpSize;
+const int warp = threadIdx.x / warpSize;
+if (lane == 0)
+{
+partial[warp] = sum;
+}
+__syncthreads();
+if (warp == 0)
+{
+sum = (threadIdx.x < (blockDim.x + warpSize - 1) / warpSize) ? partial[lane] : 0.0f;
+for (int offset = warpSize / 2; offset > 0; offset /= 2)
+{
+sum += __shfl_down_sync(0xffffffff, sum, offset);
+}
+if (lane == 0)
+{
+atomicAdd(sumsq, sum);
+}
+}
+`
+
+//SumSquares adds the sum of the squares of x to sumsq[0]
+func SumSquares() Kernel {
+	return Kernel{
+		Name: `SumSquares`,
+		Code: `extern "C" __global__ void SumSquares(const int n,
+			const float *x,
+			float *sumsq)
+{
+float sum = 0.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+sum += x[i] * x[i];
+}` + sumsquaresreduce + `}`,
+	}
+}
+
+//SumSquaresFP16 adds the sum of the squares of x to sumsq[0]. sumsq is float.
+func SumSquaresFP16() Kernel {
+	return Kernel{
+		Name: `SumSquaresFP16`,
+		Code: `extern "C" __global__ void SumSquaresFP16(const int n,
+			const __half *x,
+			float *sumsq)
+{
+float sum = 0.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+const float xi = __half2float(x[i]);
+sum += xi * xi;
+}` + sumsquaresreduce + `}`,
+	}
+}
+
+//ScaleByNorm scales x by maxnorm/(norm+1e-6) if the norm, sqrt(sumsq[0]), is more than maxnorm. A NaN norm leaves x alone.
+func ScaleByNorm() Kernel {
+	return Kernel{
+		Name: `ScaleByNorm`,
+		Code: `extern "C" __global__ void ScaleByNorm(const int n,
+			float *x,
+			const float *sumsq,
+			const float maxnorm)
+{
+const float norm = sqrtf(sumsq[0]);
+if (!(norm > maxnorm))
+{
+return;
+}
+const float scale = maxnorm / (norm + 1e-6f);
+CUDA_GRID_LOOP_X(i, n)
+{
+x[i] = x[i] * scale;
+}
+}`,
+	}
+}
+
+//ScaleByNormFP16 scales x by maxnorm/(norm+1e-6) if the norm, sqrt(sumsq[0]), is more than maxnorm. sumsq is float.
+func ScaleByNormFP16() Kernel {
+	return Kernel{
+		Name: `ScaleByNormFP16`,
+		Code: `extern "C" __global__ void ScaleByNormFP16(const int n,
+			__half *x,
+			const float *sumsq,
+			const float maxnorm)
+{
+const float norm = sqrtf(sumsq[0]);
+if (!(norm > maxnorm))
+{
+return;
+}
+const float scale = maxnorm / (norm + 1e-6f);
+CUDA_GRID_LOOP_X(i, n)
+{
+x[i] = __float2half(__half2float(x[i]) * scale);
+}
+}`,
+	}
+}
+
+//ClipByValue clips x to [min,max]
+func ClipByValue() Kernel {
+	return Kernel{
+		Name: `ClipByValue`,
+		Code: `extern "C" __global__ void ClipByValue(const int n,
+			float *x,
+			const float min,
+			const float max)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+x[i] = fminf(fmaxf(x[i], min), max);
+}
+}`,
+	}
+}
+
+//ClipByValueFP16 clips x to [min,max]
+func ClipByValueFP16() Kernel {
+	return Kernel{
+		Name: `ClipByValueFP16`,
+		Code: `extern "C" __global__ void ClipByValueFP16(const int n,
+			__half *x,
+			const float min,
+			const float max)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+x[i] = __float2half(fminf(fmaxf(__half2float(x[i]), min), max));
+}
+}`,
+	}
+}
@@ -0,0 +1,71 @@
+package xtrakerns
+
+//EMAUpdate moves shadow toward w. shadow = decay*shadow + (1-decay)*w
+func EMAUpdate() Kernel {
+	return Kernel{
+		Name: `EMAUpdate`,
+		Code: `extern "C" __global__ void EMAUpdate(const int n,
+			const float *w,
+			float *shadow,
+			const float decay)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+shadow[i] = decay * shadow[i] + (1.0f - decay) * w[i];
+}
+}`,
+	}
+}
+
+//EMAUpdateFP16 moves shadow toward w. shadow = decay*shadow + (1-decay)*w. The math is done in float.
+func EMAUpdateFP16() Kernel {
+	return Kernel{
+		Name: `EMAUpdateFP16`,
+		Code: `extern "C" __global__ void EMAUpdateFP16(const int n,
+			const __half *w,
+			__half *shadow,
+			const float decay)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+shadow[i] = __float2half(decay * __half2float(shadow[i]) + (1.0f - decay) * __half2float(w[i]));
+}
+}`,
+	}
+}
+
+//SwapValues swaps the values of x and y
+func SwapValues() Kernel {
+	return Kernel{
+		Name: `SwapValues`,
+		Code: `extern "C" __global__ void SwapValues(const int n,
+			float *x,
+			float *y)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const float t = x[i];
+x[i] = y[i];
+y[i] = t;
+}
+}`,
+	}
+}
+
+//SwapValuesFP16 swaps the values of x and y
+func SwapValuesFP16() Kernel {
+	return Kernel{
+		Name: `SwapValuesFP16`,
+		Code: `extern "C" __global__ void SwapValuesFP16(const int n,
+			__half *x,
+			__half *y)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const __half t = x[i];
+x[i] = y[i];
+y[i] = t;
+}
+}`,
+	}
+}
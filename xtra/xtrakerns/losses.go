@@ -0,0 +1,320 @@
+package xtrakerns
+
+import "strings"
+
+//The losses below each have a float and a half kernel made from the same code.  The half kernels load and store half,
+//but the math and the loss are float.  Each kernel adds the sum of its loss to loss[0], which needs to be zeroed first,
+//and writes dx = alpha*grad + beta*dx.  The block size needs to be a multiple of 32.
+//The math is the same as in xtrahost.
+
+//losshelpers are guarded so they can be in a module more than once.
+const losshelpers = `
+#ifndef XTRA_LOSS_HELPERS
+#define XTRA_LOSS_HELPERS
+__device__ __forceinline__ float ldf(const float x) { return x; }
+__device__ __forceinline__ float ldf(const __half x) { return __half2float(x); }
+__device__ __forceinline__ void stf(float *p, const float v) { *p = v; }
+__device__ __forceinline__ void stf(__half *p, const float v) { *p = __float2half(v); }
+template <typename T>
+__device__ __forceinline__ void stgrad(T *dx, const float g, const float alpha, const float beta)
+{
+stf(dx, beta == 0.0f ? alpha * g : (alpha * g) + (beta * ldf(*dx)));
+}
+__device__ __forceinline__ float sigmoidf(const float x) { return 1.0f / (1.0f + expf(-x)); }
+__device__ __forceinline__ float bcelogitsf(const float x, const float t)
+{
+return fmaxf(x, 0.0f) - (x * t) + log1pf(expf(-fabsf(x)));
+}
+//blockaddloss adds sum from every thread in the block to loss[0]
+__device__ __forceinline__ void blockaddloss(float sum, float *loss)
+{
+__shared__ float partial[32];
+for (int offset = warpSize / 2; offset > 0; offset /= 2)
+{
+sum += __shfl_down_sync(0xffffffff, sum, offset);
+}
+const int lane = threadIdx.x % warpSize;
+const int warp = threadIdx.x / warpSize;
+if (lane == 0)
+{
+partial[warp] = sum;
+}
+__syncthreads();
+if (warp == 0)
+{
+sum = (threadIdx.x < (blockDim.x + warpSize - 1) / warpSize) ? partial[lane] : 0.0f;
+for (int offset = warpSize / 2; offset > 0; offset /= 2)
+{
+sum += __shfl_down_sync(0xffffffff, sum, offset);
+}
+if (lane == 0)
+{
+atomicAdd(loss, sum);
+}
+}
+}
+//logsumexpf returns log(sum(exp(x))) over the classes of a position
+template <typename T>
+__device__ __forceinline__ float logsumexpf(const T *x, const int base, const int classes, const int inner)
+{
+float mx = ldf(x[base]);
+for (int c = 1; c < classes; c++)
+{
+mx = fmaxf(mx, ldf(x[base + (c * inner)]));
+}
+float se = 0.0f;
+for (int c = 0; c < classes; c++)
+{
+se += expf(ldf(x[base + (c * inner)]) - mx);
+}
+return mx + logf(se);
+}
+#endif
+`
+
+//losskernel makes the float or the half kernel from code.  TYPE in code is replaced with float or __half.
+func losskernel(name, code string, fp16 bool) Kernel {
+	typ := "float"
+	if fp16 {
+		name += "FP16"
+		typ = "__half"
+	}
+	return Kernel{
+		Name: name,
+		Code: losshelpers + strings.NewReplacer("NAME", name, "TYPE", typ).Replace(code),
+	}
+}
+
+//softmaxcecode is shared by the dense and sparse cross entropy. TARGET sets tc for class c at pos, and SKIP is true if pos is ignored.
+const softmaxcecode = `extern "C" __global__ void NAME(const int npos,
+			const int classes,
+			const int inner,
+			const TYPE *x,
+			const TARGETTYPE *t,
+			TYPE *dx,
+			const float *weights,
+			const int hasweights,
+			const float smoothing,
+			const float alpha,
+			const float beta,
+			float *loss)
+{
+float sum = 0.0f;
+CUDA_GRID_LOOP_X(pos, npos)
+{
+const int base = ((pos / inner) * classes * inner) + (pos % inner);
+if (SKIP)
+{
+for (int c = 0; c < classes; c++)
+{
+stgrad(&dx[base + (c * inner)], 0.0f, alpha, beta);
+}
+continue;
+}
+const float lse = logsumexpf(x, base, classes, inner);
+float s = 0.0f;
+for (int c = 0; c < classes; c++)
+{
+const int i = base + (c * inner);
+const float tc = TARGET;
+const float wt = (hasweights ? weights[c] : 1.0f) * tc;
+s += wt;
+sum += wt * (lse - ldf(x[i]));
+}
+for (int c = 0; c < classes; c++)
+{
+const int i = base + (c * inner);
+const float tc = TARGET;
+const float p = expf(ldf(x[i]) - lse);
+stgrad(&dx[i], (p * s) - ((hasweights ? weights[c] : 1.0f) * tc), alpha, beta);
+}
+}
+blockaddloss(sum, loss);
+}`
+
+//SoftmaxCrossEntropyLoss is the cross entropy of softmax(x) along the class dim against the target probabilities t,
+//with label smoothing and optional class weights.
+func SoftmaxCrossEntropyLoss() Kernel {
+	return losskernel("SoftmaxCrossEntropyLoss", densece, false)
+}
+
+//SoftmaxCrossEntropyLossFP16 is SoftmaxCrossEntropyLoss for half
+func SoftmaxCrossEntropyLossFP16() Kernel {
+	return losskernel("SoftmaxCrossEntropyLoss", densece, true)
+}
+
+var densece = strings.NewReplacer(
+	"TARGETTYPE", "TYPE",
+	"SKIP", "false",
+	"TARGET", "((1.0f - smoothing) * ldf(t[i])) + (smoothing / classes)",
+).Replace(softmaxcecode)
+
+//SparseSoftmaxCrossEntropyLoss is the cross entropy of softmax(x) along the class dim against an int32 label per position,
+//with label smoothing and optional class weights.  Labels that are negative or not less than classes are ignored.
+func SparseSoftmaxCrossEntropyLoss() Kernel {
+	return losskernel("SparseSoftmaxCrossEntropyLoss", sparsece, false)
+}
+
+//SparseSoftmaxCrossEntropyLossFP16 is SparseSoftmaxCrossEntropyLoss for half. The labels are still int32.
+func SparseSoftmaxCrossEntropyLossFP16() Kernel {
+	return losskernel("SparseSoftmaxCrossEntropyLoss", sparsece, true)
+}
+
+var sparsece = strings.NewReplacer(
+	"TARGETTYPE", "int",
+	"SKIP", "t[pos] < 0 || t[pos] >= classes",
+	"TARGET", "(c == t[pos] ? 1.0f - smoothing : 0.0f) + (smoothing / classes)",
+).Replace(softmaxcecode)
+
+const kldivcode = `extern "C" __global__ void NAME(const int npos,
+			const int classes,
+			const int inner,
+			const TYPE *x,
+			const TYPE *t,
+			TYPE *dx,
+			const float alpha,
+			const float beta,
+			float *loss)
+{
+float sum = 0.0f;
+CUDA_GRID_LOOP_X(pos, npos)
+{
+const int base = ((pos / inner) * classes * inner) + (pos % inner);
+const float lse = logsumexpf(x, base, classes, inner);
+float s = 0.0f;
+for (int c = 0; c < classes; c++)
+{
+const int i = base + (c * inner);
+const float tc = ldf(t[i]);
+s += tc;
+if (tc > 0.0f)
+{
+sum += tc * (logf(tc) - (ldf(x[i]) - lse));
+}
+}
+for (int c = 0; c < classes; c++)
+{
+const int i = base + (c * inner);
+stgrad(&dx[i], (expf(ldf(x[i]) - lse) * s) - ldf(t[i]), alpha, beta);
+}
+}
+blockaddloss(sum, loss);
+}`
+
+//KLDivLoss is the kl divergence of softmax(x) along the class dim from the target probabilities t.
+func KLDivLoss() Kernel {
+	return losskernel("KLDivLoss", kldivcode, false)
+}
+
+//KLDivLossFP16 is KLDivLoss for half
+func KLDivLossFP16() Kernel {
+	return losskernel("KLDivLoss", kldivcode, true)
+}
+
+const bcecode = `extern "C" __global__ void NAME(const int n,
+			const TYPE *x,
+			const TYPE *t,
+			TYPE *dx,
+			const float alpha,
+			const float beta,
+			float *loss)
+{
+float sum = 0.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+const float xi = ldf(x[i]);
+const float ti = ldf(t[i]);
+sum += bcelogitsf(xi, ti);
+stgrad(&dx[i], sigmoidf(xi) - ti, alpha, beta);
+}
+blockaddloss(sum, loss);
+}`
+
+//BCEWithLogitsLoss is the binary cross entropy of sigmoid(x) against t.
+func BCEWithLogitsLoss() Kernel {
+	return losskernel("BCEWithLogitsLoss", bcecode, false)
+}
+
+//BCEWithLogitsLossFP16 is BCEWithLogitsLoss for half
+func BCEWithLogitsLossFP16() Kernel {
+	return losskernel("BCEWithLogitsLoss", bcecode, true)
+}
+
+const hubercode = `extern "C" __global__ void NAME(const int n,
+			const TYPE *x,
+			const TYPE *t,
+			TYPE *dx,
+			const float delta,
+			const float scale,
+			const float alpha,
+			const float beta,
+			float *loss)
+{
+float sum = 0.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+const float r = ldf(x[i]) - ldf(t[i]);
+if (fabsf(r) <= delta)
+{
+sum += scale * 0.5f * r * r;
+stgrad(&dx[i], scale * r, alpha, beta);
+}
+else
+{
+sum += scale * delta * (fabsf(r) - (0.5f * delta));
+stgrad(&dx[i], scale * copysignf(delta, r), alpha, beta);
+}
+}
+blockaddloss(sum, loss);
+}`
+
+//HuberLoss is the huber loss of x-t times scale.  Smooth l1 is delta = beta and scale = 1/beta.
+func HuberLoss() Kernel {
+	return losskernel("HuberLoss", hubercode, false)
+}
+
+//HuberLossFP16 is HuberLoss for half
+func HuberLossFP16() Kernel {
+	return losskernel("HuberLoss", hubercode, true)
+}
+
+const focalcode = `extern "C" __global__ void NAME(const int n,
+			const TYPE *x,
+			const TYPE *t,
+			TYPE *dx,
+			const float falpha,
+			const float gamma,
+			const float alpha,
+			const float beta,
+			float *loss)
+{
+float sum = 0.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+const float xi = ldf(x[i]);
+const float ti = ldf(t[i]);
+const float p = sigmoidf(xi);
+const float ce = bcelogitsf(xi, ti);
+const float m = 1.0f - ((p * ti) + ((1.0f - p) * (1.0f - ti)));
+const float a = falpha >= 0.0f ? (falpha * ti) + ((1.0f - falpha) * (1.0f - ti)) : 1.0f;
+const float mg = powf(m, gamma);
+float g = mg * (p - ti);
+if (m > 0.0f && gamma != 0.0f)
+{
+g -= gamma * powf(m, gamma - 1.0f) * ((2.0f * ti) - 1.0f) * p * (1.0f - p) * ce;
+}
+sum += a * mg * ce;
+stgrad(&dx[i], a * g, alpha, beta);
+}
+blockaddloss(sum, loss);
+}`
+
+//FocalLoss is the sigmoid focal loss of x against t.  A negative falpha turns off the alpha weighting.
+func FocalLoss() Kernel {
+	return losskernel("FocalLoss", focalcode, false)
+}
+
+//FocalLossFP16 is FocalLoss for half
+func FocalLossFP16() Kernel {
+	return losskernel("FocalLoss", focalcode, true)
+}
@@ -0,0 +1,77 @@
+package xtrakerns
+
+//LossScale multiplies the loss gradient by scale
+func LossScale() Kernel {
+	return Kernel{
+		Name: `LossScale`,
+		Code: `extern "C" __global__ void LossScale(const int n,
+			float *x,
+			const float scale)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+x[i] = x[i] * scale;
+}
+}`,
+	}
+}
+
+//LossScaleFP16 multiplies the loss gradient by scale. The math is done in float.
+func LossScaleFP16() Kernel {
+	return Kernel{
+		Name: `LossScaleFP16`,
+		Code: `extern "C" __global__ void LossScaleFP16(const int n,
+			__half *x,
+			const float scale)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+x[i] = __float2half(__half2float(x[i]) * scale);
+}
+}`,
+	}
+}
+
+//UnscaleCheck multiplies x by unscale and sets found[0] to 1 if any value is Inf or NaN. found needs to be zeroed before the first launch.
+func UnscaleCheck() Kernel {
+	return Kernel{
+		Name: `UnscaleCheck`,
+		Code: `extern "C" __global__ void UnscaleCheck(const int n,
+			float *x,
+			const float unscale,
+			int *found)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const float v = x[i] * unscale;
+if (!isfinite(v))
+{
+found[0] = 1;
+}
+x[i] = v;
+}
+}`,
+	}
+}
+
+//UnscaleCheckFP16 multiplies x by unscale and sets found[0] to 1 if any value is Inf or NaN. The math is done in float.
+func UnscaleCheckFP16() Kernel {
+	return Kernel{
+		Name: `UnscaleCheckFP16`,
+		Code: `extern "C" __global__ void UnscaleCheckFP16(const int n,
+			__half *x,
+			const float unscale,
+			int *found)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const float v = __half2float(x[i]) * unscale;
+if (!isfinite(v))
+{
+found[0] = 1;
+}
+x[i] = __float2half(v);
+}
+}`,
+	}
+}
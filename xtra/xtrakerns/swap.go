@@ -1,12 +1,13 @@
 package xtrakerns
 
 import (
-    //"errors"
-    "fmt"
-    "github.com/negativeOne1/gocudnn/cuda"
-    "github.com/negativeOne1/gocudnn/nvrtc"
-    "strconv"
-    "strings"
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/negativeOne1/gocudnn/cuda"
+	"github.com/negativeOne1/gocudnn/nvrtc/nvrtcbuild"
+	"github.com/negativeOne1/gocudnn/nvrtc/nvrtccache"
 )
 
 //Kernel is used to build kernels
@@ -19,49 +20,68 @@ func (k *Kernel) cuname() string {
 	return strings.ToLower(k.Name) + ".cu"
 }
 
-func CreateModule(k Kernel, dev cuda.Device) (*cuda.Module, error) {
-	p, err := nvrtc.CreateProgram(Defines+k.Code, k.cuname())
-	if err != nil {
-		return nil, err
+//Device is what CreateModule needs from a device. cuda.Device and cudart.Device both satisfy it.
+type Device interface {
+	Major() (int, error)
+	Minor() (int, error)
+}
+
+//CreateModule compiles k with nvrtc for the compute capability of dev and loads it into the current context.
+func CreateModule(k Kernel, dev Device) (*cuda.Module, error) {
+	return CreateModuleKernels(dev, k)
+}
+
+//CreateModuleKernels compiles kerns into a single module for the compute capability of dev and loads it into the current context.
+//The Headers and Defines are put in front of the code. The ptx is kept in the compile cache (see SetCache) so the next run doesn't compile it again.
+func CreateModuleKernels(dev Device, kerns ...Kernel) (*cuda.Module, error) {
+	if len(kerns) == 0 {
+		return nil, errors.New("CreateModuleKernels: no kernels")
 	}
 	major, err := dev.Major()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 	minor, err := dev.Minor()
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-    majstr := strconv.Itoa(major)
-    minstr := strconv.Itoa(minor)
-    computecapability := majstr + minstr
-	fmt.Println(k.Name, k.cuname(), computecapability)
-	err = p.AddNameExpression(k.Name)
+	var code strings.Builder
+	code.WriteString(Headers)
+	code.WriteString(Defines)
+	names := make([]string, len(kerns))
+	for i, k := range kerns {
+		code.WriteString(k.Code)
+		code.WriteString("\n")
+		names[i] = k.Name
+	}
+	version, err := nvrtcversion()
 	if err != nil {
-		panic(err)
-		//return nil, err
+		return nil, err
 	}
-
-    archflag := "--gpu-architecture=compute_" + computecapability
-    err = p.Compile(archflag, "-I/opt/cuda/include", "-I/opt/cuda/targets/x86_64-linux/include")
+	opts := nvrtcbuild.NewOptions().Arch(major, minor, false).Include("/opt/cuda/include").Include("/opt/cuda/targets/x86_64-linux/include")
+	args, err := opts.Args()
 	if err != nil {
-		logerr, err2 := p.GetLog()
-		if err2 != nil {
-			fmt.Println("LOG:", logerr)
-			fmt.Println("Error in the log:", err2.Error())
-		}
-		fmt.Println("LOG:", logerr, err)
-		panic(err)
-		//err = errors.New(logerr + " : " + err.Error())
-		///	panic(err)
+		return nil, err
+	}
+	r := nvrtccache.Request{
+		Name:    kerns[0].cuname(),
+		Source:  code.String(),
+		Names:   names,
+		Options: args,
+		Arch:    opts.Target(),
+		Version: version,
+		Output:  "ptx",
+	}
+	var ptx []byte
+	if c := compilecache(); c != nil {
+		ptx, err = c.Get(r)
+	} else {
+		ptx, err = compile(r)
 	}
-	p.GetLoweredName(MSELoss().Name)
-	ptx, err := p.PTX()
 	if err != nil {
-
-		panic(err)
+		return nil, err
 	}
-	return cuda.NewModuleData(ptx)
+	return cuda.NewModuleData(bytes.NewReader(ptx))
 }
 
 //SwapEveryOther will swap the batches between 2 tensors.
@@ -0,0 +1,65 @@
+package xtrakerns
+
+//AdamW is adam with decoupled weight decay.
+func AdamW() Kernel {
+	return Kernel{
+		Name: `AdamW`,
+		Code: `extern "C" __global__ void AdamW(const int n,
+			float *w,
+			float *gsum,
+			float *xsum,
+			float *dw,
+			const float rate,
+			const float beta1,
+			const float beta2,
+			const float eps,
+			const float decay,
+			const float denombeta1,
+			const float denombeta2,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+gsum[i] = (beta1 * gsum[i]) + ((1.0f - beta1) * dw[i]);
+xsum[i] = (beta2 * xsum[i]) + ((1.0f - beta2) * (dw[i] * dw[i]));
+const float gsumt = gsum[i] / denombeta1;
+const float xsumt = xsum[i] / denombeta2;
+w[i] -= rate * ((gsumt / (sqrtf(xsumt) + eps)) + (decay * w[i]));
+dw[i] = dw[i] * dwalpha; //smoothing factor
+}
+}`,
+	}
+}
+
+//AdamWFP16 is adam with decoupled weight decay.  The math is done in float.
+func AdamWFP16() Kernel {
+	return Kernel{
+		Name: `AdamWFP16`,
+		Code: `extern "C" __global__ void AdamWFP16(const int n,
+			__half *w,
+			__half *gsum,
+			__half *xsum,
+			__half *dw,
+			const float rate,
+			const float beta1,
+			const float beta2,
+			const float eps,
+			const float decay,
+			const float denombeta1,
+			const float denombeta2,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const float g = __half2float(dw[i]);
+const float m = (beta1 * __half2float(gsum[i])) + ((1.0f - beta1) * g);
+const float v = (beta2 * __half2float(xsum[i])) + ((1.0f - beta2) * (g * g));
+gsum[i] = __float2half(m);
+xsum[i] = __float2half(v);
+const float wt = __half2float(w[i]);
+w[i] = __float2half(wt - (rate * (((m / denombeta1) / (sqrtf(v / denombeta2) + eps)) + (decay * wt))));
+dw[i] = __float2half(g * dwalpha);
+}
+}`,
+	}
+}
@@ -0,0 +1,135 @@
+package xtrakerns
+
+//LAMB is done in two launches.  LAMBMoments updates the moments and adds the sum of squares of the weights and of the update
+//to norms[0] and norms[1].  norms needs to be zeroed before it is launched.  LAMBUpdate then scales the update by the trust ratio.
+
+//LAMBMoments is the first pass of LAMB.
+func LAMBMoments() Kernel {
+	return Kernel{
+		Name: `LAMBMoments`,
+		Code: `extern "C" __global__ void LAMBMoments(const int n,
+			const float *w,
+			float *gsum,
+			float *xsum,
+			const float *dw,
+			const float beta1,
+			const float beta2,
+			const float eps,
+			const float decay,
+			const float denombeta1,
+			const float denombeta2,
+			float *norms)
+{
+float wsq = 0.0f;
+float rsq = 0.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+gsum[i] = (beta1 * gsum[i]) + ((1.0f - beta1) * dw[i]);
+xsum[i] = (beta2 * xsum[i]) + ((1.0f - beta2) * (dw[i] * dw[i]));
+const float r = ((gsum[i] / denombeta1) / (sqrtf(xsum[i] / denombeta2) + eps)) + (decay * w[i]);
+wsq += w[i] * w[i];
+rsq += r * r;
+}
+atomicAdd(&norms[0], wsq);
+atomicAdd(&norms[1], rsq);
+}`,
+	}
+}
+
+//LAMBMomentsFP16 is the first pass of LAMB.  The math is done in float and norms is float.
+func LAMBMomentsFP16() Kernel {
+	return Kernel{
+		Name: `LAMBMomentsFP16`,
+		Code: `extern "C" __global__ void LAMBMomentsFP16(const int n,
+			const __half *w,
+			__half *gsum,
+			__half *xsum,
+			const __half *dw,
+			const float beta1,
+			const float beta2,
+			const float eps,
+			const float decay,
+			const float denombeta1,
+			const float denombeta2,
+			float *norms)
+{
+float wsq = 0.0f;
+float rsq = 0.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+const float g = __half2float(dw[i]);
+const float m = (beta1 * __half2float(gsum[i])) + ((1.0f - beta1) * g);
+const float v = (beta2 * __half2float(xsum[i])) + ((1.0f - beta2) * (g * g));
+gsum[i] = __float2half(m);
+xsum[i] = __float2half(v);
+const float wt = __half2float(w[i]);
+const float r = ((__half2float(gsum[i]) / denombeta1) / (sqrtf(__half2float(xsum[i]) / denombeta2) + eps)) + (decay * wt);
+wsq += wt * wt;
+rsq += r * r;
+}
+atomicAdd(&norms[0], wsq);
+atomicAdd(&norms[1], rsq);
+}`,
+	}
+}
+
+//LAMBUpdate is the second pass of LAMB.
+func LAMBUpdate() Kernel {
+	return Kernel{
+		Name: `LAMBUpdate`,
+		Code: `extern "C" __global__ void LAMBUpdate(const int n,
+			float *w,
+			const float *gsum,
+			const float *xsum,
+			float *dw,
+			const float rate,
+			const float eps,
+			const float decay,
+			const float denombeta1,
+			const float denombeta2,
+			const float dwalpha,
+			const float *norms)
+{
+const float wnorm = sqrtf(norms[0]);
+const float rnorm = sqrtf(norms[1]);
+const float trust = (wnorm > 0.0f && rnorm > 0.0f) ? wnorm / rnorm : 1.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+const float r = ((gsum[i] / denombeta1) / (sqrtf(xsum[i] / denombeta2) + eps)) + (decay * w[i]);
+w[i] -= rate * trust * r;
+dw[i] = dw[i] * dwalpha; //smoothing factor
+}
+}`,
+	}
+}
+
+//LAMBUpdateFP16 is the second pass of LAMB.  The math is done in float.
+func LAMBUpdateFP16() Kernel {
+	return Kernel{
+		Name: `LAMBUpdateFP16`,
+		Code: `extern "C" __global__ void LAMBUpdateFP16(const int n,
+			__half *w,
+			const __half *gsum,
+			const __half *xsum,
+			__half *dw,
+			const float rate,
+			const float eps,
+			const float decay,
+			const float denombeta1,
+			const float denombeta2,
+			const float dwalpha,
+			const float *norms)
+{
+const float wnorm = sqrtf(norms[0]);
+const float rnorm = sqrtf(norms[1]);
+const float trust = (wnorm > 0.0f && rnorm > 0.0f) ? wnorm / rnorm : 1.0f;
+CUDA_GRID_LOOP_X(i, n)
+{
+const float wt = __half2float(w[i]);
+const float r = ((__half2float(gsum[i]) / denombeta1) / (sqrtf(__half2float(xsum[i]) / denombeta2) + eps)) + (decay * wt);
+w[i] = __float2half(wt - (rate * trust * r));
+dw[i] = __float2half(__half2float(dw[i]) * dwalpha);
+}
+}`,
+	}
+}
@@ -0,0 +1,93 @@
+package xtrakerns
+
+//Momentum is sgd with momentum. vel is the velocity.
+func Momentum() Kernel {
+	return Kernel{
+		Name: `Momentum`,
+		Code: `extern "C" __global__ void Momentum(const int n,
+			float *w,
+			float *dw,
+			float *vel,
+			const float rate,
+			const float momentum,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+vel[i] = (momentum * vel[i]) + dw[i];
+w[i] -= rate * vel[i];
+dw[i] = dw[i] * dwalpha; //smoothing factor
+}
+}`,
+	}
+}
+
+//MomentumFP16 is sgd with momentum.  The math is done in float.
+func MomentumFP16() Kernel {
+	return Kernel{
+		Name: `MomentumFP16`,
+		Code: `extern "C" __global__ void MomentumFP16(const int n,
+			__half *w,
+			__half *dw,
+			__half *vel,
+			const float rate,
+			const float momentum,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const float g = __half2float(dw[i]);
+const float v = (momentum * __half2float(vel[i])) + g;
+vel[i] = __float2half(v);
+w[i] = __float2half(__half2float(w[i]) - (rate * v));
+dw[i] = __float2half(g * dwalpha);
+}
+}`,
+	}
+}
+
+//Nesterov is sgd with nesterov momentum. vel is the velocity.
+func Nesterov() Kernel {
+	return Kernel{
+		Name: `Nesterov`,
+		Code: `extern "C" __global__ void Nesterov(const int n,
+			float *w,
+			float *dw,
+			float *vel,
+			const float rate,
+			const float momentum,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+vel[i] = (momentum * vel[i]) + dw[i];
+w[i] -= rate * (dw[i] + (momentum * vel[i]));
+dw[i] = dw[i] * dwalpha; //smoothing factor
+}
+}`,
+	}
+}
+
+//NesterovFP16 is sgd with nesterov momentum.  The math is done in float.
+func NesterovFP16() Kernel {
+	return Kernel{
+		Name: `NesterovFP16`,
+		Code: `extern "C" __global__ void NesterovFP16(const int n,
+			__half *w,
+			__half *dw,
+			__half *vel,
+			const float rate,
+			const float momentum,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const float g = __half2float(dw[i]);
+const float v = (momentum * __half2float(vel[i])) + g;
+vel[i] = __float2half(v);
+w[i] = __float2half(__half2float(w[i]) - (rate * (g + (momentum * v))));
+dw[i] = __float2half(g * dwalpha);
+}
+}`,
+	}
+}
@@ -0,0 +1,49 @@
+package xtrakerns
+
+//RMSProp is the rmsprop weight updater. ms is the running mean of the square of dw.
+func RMSProp() Kernel {
+	return Kernel{
+		Name: `RMSProp`,
+		Code: `extern "C" __global__ void RMSProp(const int n,
+			float *w,
+			float *dw,
+			float *ms,
+			const float rate,
+			const float decay,
+			const float eps,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+ms[i] = (decay * ms[i]) + ((1.0f - decay) * dw[i] * dw[i]);
+w[i] -= (rate * dw[i]) / (sqrtf(ms[i]) + eps);
+dw[i] = dw[i] * dwalpha; //smoothing factor
+}
+}`,
+	}
+}
+
+//RMSPropFP16 is the rmsprop weight updater. The math is done in float.
+func RMSPropFP16() Kernel {
+	return Kernel{
+		Name: `RMSPropFP16`,
+		Code: `extern "C" __global__ void RMSPropFP16(const int n,
+			__half *w,
+			__half *dw,
+			__half *ms,
+			const float rate,
+			const float decay,
+			const float eps,
+			const float dwalpha)
+{
+CUDA_GRID_LOOP_X(i, n)
+{
+const float g = __half2float(dw[i]);
+const float s = (decay * __half2float(ms[i])) + ((1.0f - decay) * g * g);
+ms[i] = __float2half(s);
+w[i] = __float2half(__half2float(w[i]) - ((rate * g) / (sqrtf(s) + eps)));
+dw[i] = __float2half(g * dwalpha);
+}
+}`,
+	}
+}
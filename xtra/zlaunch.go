@@ -0,0 +1,901 @@
+// Code generated by launchgen. DO NOT EDIT.
+//Sources: ../kernels/gocudnnxtra.cu ../kernels/gocudnnxtrafp16.cu xtrakerns/*.go
+
+package xtra
+
+import (
+	"github.com/dereklstinson/cutil"
+	"github.com/dereklstinson/half"
+	"github.com/negativeOne1/gocudnn/cuda"
+	"github.com/negativeOne1/gocudnn/gocu"
+)
+
+// TransposeKernel launches the Transpose kernel with typed args.
+type TransposeKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches Transpose with a 1d config.
+func (k TransposeKernel) Launch(cfg Config, s gocu.Streamer, numthreads int32, src, buf cutil.Mem, ndims int32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, numthreads, src, buf, ndims, dest)
+}
+
+// SwapEveryOtherKernel launches the SwapEveryOther kernel with typed args.
+type SwapEveryOtherKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SwapEveryOther with a 1d config.
+func (k SwapEveryOtherKernel) Launch(cfg Config, s gocu.Streamer, xThreads, totalbatches int32, t1, t2 cutil.Mem, start, stride int32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xThreads, totalbatches, t1, t2, start, stride)
+}
+
+// SwapUpperLowerKernel launches the SwapUpperLower kernel with typed args.
+type SwapUpperLowerKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SwapUpperLower with a 1d config.
+func (k SwapUpperLowerKernel) Launch(cfg Config, s gocu.Streamer, xThreads, yThreads int32, t1, t2 cutil.Mem, t1upper, t2upper, inverse int32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xThreads, yThreads, t1, t2, t1upper, t2upper, inverse)
+}
+
+// ShapetoBatch4DNHWCKernel launches the ShapetoBatch4DNHWC kernel with typed args.
+type ShapetoBatch4DNHWCKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ShapetoBatch4DNHWC with a 1d config.
+func (k ShapetoBatch4DNHWCKernel) Launch(cfg Config, s gocu.Streamer, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride int32, shape, batch cutil.Mem, h_over_scan, w_over_scan int32, S2B bool) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride, shape, batch, h_over_scan, w_over_scan, S2B)
+}
+
+// ShapetoBatch4DNCHWKernel launches the ShapetoBatch4DNCHW kernel with typed args.
+type ShapetoBatch4DNCHWKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ShapetoBatch4DNCHW with a 1d config.
+func (k ShapetoBatch4DNCHWKernel) Launch(cfg Config, s gocu.Streamer, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride int32, shape, batch cutil.Mem, h_over_scan, w_over_scan int32, S2B bool) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride, shape, batch, h_over_scan, w_over_scan, S2B)
+}
+
+// NearestNeighborNHWCKernel launches the NearestNeighborNHWC kernel with typed args.
+type NearestNeighborNHWCKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNHWC with a 1d config.
+func (k NearestNeighborNHWCKernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// NearestNeighborNCHWKernel launches the NearestNeighborNCHW kernel with typed args.
+type NearestNeighborNCHWKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNCHW with a 1d config.
+func (k NearestNeighborNCHWKernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// NearestNeighborNCHWBackKernel launches the NearestNeighborNCHWBack kernel with typed args.
+type NearestNeighborNCHWBackKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNCHWBack with a 1d config.
+func (k NearestNeighborNCHWBackKernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// NearestNeighborNHWCBackKernel launches the NearestNeighborNHWCBack kernel with typed args.
+type NearestNeighborNHWCBackKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNHWCBack with a 1d config.
+func (k NearestNeighborNHWCBackKernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// AdaGradKernel launches the AdaGrad kernel with typed args.
+type AdaGradKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches AdaGrad with a 1d config.
+func (k AdaGradKernel) Launch(cfg Config, s gocu.Streamer, length int32, weights, dw, gsum cutil.Mem, rate, eps, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, weights, dw, gsum, rate, eps, dwalpha)
+}
+
+// AdamKernel launches the Adam kernel with typed args.
+type AdamKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches Adam with a 1d config.
+func (k AdamKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, rate, beta1, beta2, eps, denombeta1, denombeta2, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, rate, beta1, beta2, eps, denombeta1, denombeta2, dwalpha)
+}
+
+// AdaDeltaKernel launches the AdaDelta kernel with typed args.
+type AdaDeltaKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches AdaDelta with a 1d config.
+func (k AdaDeltaKernel) Launch(cfg Config, s gocu.Streamer, length int32, weights, gsum, xsum, dw cutil.Mem, rate, eps, ro, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, weights, gsum, xsum, dw, rate, eps, ro, dwalpha)
+}
+
+// L1L2Kernel launches the L1L2 kernel with typed args.
+type L1L2Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches L1L2 with a 1d config.
+func (k L1L2Kernel) Launch(cfg Config, s gocu.Streamer, length int32, dw, w, l1, l2 cutil.Mem, batch, decay1, decay2 float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, dw, w, l1, l2, batch, decay1, decay2)
+}
+
+// ThreshForwardKernel launches the ThreshForward kernel with typed args.
+type ThreshForwardKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ThreshForward with a 1d config.
+func (k ThreshForwardKernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, x, y, negcoefs, threshhold, poscoefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, x, y, negcoefs, threshhold, poscoefs)
+}
+
+// ThreshBackwardKernel launches the ThreshBackward kernel with typed args.
+type ThreshBackwardKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ThreshBackward with a 1d config.
+func (k ThreshBackwardKernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, x, dx, dy, negcoefs, dnegcoefs, threshhold, dthreshhold, poscoefs, dposcoefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, x, dx, dy, negcoefs, dnegcoefs, threshhold, dthreshhold, poscoefs, dposcoefs)
+}
+
+// PreluForwardKernel launches the PreluForward kernel with typed args.
+type PreluForwardKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches PreluForward with a 1d config.
+func (k PreluForwardKernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, x, y, coefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, x, y, coefs)
+}
+
+// PreluBackwardKernel launches the PreluBackward kernel with typed args.
+type PreluBackwardKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches PreluBackward with a 1d config.
+func (k PreluBackwardKernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, dx, x, dy, coefs, dcoefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, dx, x, dy, coefs, dcoefs)
+}
+
+// LeakyForwardAlphaBetaKernel launches the LeakyForwardAlphaBeta kernel with typed args.
+type LeakyForwardAlphaBetaKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyForwardAlphaBeta with a 1d config.
+func (k LeakyForwardAlphaBetaKernel) Launch(cfg Config, s gocu.Streamer, length int32, x, y cutil.Mem, coef, alpha, beta float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, y, coef, alpha, beta)
+}
+
+// LeakyBackwardAlphaBetaKernel launches the LeakyBackwardAlphaBeta kernel with typed args.
+type LeakyBackwardAlphaBetaKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyBackwardAlphaBeta with a 1d config.
+func (k LeakyBackwardAlphaBetaKernel) Launch(cfg Config, s gocu.Streamer, length int32, x, dx, dy cutil.Mem, coef, alpha, beta float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, dx, dy, coef, alpha, beta)
+}
+
+// LeakyForwardAlphaKernel launches the LeakyForwardAlpha kernel with typed args.
+type LeakyForwardAlphaKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyForwardAlpha with a 1d config.
+func (k LeakyForwardAlphaKernel) Launch(cfg Config, s gocu.Streamer, length int32, x, y cutil.Mem, coef, alpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, y, coef, alpha)
+}
+
+// LeakyBackwardAlphaKernel launches the LeakyBackwardAlpha kernel with typed args.
+type LeakyBackwardAlphaKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyBackwardAlpha with a 1d config.
+func (k LeakyBackwardAlphaKernel) Launch(cfg Config, s gocu.Streamer, length int32, x, dx, dy cutil.Mem, coef, alpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, dx, dy, coef, alpha)
+}
+
+// LeakyForwardKernel launches the LeakyForward kernel with typed args.
+type LeakyForwardKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyForward with a 1d config.
+func (k LeakyForwardKernel) Launch(cfg Config, s gocu.Streamer, length int32, x, y cutil.Mem, coef float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, y, coef)
+}
+
+// LeakyBackwardKernel launches the LeakyBackward kernel with typed args.
+type LeakyBackwardKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyBackward with a 1d config.
+func (k LeakyBackwardKernel) Launch(cfg Config, s gocu.Streamer, length int32, x, dx, dy cutil.Mem, coef float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, dx, dy, coef)
+}
+
+// MSELossKernel launches the MSELoss kernel with typed args.
+type MSELossKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches MSELoss with a 1d config.
+func (k MSELossKernel) Launch(cfg Config, s gocu.Streamer, length int32, errors, target, networkout, loss cutil.Mem, alpha, beta float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, errors, target, networkout, loss, alpha, beta)
+}
+
+// MSELossbyBatchesKernel launches the MSELossbyBatches kernel with typed args.
+type MSELossbyBatchesKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches MSELossbyBatches with a 1d config.
+func (k MSELossbyBatchesKernel) Launch(cfg Config, s gocu.Streamer, xthreads, ythreads int32, errors, target, networkout, loss cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xthreads, ythreads, errors, target, networkout, loss)
+}
+
+// ConcatForwardNHWCEXKernel launches the ConcatForwardNHWCEX kernel with typed args.
+type ConcatForwardNHWCEXKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatForwardNHWCEX with a 1d config.
+func (k ConcatForwardNHWCEXKernel) Launch(cfg Config, s gocu.Streamer, XThreads, YThreads, Batches, ConcatBatchVolume int32, Channels, srcs, vols cutil.Mem, length int32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, YThreads, Batches, ConcatBatchVolume, Channels, srcs, vols, length, dest)
+}
+
+// ConcatBackwardNHWCEXKernel launches the ConcatBackwardNHWCEX kernel with typed args.
+type ConcatBackwardNHWCEXKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatBackwardNHWCEX with a 1d config.
+func (k ConcatBackwardNHWCEXKernel) Launch(cfg Config, s gocu.Streamer, XThreads, YThreads, Batches, ConcatBatchVolume int32, Channels, srcs, vols cutil.Mem, length int32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, YThreads, Batches, ConcatBatchVolume, Channels, srcs, vols, length, dest)
+}
+
+// ConcatForwardNCHWEXKernel launches the ConcatForwardNCHWEX kernel with typed args.
+type ConcatForwardNCHWEXKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatForwardNCHWEX with a 1d config.
+func (k ConcatForwardNCHWEXKernel) Launch(cfg Config, s gocu.Streamer, XThreads, YThreads, Batches, ConcatBatchVolume int32, Channels, srcs, vols cutil.Mem, numofsrcs int32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, YThreads, Batches, ConcatBatchVolume, Channels, srcs, vols, numofsrcs, dest)
+}
+
+// ConcatBackwardNCHWEXKernel launches the ConcatBackwardNCHWEX kernel with typed args.
+type ConcatBackwardNCHWEXKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatBackwardNCHWEX with a 1d config.
+func (k ConcatBackwardNCHWEXKernel) Launch(cfg Config, s gocu.Streamer, XThreads, YThreads, Batches, ConcatBatchVolume int32, Channels, srcs, vols cutil.Mem, numofsrcs int32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, YThreads, Batches, ConcatBatchVolume, Channels, srcs, vols, numofsrcs, dest)
+}
+
+// ConcatForwardNCHWKernel launches the ConcatForwardNCHW kernel with typed args.
+type ConcatForwardNCHWKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatForwardNCHW with a 1d config.
+func (k ConcatForwardNCHWKernel) Launch(cfg Config, s gocu.Streamer, XThreads, Batches, Channels1, src1vol int32, Src1 cutil.Mem, Channels2, src2vol int32, Src2, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, Batches, Channels1, src1vol, Src1, Channels2, src2vol, Src2, dest)
+}
+
+// ConcatBackwardNCHWKernel launches the ConcatBackwardNCHW kernel with typed args.
+type ConcatBackwardNCHWKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatBackwardNCHW with a 1d config.
+func (k ConcatBackwardNCHWKernel) Launch(cfg Config, s gocu.Streamer, XThreads, Batches, Channels1, src1vol int32, Src1 cutil.Mem, Channels2, src2vol int32, Src2, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, Batches, Channels1, src1vol, Src1, Channels2, src2vol, Src2, dest)
+}
+
+// ConcatForwardNCHWhalfKernel launches the ConcatForwardNCHWhalf kernel with typed args.
+type ConcatForwardNCHWhalfKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatForwardNCHWhalf with a 1d config.
+func (k ConcatForwardNCHWhalfKernel) Launch(cfg Config, s gocu.Streamer, XThreads, Batches, Channels1, src1vol int32, Src1 cutil.Mem, Channels2, src2vol int32, Src2, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, Batches, Channels1, src1vol, Src1, Channels2, src2vol, Src2, dest)
+}
+
+// ConcatBackwardNCHWhalfKernel launches the ConcatBackwardNCHWhalf kernel with typed args.
+type ConcatBackwardNCHWhalfKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatBackwardNCHWhalf with a 1d config.
+func (k ConcatBackwardNCHWhalfKernel) Launch(cfg Config, s gocu.Streamer, XThreads, Batches, Channels1, src1vol int32, Src1 cutil.Mem, Channels2, src2vol int32, Src2, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, Batches, Channels1, src1vol, Src1, Channels2, src2vol, Src2, dest)
+}
+
+// MakePlanarImageBatchesUint8Kernel launches the MakePlanarImageBatchesUint8 kernel with typed args.
+type MakePlanarImageBatchesUint8Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches MakePlanarImageBatchesUint8 with a 1d config.
+func (k MakePlanarImageBatchesUint8Kernel) Launch(cfg Config, s gocu.Streamer, XThreads, Batches, channelsperbatch int32, Srcs, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, Batches, channelsperbatch, Srcs, dest)
+}
+
+// TransposeFP16Kernel launches the TransposeFP16 kernel with typed args.
+type TransposeFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches TransposeFP16 with a 1d config.
+func (k TransposeFP16Kernel) Launch(cfg Config, s gocu.Streamer, numthreads int32, src, buf cutil.Mem, ndims int32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, numthreads, src, buf, ndims, dest)
+}
+
+// SwapEveryOtherFP16Kernel launches the SwapEveryOtherFP16 kernel with typed args.
+type SwapEveryOtherFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SwapEveryOtherFP16 with a 1d config.
+func (k SwapEveryOtherFP16Kernel) Launch(cfg Config, s gocu.Streamer, n, totalbatches int32, t1, t2 cutil.Mem, start, stride int32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, totalbatches, t1, t2, start, stride)
+}
+
+// SwapUpperLowerFP16Kernel launches the SwapUpperLowerFP16 kernel with typed args.
+type SwapUpperLowerFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SwapUpperLowerFP16 with a 1d config.
+func (k SwapUpperLowerFP16Kernel) Launch(cfg Config, s gocu.Streamer, xThreads, yThreads int32, t1, t2 cutil.Mem, t1upper, t2upper, inverse int32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xThreads, yThreads, t1, t2, t1upper, t2upper, inverse)
+}
+
+// ShapetoBatch4DNHWCFP16Kernel launches the ShapetoBatch4DNHWCFP16 kernel with typed args.
+type ShapetoBatch4DNHWCFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ShapetoBatch4DNHWCFP16 with a 1d config.
+func (k ShapetoBatch4DNHWCFP16Kernel) Launch(cfg Config, s gocu.Streamer, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride int32, shape, batch cutil.Mem, h_over_scan, w_over_scan int32, S2B bool) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride, shape, batch, h_over_scan, w_over_scan, S2B)
+}
+
+// ShapetoBatch4DNCHWFP16Kernel launches the ShapetoBatch4DNCHWFP16 kernel with typed args.
+type ShapetoBatch4DNCHWFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ShapetoBatch4DNCHWFP16 with a 1d config.
+func (k ShapetoBatch4DNCHWFP16Kernel) Launch(cfg Config, s gocu.Streamer, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride int32, shape, batch cutil.Mem, h_over_scan, w_over_scan int32, S2B bool) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xThreads, yThreads, zThreads, hSize, wSize, num_original_batches, BatchVolume, OriginalVol, N1, N2, hstride, wstride, shape, batch, h_over_scan, w_over_scan, S2B)
+}
+
+// NearestNeighborNCHWFP16Kernel launches the NearestNeighborNCHWFP16 kernel with typed args.
+type NearestNeighborNCHWFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNCHWFP16 with a 1d config.
+func (k NearestNeighborNCHWFP16Kernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// NearestNeighborNHWCBackFP16Kernel launches the NearestNeighborNHWCBackFP16 kernel with typed args.
+type NearestNeighborNHWCBackFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNHWCBackFP16 with a 1d config.
+func (k NearestNeighborNHWCBackFP16Kernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// NearestNeighborNHWCFP16Kernel launches the NearestNeighborNHWCFP16 kernel with typed args.
+type NearestNeighborNHWCFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNHWCFP16 with a 1d config.
+func (k NearestNeighborNHWCFP16Kernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// NearestNeighborNCHWBackFP16Kernel launches the NearestNeighborNCHWBackFP16 kernel with typed args.
+type NearestNeighborNCHWBackFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NearestNeighborNCHWBackFP16 with a 1d config.
+func (k NearestNeighborNCHWBackFP16Kernel) Launch(cfg Config, s gocu.Streamer, aligncorners, threads int32, src cutil.Mem, src_height, src_width, channels, dest_height, dest_width int32, height_scale, width_scale float32, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, aligncorners, threads, src, src_height, src_width, channels, dest_height, dest_width, height_scale, width_scale, dest)
+}
+
+// AdaGradFP16Kernel launches the AdaGradFP16 kernel with typed args.
+type AdaGradFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches AdaGradFP16 with a 1d config.
+func (k AdaGradFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, dw, gsum cutil.Mem, rate, eps, dwalpha half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, dw, gsum, rate, eps, dwalpha)
+}
+
+// AdamFP16Kernel launches the AdamFP16 kernel with typed args.
+type AdamFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches AdamFP16 with a 1d config.
+func (k AdamFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, rate, beta1, beta2, eps, denombeta1, denombeta2, dwalpha half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, rate, beta1, beta2, eps, denombeta1, denombeta2, dwalpha)
+}
+
+// AdaDeltaFP16Kernel launches the AdaDeltaFP16 kernel with typed args.
+type AdaDeltaFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches AdaDeltaFP16 with a 1d config.
+func (k AdaDeltaFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, rate, eps, ro, dwalpha half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, rate, eps, ro, dwalpha)
+}
+
+// L1L2FP16Kernel launches the L1L2FP16 kernel with typed args.
+type L1L2FP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches L1L2FP16 with a 1d config.
+func (k L1L2FP16Kernel) Launch(cfg Config, s gocu.Streamer, length int32, dw, w, l1, l2 cutil.Mem, batch, decay1, decay2 half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, dw, w, l1, l2, batch, decay1, decay2)
+}
+
+// ThreshForwardFP16Kernel launches the ThreshForwardFP16 kernel with typed args.
+type ThreshForwardFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ThreshForwardFP16 with a 1d config.
+func (k ThreshForwardFP16Kernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, x, y, negcoefs, threshhold, poscoefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, x, y, negcoefs, threshhold, poscoefs)
+}
+
+// ThreshBackwardFP16Kernel launches the ThreshBackwardFP16 kernel with typed args.
+type ThreshBackwardFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ThreshBackwardFP16 with a 1d config.
+func (k ThreshBackwardFP16Kernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, x, dx, dy, negcoefs, dnegcoefs, threshhold, poscoefs, dposcoefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, x, dx, dy, negcoefs, dnegcoefs, threshhold, poscoefs, dposcoefs)
+}
+
+// PreluForwardFP16Kernel launches the PreluForwardFP16 kernel with typed args.
+type PreluForwardFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches PreluForwardFP16 with a 1d config.
+func (k PreluForwardFP16Kernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, x, y, coefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, x, y, coefs)
+}
+
+// PreluBackwardFP16Kernel launches the PreluBackwardFP16 kernel with typed args.
+type PreluBackwardFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches PreluBackwardFP16 with a 1d config.
+func (k PreluBackwardFP16Kernel) Launch(cfg Config, s gocu.Streamer, XThreads, batchsize int32, dx, x, dy, coefs, dcoefs cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, batchsize, dx, x, dy, coefs, dcoefs)
+}
+
+// LeakyForwardAlphaBetaFP16Kernel launches the LeakyForwardAlphaBetaFP16 kernel with typed args.
+type LeakyForwardAlphaBetaFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyForwardAlphaBetaFP16 with a 1d config.
+func (k LeakyForwardAlphaBetaFP16Kernel) Launch(cfg Config, s gocu.Streamer, length int32, x, y cutil.Mem, coef, alpha, beta half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, y, coef, alpha, beta)
+}
+
+// LeakyBackwardAlphaBetaFP16Kernel launches the LeakyBackwardAlphaBetaFP16 kernel with typed args.
+type LeakyBackwardAlphaBetaFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyBackwardAlphaBetaFP16 with a 1d config.
+func (k LeakyBackwardAlphaBetaFP16Kernel) Launch(cfg Config, s gocu.Streamer, length int32, x, dx, dy cutil.Mem, coef, alpha, beta half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, dx, dy, coef, alpha, beta)
+}
+
+// LeakyForwardAlphaFP16Kernel launches the LeakyForwardAlphaFP16 kernel with typed args.
+type LeakyForwardAlphaFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyForwardAlphaFP16 with a 1d config.
+func (k LeakyForwardAlphaFP16Kernel) Launch(cfg Config, s gocu.Streamer, length int32, x, y cutil.Mem, coef, alpha half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, y, coef, alpha)
+}
+
+// LeakyBackwardAlphaFP16Kernel launches the LeakyBackwardAlphaFP16 kernel with typed args.
+type LeakyBackwardAlphaFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyBackwardAlphaFP16 with a 1d config.
+func (k LeakyBackwardAlphaFP16Kernel) Launch(cfg Config, s gocu.Streamer, length int32, x, dx, dy cutil.Mem, coef, alpha half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, dx, dy, coef, alpha)
+}
+
+// LeakyForwardFP16Kernel launches the LeakyForwardFP16 kernel with typed args.
+type LeakyForwardFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyForwardFP16 with a 1d config.
+func (k LeakyForwardFP16Kernel) Launch(cfg Config, s gocu.Streamer, length int32, x, y cutil.Mem, coef half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, y, coef)
+}
+
+// LeakyBackwardFP16Kernel launches the LeakyBackwardFP16 kernel with typed args.
+type LeakyBackwardFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LeakyBackwardFP16 with a 1d config.
+func (k LeakyBackwardFP16Kernel) Launch(cfg Config, s gocu.Streamer, length int32, x, dx, dy cutil.Mem, coef half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, length, x, dx, dy, coef)
+}
+
+// MSELossbyBatchesFP16Kernel launches the MSELossbyBatchesFP16 kernel with typed args.
+type MSELossbyBatchesFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches MSELossbyBatchesFP16 with a 1d config.
+func (k MSELossbyBatchesFP16Kernel) Launch(cfg Config, s gocu.Streamer, xthreads, ythreads int32, errors, target, networkout, loss cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, xthreads, ythreads, errors, target, networkout, loss)
+}
+
+// MSELossFP16Kernel launches the MSELossFP16 kernel with typed args.
+type MSELossFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches MSELossFP16 with a 1d config.
+func (k MSELossFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, errors, target, networkout, loss cutil.Mem, alpha, beta half.Float16) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, errors, target, networkout, loss, alpha, beta)
+}
+
+// SumSquaresKernel launches the SumSquares kernel with typed args.
+type SumSquaresKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SumSquares with a 1d config.
+func (k SumSquaresKernel) Launch(cfg Config, s gocu.Streamer, n int32, x, sumsq cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, sumsq)
+}
+
+// SumSquaresFP16Kernel launches the SumSquaresFP16 kernel with typed args.
+type SumSquaresFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SumSquaresFP16 with a 1d config.
+func (k SumSquaresFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, x, sumsq cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, sumsq)
+}
+
+// ScaleByNormKernel launches the ScaleByNorm kernel with typed args.
+type ScaleByNormKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ScaleByNorm with a 1d config.
+func (k ScaleByNormKernel) Launch(cfg Config, s gocu.Streamer, n int32, x, sumsq cutil.Mem, maxnorm float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, sumsq, maxnorm)
+}
+
+// ScaleByNormFP16Kernel launches the ScaleByNormFP16 kernel with typed args.
+type ScaleByNormFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ScaleByNormFP16 with a 1d config.
+func (k ScaleByNormFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, x, sumsq cutil.Mem, maxnorm float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, sumsq, maxnorm)
+}
+
+// ClipByValueKernel launches the ClipByValue kernel with typed args.
+type ClipByValueKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ClipByValue with a 1d config.
+func (k ClipByValueKernel) Launch(cfg Config, s gocu.Streamer, n int32, x cutil.Mem, min, max float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, min, max)
+}
+
+// ClipByValueFP16Kernel launches the ClipByValueFP16 kernel with typed args.
+type ClipByValueFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ClipByValueFP16 with a 1d config.
+func (k ClipByValueFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, x cutil.Mem, min, max float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, min, max)
+}
+
+// ConcatForwardNCHWFP16Kernel launches the ConcatForwardNCHWFP16 kernel with typed args.
+type ConcatForwardNCHWFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatForwardNCHWFP16 with a 1d config.
+func (k ConcatForwardNCHWFP16Kernel) Launch(cfg Config, s gocu.Streamer, XThreads, Batches, Channels1, src1vol int32, Src1 cutil.Mem, Channels2, src2vol int32, Src2, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, Batches, Channels1, src1vol, Src1, Channels2, src2vol, Src2, dest)
+}
+
+// ConcatBackwardNCHWFP16Kernel launches the ConcatBackwardNCHWFP16 kernel with typed args.
+type ConcatBackwardNCHWFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches ConcatBackwardNCHWFP16 with a 1d config.
+func (k ConcatBackwardNCHWFP16Kernel) Launch(cfg Config, s gocu.Streamer, XThreads, Batches, Channels1, src1vol int32, Src1 cutil.Mem, Channels2, src2vol int32, Src2, dest cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, XThreads, Batches, Channels1, src1vol, Src1, Channels2, src2vol, Src2, dest)
+}
+
+// EMAUpdateKernel launches the EMAUpdate kernel with typed args.
+type EMAUpdateKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches EMAUpdate with a 1d config.
+func (k EMAUpdateKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, shadow cutil.Mem, decay float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, shadow, decay)
+}
+
+// EMAUpdateFP16Kernel launches the EMAUpdateFP16 kernel with typed args.
+type EMAUpdateFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches EMAUpdateFP16 with a 1d config.
+func (k EMAUpdateFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, shadow cutil.Mem, decay float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, shadow, decay)
+}
+
+// SwapValuesKernel launches the SwapValues kernel with typed args.
+type SwapValuesKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SwapValues with a 1d config.
+func (k SwapValuesKernel) Launch(cfg Config, s gocu.Streamer, n int32, x, y cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, y)
+}
+
+// SwapValuesFP16Kernel launches the SwapValuesFP16 kernel with typed args.
+type SwapValuesFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches SwapValuesFP16 with a 1d config.
+func (k SwapValuesFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, x, y cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, y)
+}
+
+// LossScaleKernel launches the LossScale kernel with typed args.
+type LossScaleKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LossScale with a 1d config.
+func (k LossScaleKernel) Launch(cfg Config, s gocu.Streamer, n int32, x cutil.Mem, scale float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, scale)
+}
+
+// LossScaleFP16Kernel launches the LossScaleFP16 kernel with typed args.
+type LossScaleFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LossScaleFP16 with a 1d config.
+func (k LossScaleFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, x cutil.Mem, scale float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, scale)
+}
+
+// UnscaleCheckKernel launches the UnscaleCheck kernel with typed args.
+type UnscaleCheckKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches UnscaleCheck with a 1d config.
+func (k UnscaleCheckKernel) Launch(cfg Config, s gocu.Streamer, n int32, x cutil.Mem, unscale float32, found cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, unscale, found)
+}
+
+// UnscaleCheckFP16Kernel launches the UnscaleCheckFP16 kernel with typed args.
+type UnscaleCheckFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches UnscaleCheckFP16 with a 1d config.
+func (k UnscaleCheckFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, x cutil.Mem, unscale float32, found cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, x, unscale, found)
+}
+
+// Segment1stDimKernel launches the Segment1stDim kernel with typed args.
+type Segment1stDimKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches Segment1stDim with a 1d config.
+func (k Segment1stDimKernel) Launch(cfg Config, s gocu.Streamer, start_index int32, src, dst cutil.Mem, size int32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, start_index, src, dst, size)
+}
+
+// Segment1stDimFP16Kernel launches the Segment1stDimFP16 kernel with typed args.
+type Segment1stDimFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches Segment1stDimFP16 with a 1d config.
+func (k Segment1stDimFP16Kernel) Launch(cfg Config, s gocu.Streamer, start_index int32, src, dst cutil.Mem, size int32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, start_index, src, dst, size)
+}
+
+// AdamWKernel launches the AdamW kernel with typed args.
+type AdamWKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches AdamW with a 1d config.
+func (k AdamWKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, rate, beta1, beta2, eps, decay, denombeta1, denombeta2, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, rate, beta1, beta2, eps, decay, denombeta1, denombeta2, dwalpha)
+}
+
+// AdamWFP16Kernel launches the AdamWFP16 kernel with typed args.
+type AdamWFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches AdamWFP16 with a 1d config.
+func (k AdamWFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, rate, beta1, beta2, eps, decay, denombeta1, denombeta2, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, rate, beta1, beta2, eps, decay, denombeta1, denombeta2, dwalpha)
+}
+
+// LAMBMomentsKernel launches the LAMBMoments kernel with typed args.
+type LAMBMomentsKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LAMBMoments with a 1d config.
+func (k LAMBMomentsKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, beta1, beta2, eps, decay, denombeta1, denombeta2 float32, norms cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, beta1, beta2, eps, decay, denombeta1, denombeta2, norms)
+}
+
+// LAMBMomentsFP16Kernel launches the LAMBMomentsFP16 kernel with typed args.
+type LAMBMomentsFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LAMBMomentsFP16 with a 1d config.
+func (k LAMBMomentsFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, beta1, beta2, eps, decay, denombeta1, denombeta2 float32, norms cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, beta1, beta2, eps, decay, denombeta1, denombeta2, norms)
+}
+
+// LAMBUpdateKernel launches the LAMBUpdate kernel with typed args.
+type LAMBUpdateKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LAMBUpdate with a 1d config.
+func (k LAMBUpdateKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, rate, eps, decay, denombeta1, denombeta2, dwalpha float32, norms cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, rate, eps, decay, denombeta1, denombeta2, dwalpha, norms)
+}
+
+// LAMBUpdateFP16Kernel launches the LAMBUpdateFP16 kernel with typed args.
+type LAMBUpdateFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches LAMBUpdateFP16 with a 1d config.
+func (k LAMBUpdateFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, gsum, xsum, dw cutil.Mem, rate, eps, decay, denombeta1, denombeta2, dwalpha float32, norms cutil.Mem) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, gsum, xsum, dw, rate, eps, decay, denombeta1, denombeta2, dwalpha, norms)
+}
+
+// MomentumKernel launches the Momentum kernel with typed args.
+type MomentumKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches Momentum with a 1d config.
+func (k MomentumKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, dw, vel cutil.Mem, rate, momentum, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, dw, vel, rate, momentum, dwalpha)
+}
+
+// MomentumFP16Kernel launches the MomentumFP16 kernel with typed args.
+type MomentumFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches MomentumFP16 with a 1d config.
+func (k MomentumFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, dw, vel cutil.Mem, rate, momentum, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, dw, vel, rate, momentum, dwalpha)
+}
+
+// NesterovKernel launches the Nesterov kernel with typed args.
+type NesterovKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches Nesterov with a 1d config.
+func (k NesterovKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, dw, vel cutil.Mem, rate, momentum, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, dw, vel, rate, momentum, dwalpha)
+}
+
+// NesterovFP16Kernel launches the NesterovFP16 kernel with typed args.
+type NesterovFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches NesterovFP16 with a 1d config.
+func (k NesterovFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, dw, vel cutil.Mem, rate, momentum, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, dw, vel, rate, momentum, dwalpha)
+}
+
+// RMSPropKernel launches the RMSProp kernel with typed args.
+type RMSPropKernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches RMSProp with a 1d config.
+func (k RMSPropKernel) Launch(cfg Config, s gocu.Streamer, n int32, w, dw, ms cutil.Mem, rate, decay, eps, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, dw, ms, rate, decay, eps, dwalpha)
+}
+
+// RMSPropFP16Kernel launches the RMSPropFP16 kernel with typed args.
+type RMSPropFP16Kernel struct {
+	*cuda.Kernel
+}
+
+// Launch launches RMSPropFP16 with a 1d config.
+func (k RMSPropFP16Kernel) Launch(cfg Config, s gocu.Streamer, n int32, w, dw, ms cutil.Mem, rate, decay, eps, dwalpha float32) error {
+	return k.Kernel.Launch(cfg.BlockCount, 1, 1, cfg.ThreadPerBlock, 1, 1, 0, s, n, w, dw, ms, rate, decay, eps, dwalpha)
+}